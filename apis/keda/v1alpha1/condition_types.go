@@ -34,6 +34,9 @@ const (
 	ConditionFallback ConditionType = "Fallback"
 	// ConditionPaused specifies that the resource is paused.
 	ConditionPaused ConditionType = "Paused"
+	// ConditionDegraded specifies that the resource is working in a degraded mode.
+	// It is optional and added only when it's needed.
+	ConditionDegraded ConditionType = "Degraded"
 )
 
 const (
@@ -56,6 +59,19 @@ const (
 	ScaledJobConditionPausedMessage = "ScaledJob is paused"
 	// ScaledJobConditionPausedMessage defines the default Message for paused ScaledJob
 	ScaledJobConditionUnpausedMessage = "ScaledJob is unpaused"
+	// ScaledJobConditionFailureBackoffReason defines the Reason for ScaledJob with paused jobs creation because of failing jobs
+	ScaledJobConditionFailureBackoffReason = "JobsFailureBackoff"
+	// ScaledJobConditionJobsSucceedingReason defines the Reason for ScaledJob with resumed jobs creation
+	ScaledJobConditionJobsSucceedingReason = "JobsSucceeding"
+)
+
+const (
+	// TriggerAuthenticationConditionReadySuccessReason defines the Reason for successfully resolved TriggerAuthentication
+	TriggerAuthenticationConditionReadySuccessReason = "AuthenticationResolved"
+	// TriggerAuthenticationConditionReadySuccessMessage defines the Message for successfully resolved TriggerAuthentication
+	TriggerAuthenticationConditionReadySuccessMessage = "All authentication sources were resolved successfully"
+	// TriggerAuthenticationConditionReadyFailedReason defines the Reason for TriggerAuthentication with failing sources
+	TriggerAuthenticationConditionReadyFailedReason = "AuthenticationResolutionFailed"
 )
 
 // Condition to store the condition state
@@ -179,6 +195,18 @@ func (c *Conditions) SetPausedCondition(status metav1.ConditionStatus, reason st
 	c.setCondition(ConditionPaused, status, reason, message)
 }
 
+// SetDegradedCondition modifies Degraded Condition according to input parameters,
+// the condition is added if it isn't present yet
+func (c *Conditions) SetDegradedCondition(status metav1.ConditionStatus, reason string, message string) {
+	if *c == nil {
+		*c = *GetInitializedConditions()
+	}
+	if c.getCondition(ConditionDegraded).Type == "" {
+		*c = append(*c, Condition{Type: ConditionDegraded, Status: metav1.ConditionUnknown})
+	}
+	c.setCondition(ConditionDegraded, status, reason, message)
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
@@ -211,6 +239,14 @@ func (c *Conditions) GetPausedCondition() Condition {
 	return c.getCondition(ConditionPaused)
 }
 
+// GetDegradedCondition returns Condition of type Degraded
+func (c *Conditions) GetDegradedCondition() Condition {
+	if *c == nil {
+		c = GetInitializedConditions()
+	}
+	return c.getCondition(ConditionDegraded)
+}
+
 func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	for i := range c {
 		if c[i].Type == conditionType {
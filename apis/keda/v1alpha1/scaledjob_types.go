@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -24,6 +26,11 @@ import (
 const (
 	defaultScaledJobMaxReplicaCount = 100
 	defaultScaledJobMinReplicaCount = 0
+	// defaultFailedJobsHistoryLimit matches the limit applied by the executor when FailedJobsHistoryLimit isn't set
+	defaultFailedJobsHistoryLimit = 100
+
+	defaultFailureBackoffInitialDelaySeconds = 30
+	defaultFailureBackoffMaxDelaySeconds     = 3600
 )
 
 // +genclient
@@ -69,7 +76,9 @@ type ScaledJobSpec struct {
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
 	ScalingStrategy ScalingStrategy `json:"scalingStrategy,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	// +optional
+	FailureBackoff *FailureBackoff `json:"failureBackoff,omitempty"`
+	Triggers       []ScaleTriggers `json:"triggers"`
 }
 
 // ScaledJobStatus defines the observed state of ScaledJob
@@ -104,6 +113,20 @@ type ScalingStrategy struct {
 	PendingPodConditions []string `json:"pendingPodConditions,omitempty"`
 	// +optional
 	MultipleScalersCalculation string `json:"multipleScalersCalculation,omitempty"`
+	// +optional
+	Formula string `json:"formula,omitempty"`
+}
+
+// FailureBackoff defines when and for how long the creation of new jobs is paused
+// once the created jobs keep failing
+// +optional
+type FailureBackoff struct {
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold"`
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// +optional
+	MaxDelaySeconds *int32 `json:"maxDelaySeconds,omitempty"`
 }
 
 // Rollout defines the strategy for job rollouts
@@ -143,6 +166,27 @@ func (s ScaledJob) MinReplicaCount() int64 {
 	return defaultScaledJobMinReplicaCount
 }
 
+// GetInitialDelay returns the delay applied once FailureThreshold is reached
+func (f *FailureBackoff) GetInitialDelay() time.Duration {
+	if f.InitialDelaySeconds != nil {
+		return time.Duration(*f.InitialDelaySeconds) * time.Second
+	}
+	return defaultFailureBackoffInitialDelaySeconds * time.Second
+}
+
+// GetMaxDelay returns the upper bound of the exponential backoff
+func (f *FailureBackoff) GetMaxDelay() time.Duration {
+	if f.MaxDelaySeconds != nil {
+		return time.Duration(*f.MaxDelaySeconds) * time.Second
+	}
+	return defaultFailureBackoffMaxDelaySeconds * time.Second
+}
+
+// IsUsingFormula returns whether the ScaledJob aggregates its triggers with a custom formula
+func (s *ScaledJob) IsUsingFormula() bool {
+	return s.Spec.ScalingStrategy.Formula != ""
+}
+
 func (s *ScaledJob) GenerateIdentifier() string {
 	return GenerateIdentifier("ScaledJob", s.Namespace, s.Name)
 }
@@ -18,14 +18,19 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metricscollector "github.com/kedacore/keda/v2/pkg/metricscollector/webhook"
 )
 
 var scaledjoblog = logf.Log.WithName("scaledjob-validation-webhook")
@@ -44,7 +49,7 @@ var _ webhook.Validator = &ScaledJob{}
 func (s *ScaledJob) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(s, "", "  ")
 	scaledjoblog.Info(fmt.Sprintf("validating scaledjob creation for %s", string(val)))
-	return nil, verifyTriggers(s, "create", false)
+	return nil, validateScaledJob(s, "create")
 }
 
 func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -56,7 +61,7 @@ func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 		scaledjoblog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return nil, verifyTriggers(s, "update", false)
+	return nil, validateScaledJob(s, "update")
 }
 
 func (s *ScaledJob) ValidateDelete() (admission.Warnings, error) {
@@ -71,3 +76,114 @@ func isScaledJobRemovingFinalizer(om metav1.ObjectMeta, oldOm metav1.ObjectMeta,
 
 	return len(om.Finalizers) == 0 && len(oldOm.Finalizers) == 1 && taSpecString == oldTaSpecString
 }
+
+func validateScaledJob(s *ScaledJob, action string) error {
+	metricscollector.RecordScaledJobValidatingTotal(s.Namespace, action)
+
+	if err := verifyTriggers(s, action, false); err != nil {
+		return err
+	}
+	if err := verifyFailureBackoff(s, action); err != nil {
+		return err
+	}
+	if err := verifyScaledJobInlineSecrets(s, action); err != nil {
+		return err
+	}
+	return verifyScalingStrategyFormula(s, action)
+}
+
+// verifyScaledJobInlineSecrets checks that the literal environment variables read by the TriggerAuthentications of the triggers
+// are encrypted when KEDA requires inline secrets to be encrypted
+func verifyScaledJobInlineSecrets(s *ScaledJob, action string) error {
+	if s.Spec.JobTargetRef == nil {
+		return nil
+	}
+	if err := validateInlineEnvSecrets(s.Namespace, s.Spec.Triggers, &s.Spec.JobTargetRef.Template.Spec); err != nil {
+		scaledjoblog.WithValues("name", s.Name).Error(err, "validation error")
+		metricscollector.RecordScaledJobValidatingErrors(s.Namespace, action, "plaintext-inline-secret")
+		return err
+	}
+	return nil
+}
+
+func verifyFailureBackoff(s *ScaledJob, action string) error {
+	fb := s.Spec.FailureBackoff
+	if fb == nil {
+		return nil
+	}
+
+	// the failed jobs are counted among the ones kept by FailedJobsHistoryLimit, a higher threshold would never be reached
+	failedJobsHistoryLimit := int32(defaultFailedJobsHistoryLimit)
+	if s.Spec.FailedJobsHistoryLimit != nil {
+		failedJobsHistoryLimit = *s.Spec.FailedJobsHistoryLimit
+	}
+
+	var err error
+	switch {
+	case fb.FailureThreshold < 1:
+		err = fmt.Errorf("FailureBackoff.FailureThreshold must be greater than 0")
+	case fb.FailureThreshold > failedJobsHistoryLimit:
+		err = fmt.Errorf("FailureBackoff.FailureThreshold must be less than or equal to FailedJobsHistoryLimit (%d)", failedJobsHistoryLimit)
+	case fb.InitialDelaySeconds != nil && *fb.InitialDelaySeconds < 1:
+		err = fmt.Errorf("FailureBackoff.InitialDelaySeconds must be greater than 0")
+	case fb.GetMaxDelay() < fb.GetInitialDelay():
+		err = fmt.Errorf("FailureBackoff.MaxDelaySeconds must be greater than or equal to FailureBackoff.InitialDelaySeconds")
+	}
+	if err != nil {
+		scaledjoblog.WithValues("name", s.Name).Error(err, "validation error")
+		metricscollector.RecordScaledJobValidatingErrors(s.Namespace, action, "incorrect-failure-backoff")
+	}
+	return err
+}
+
+func verifyScalingStrategyFormula(s *ScaledJob, action string) error {
+	if !s.IsUsingFormula() {
+		return nil
+	}
+	_, err := ValidateAndCompileScalingStrategyFormula(s)
+	if err != nil {
+		scaledjoblog.WithValues("name", s.Name).Error(err, "error validating ScalingStrategy formula")
+		metricscollector.RecordScaledJobValidatingErrors(s.Namespace, action, "scaling-strategy-formula")
+	}
+	return err
+}
+
+// ValidateAndCompileScalingStrategyFormula validates the formula given in
+// ScalingStrategy and compiles it (with dummy values that determine whether
+// all necessary triggers are defined). The compiled formula is returned to be
+// stored in cache and reused.
+func ValidateAndCompileScalingStrategyFormula(s *ScaledJob) (*vm.Program, error) {
+	ss := s.Spec.ScalingStrategy
+
+	if ss.Formula == "" {
+		return nil, fmt.Errorf("error ScalingStrategy.Formula is mandatory")
+	}
+	if ss.MultipleScalersCalculation != "" {
+		return nil, fmt.Errorf("ScalingStrategy.Formula and ScalingStrategy.MultipleScalersCalculation can't be used together")
+	}
+
+	// cast return value of formula to float if necessary to avoid wrong value return
+	// type (ternary operator doesnt return float)
+	s.Spec.ScalingStrategy.Formula = castToFloatIfNecessary(ss.Formula)
+
+	// dummy value for compiled map of triggers
+	dummyValue := -1.0
+
+	// Compile & Run with dummy values to determine if all triggers in formula are
+	// defined (have names)
+	triggersMap := make(map[string]float64)
+	for _, trig := range s.Spec.Triggers {
+		if trig.Name != "" {
+			triggersMap[trig.Name] = dummyValue
+		}
+	}
+	compiled, err := expr.Compile(s.Spec.ScalingStrategy.Formula, expr.Env(triggersMap), expr.AsFloat64())
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error validating formula in ScalingStrategy"), err)
+	}
+	_, err = expr.Run(compiled, triggersMap)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error validating formula in ScalingStrategy"), err)
+	}
+	return compiled, nil
+}
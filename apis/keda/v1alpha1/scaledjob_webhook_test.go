@@ -18,12 +18,16 @@ package v1alpha1
 
 import (
 	"context"
+	"os"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kedacore/keda/v2/pkg/kms"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 var _ = It("should validate empty triggers in ScaledJob", func() {
@@ -41,6 +45,52 @@ var _ = It("should validate empty triggers in ScaledJob", func() {
 	}).Should(HaveOccurred())
 })
 
+var _ = It("should validate the sj creation with ScalingStrategy.Formula", func() {
+	namespaceName := "scaledjob-formula-good"
+	namespace := createNamespace(namespaceName)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	sj := createScaledJob(sjName, namespaceName, createScaledJobFormulaTriggers())
+	sj.Spec.ScalingStrategy.Formula = "max(queue_trig, cron_trig * 0.5)"
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), sj)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("shouldnt validate the sj creation with ScalingStrategy.Formula referencing unknown triggers", func() {
+	namespaceName := "scaledjob-formula-unknown-trigger-bad"
+	namespace := createNamespace(namespaceName)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	sj := createScaledJob(sjName, namespaceName, createScaledJobFormulaTriggers())
+	sj.Spec.ScalingStrategy.Formula = "queue_trig + missing_trig"
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), sj)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("shouldnt validate the sj creation with ScalingStrategy.Formula and MultipleScalersCalculation", func() {
+	namespaceName := "scaledjob-formula-and-calculation-bad"
+	namespace := createNamespace(namespaceName)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	sj := createScaledJob(sjName, namespaceName, createScaledJobFormulaTriggers())
+	sj.Spec.ScalingStrategy.Formula = "queue_trig + cron_trig"
+	sj.Spec.ScalingStrategy.MultipleScalersCalculation = "sum"
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), sj)
+	}).Should(HaveOccurred())
+})
+
 // -------------------------------------------------------------------------- //
 // ----------------------------- HELP FUNCTIONS ----------------------------- //
 // -------------------------------------------------------------------------- //
@@ -81,3 +131,72 @@ func createScaledJob(name string, namespace string, triggers []ScaleTriggers) *S
 		},
 	}
 }
+
+func createScaledJobFormulaTriggers() []ScaleTriggers {
+	return []ScaleTriggers{
+		{
+			Type: "cron",
+			Name: "cron_trig",
+			Metadata: map[string]string{
+				"timezone":        "UTC",
+				"start":           "0 * * * *",
+				"end":             "1 * * * *",
+				"desiredReplicas": "1",
+			},
+		},
+		{
+			Type: "kubernetes-workload",
+			Name: "queue_trig",
+			Metadata: map[string]string{
+				"podSelector": "pod=workload-test",
+				"value":       "1",
+			},
+		},
+	}
+}
+
+var _ = It("shouldnt validate the sj creation with FailureBackoff.FailureThreshold above FailedJobsHistoryLimit", func() {
+	namespaceName := "scaledjob-failure-threshold-above-history-bad"
+	namespace := createNamespace(namespaceName)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	failedJobsHistoryLimit := int32(3)
+	sj := createScaledJob(sjName, namespaceName, []ScaleTriggers{{Type: "cron", Metadata: map[string]string{"timezone": "UTC", "start": "0 * * * *", "end": "1 * * * *", "desiredReplicas": "1"}}})
+	sj.Spec.FailedJobsHistoryLimit = &failedJobsHistoryLimit
+	sj.Spec.FailureBackoff = &FailureBackoff{FailureThreshold: 5}
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), sj)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("shouldnt validate the sj creation with a plaintext env literal read by its TriggerAuthentication when encrypted inline secrets are required", func() {
+	namespaceName := "scaledjob-plaintext-env-bad"
+	namespace := createNamespace(namespaceName)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	ta := createTriggerAuthentication("plaintextenvta", namespaceName, "TriggerAuthentication", TriggerAuthenticationSpec{
+		Env: []AuthEnvironment{{Parameter: "token", Name: "TOKEN"}},
+	})
+	Expect(k8sClient.Create(context.Background(), ta)).To(Succeed())
+
+	Expect(os.Setenv(util.RequireEncryptedInlineSecretsEnvVar, "true")).To(Succeed())
+	defer os.Unsetenv(util.RequireEncryptedInlineSecretsEnvVar)
+
+	triggers := []ScaleTriggers{{Type: "cron", Metadata: map[string]string{"timezone": "UTC", "start": "0 * * * *", "end": "1 * * * *", "desiredReplicas": "1"}, AuthenticationRef: &AuthenticationRef{Name: "plaintextenvta"}}}
+	sj := createScaledJob(sjName, namespaceName, triggers)
+	sj.Spec.JobTargetRef.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "TOKEN", Value: "plaintext-token"}}
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), sj)
+	}).Should(HaveOccurred())
+
+	sj.Spec.JobTargetRef.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "TOKEN", Value: kms.EnvelopePrefix + "eyJwcm92aWRlciI6ImFlcy1rZXlmaWxlIn0"}}
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), sj)
+	}).ShouldNot(HaveOccurred())
+})
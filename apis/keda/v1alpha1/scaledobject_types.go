@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -95,6 +97,8 @@ type ScaledObjectSpec struct {
 	// +optional
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
+	ReplicaCountSchedules []ReplicaCountSchedule `json:"replicaCountSchedules,omitempty"`
+	// +optional
 	Advanced *AdvancedConfig `json:"advanced,omitempty"`
 
 	Triggers []ScaleTriggers `json:"triggers"`
@@ -108,6 +112,19 @@ type Fallback struct {
 	Replicas         int32 `json:"replicas"`
 }
 
+// ReplicaCountSchedule overrides minReplicaCount and/or maxReplicaCount within a recurring time window,
+// the window starts and ends according to the cron expressions
+type ReplicaCountSchedule struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+}
+
 // AdvancedConfig specifies advance scaling options
 type AdvancedConfig struct {
 	// +optional
@@ -116,6 +133,31 @@ type AdvancedConfig struct {
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 	// +optional
 	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// +optional
+	DirectScaling bool `json:"directScaling,omitempty"`
+	// +optional
+	ZeroScalingStaging *ZeroScalingStaging `json:"zeroScalingStaging,omitempty"`
+}
+
+// ZeroScalingStaging configures gradual scaling from and to zero (or idle) replicas
+type ZeroScalingStaging struct {
+	// ActivationReplicaCount is the replica count the target is scaled to from zero (or idle) replicas,
+	// max(minReplicaCount, 1) is used if it isn't set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActivationReplicaCount *int32 `json:"activationReplicaCount,omitempty"`
+	// ScaleDownSteps are replica counts the target is kept on, one after another, once the cooldown period passes
+	// and before it is scaled to zero (or idle) replicas. When the HPA is used, steps can't go below minReplicaCount.
+	// +optional
+	ScaleDownSteps []ScaleDownStep `json:"scaleDownSteps,omitempty"`
+}
+
+// ScaleDownStep is a replica count the target is kept on for the duration during staged scale to zero (or idle) replicas
+type ScaleDownStep struct {
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas"`
+	// +kubebuilder:validation:Minimum=0
+	DurationSeconds int32 `json:"durationSeconds"`
 }
 
 // ScalingModifiers describes advanced scaling logic options like formula
@@ -174,6 +216,30 @@ type ScaledObjectStatus struct {
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
 	// +optional
 	HpaName string `json:"hpaName,omitempty"`
+	// +optional
+	ScalingStage *ScalingStageStatus `json:"scalingStage,omitempty"`
+}
+
+// ScalingStage is the stage of staged scaling from or to zero (or idle) replicas
+type ScalingStage string
+
+const (
+	// ScalingStageActivation means the target was scaled from zero (or idle) replicas to the activation replica count
+	ScalingStageActivation ScalingStage = "Activation"
+
+	// ScalingStageScaleDown means the target is kept on one of the scale down steps
+	ScalingStageScaleDown ScalingStage = "ScaleDown"
+)
+
+// ScalingStageStatus describes the current stage of staged scaling from or to zero (or idle) replicas
+type ScalingStageStatus struct {
+	Stage ScalingStage `json:"stage"`
+	// Step is the index of the current scale down step
+	// +optional
+	Step int32 `json:"step,omitempty"`
+	// Replicas is the replica count the target was scaled to at the beginning of the stage
+	Replicas  int32       `json:"replicas"`
+	StartTime metav1.Time `json:"startTime"`
 }
 
 // +kubebuilder:object:root=true
@@ -230,10 +296,31 @@ func (so *ScaledObject) IsUsingModifiers() bool {
 	return so.Spec.Advanced != nil && !reflect.DeepEqual(so.Spec.Advanced.ScalingModifiers, ScalingModifiers{})
 }
 
+// IsUsingDirectScaling determines whether the scale target is scaled directly by KEDA instead of HPA
+func (so *ScaledObject) IsUsingDirectScaling() bool {
+	return so.Spec.Advanced != nil && so.Spec.Advanced.DirectScaling
+}
+
+// GetHPABehavior returns the scaling behavior defined in HorizontalPodAutoscalerConfig, if any
+func (so *ScaledObject) GetHPABehavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	if so.Spec.Advanced != nil && so.Spec.Advanced.HorizontalPodAutoscalerConfig != nil {
+		return so.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior
+	}
+	return nil
+}
+
+// GetZeroScalingStaging returns the staging configuration for scaling from and to zero (or idle) replicas, if any
+func (so *ScaledObject) GetZeroScalingStaging() *ZeroScalingStaging {
+	if so.Spec.Advanced != nil {
+		return so.Spec.Advanced.ZeroScalingStaging
+	}
+	return nil
+}
+
 // getHPAMinReplicas returns MinReplicas based on definition in ScaledObject or default value if not defined
 func (so *ScaledObject) GetHPAMinReplicas() *int32 {
-	if so.Spec.MinReplicaCount != nil && *so.Spec.MinReplicaCount > 0 {
-		return so.Spec.MinReplicaCount
+	if minReplicaCount := so.GetMinReplicaCount(); minReplicaCount != nil && *minReplicaCount > 0 {
+		return minReplicaCount
 	}
 	tmp := defaultHPAMinReplicas
 	return &tmp
@@ -241,27 +328,186 @@ func (so *ScaledObject) GetHPAMinReplicas() *int32 {
 
 // getHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined
 func (so *ScaledObject) GetHPAMaxReplicas() int32 {
-	if so.Spec.MaxReplicaCount != nil {
-		return *so.Spec.MaxReplicaCount
+	if maxReplicaCount := so.GetMaxReplicaCount(); maxReplicaCount != nil {
+		return *maxReplicaCount
+	}
+	return defaultHPAMaxReplicas
+}
+
+// GetMinReplicaCount returns MinReplicaCount, overridden by the currently active replica count schedule if there is any
+func (so *ScaledObject) GetMinReplicaCount() *int32 {
+	if schedule := so.GetActiveReplicaCountSchedule(time.Now()); schedule != nil && schedule.MinReplicaCount != nil {
+		return schedule.MinReplicaCount
+	}
+	return so.Spec.MinReplicaCount
+}
+
+// GetMaxReplicaCount returns MaxReplicaCount, overridden by the currently active replica count schedule if there is any
+func (so *ScaledObject) GetMaxReplicaCount() *int32 {
+	if schedule := so.GetActiveReplicaCountSchedule(time.Now()); schedule != nil && schedule.MaxReplicaCount != nil {
+		return schedule.MaxReplicaCount
+	}
+	return so.Spec.MaxReplicaCount
+}
+
+// GetActiveReplicaCountSchedule returns the first replica count schedule whose time window contains now,
+// nil is returned if there isn't any. Invalid schedules are skipped, they are rejected by the webhook.
+func (so *ScaledObject) GetActiveReplicaCountSchedule(now time.Time) *ReplicaCountSchedule {
+	for i := range so.Spec.ReplicaCountSchedules {
+		active, _, err := so.Spec.ReplicaCountSchedules[i].Evaluate(now)
+		if err == nil && active {
+			return &so.Spec.ReplicaCountSchedules[i]
+		}
+	}
+	return nil
+}
+
+// GetNextReplicaCountScheduleTransition returns the time when the next replica count schedule window starts or ends,
+// zero time is returned if there aren't any schedules
+func (so *ScaledObject) GetNextReplicaCountScheduleTransition(now time.Time) time.Time {
+	next := time.Time{}
+	for _, schedule := range so.Spec.ReplicaCountSchedules {
+		_, transition, err := schedule.Evaluate(now)
+		if err != nil {
+			continue
+		}
+		if next.IsZero() || transition.Before(next) {
+			next = transition
+		}
+	}
+	return next
+}
+
+var replicaCountScheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// Validate checks that the timezone and the cron expressions of the schedule can be parsed
+func (s ReplicaCountSchedule) Validate() error {
+	_, _, _, err := s.parse()
+	return err
+}
+
+// Evaluate returns whether now is within the time window of the schedule and the time of the next start or end of the window
+func (s ReplicaCountSchedule) Evaluate(now time.Time) (bool, time.Time, error) {
+	location, start, end, err := s.parse()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	// the window is active when it ends sooner than it starts again
+	nextStart := start.Next(now.In(location))
+	nextEnd := end.Next(now.In(location))
+	if nextEnd.Before(nextStart) {
+		return true, nextEnd, nil
+	}
+	return false, nextStart, nil
+}
+
+func (s ReplicaCountSchedule) parse() (*time.Location, cron.Schedule, cron.Schedule, error) {
+	location := time.UTC
+	if s.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(s.Timezone)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to load timezone %s: %w", s.Timezone, err)
+		}
+	}
+	start, err := replicaCountScheduleParser.Parse(s.Start)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing start schedule %q: %w", s.Start, err)
+	}
+	end, err := replicaCountScheduleParser.Parse(s.End)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing end schedule %q: %w", s.End, err)
+	}
+	return location, start, end, nil
+}
+
+// ReplicaCountBounds are the min and max replica counts applied outside of the replica count schedules
+// or within the window of one of them
+type ReplicaCountBounds struct {
+	// Window describes where the bounds apply, for the error messages
+	Window          string
+	MinReplicaCount *int32
+	MaxReplicaCount *int32
+}
+
+// GetReplicaCountBounds returns the bounds of the spec followed by the ones of every replica count schedule window,
+// so they can be validated without depending on the current time
+func (so *ScaledObject) GetReplicaCountBounds() []ReplicaCountBounds {
+	bounds := []ReplicaCountBounds{{
+		Window:          "spec",
+		MinReplicaCount: so.Spec.MinReplicaCount,
+		MaxReplicaCount: so.Spec.MaxReplicaCount,
+	}}
+	for _, schedule := range so.Spec.ReplicaCountSchedules {
+		b := ReplicaCountBounds{
+			Window:          fmt.Sprintf("replicaCountSchedule (start=%q, end=%q)", schedule.Start, schedule.End),
+			MinReplicaCount: so.Spec.MinReplicaCount,
+			MaxReplicaCount: so.Spec.MaxReplicaCount,
+		}
+		if schedule.MinReplicaCount != nil {
+			b.MinReplicaCount = schedule.MinReplicaCount
+		}
+		if schedule.MaxReplicaCount != nil {
+			b.MaxReplicaCount = schedule.MaxReplicaCount
+		}
+		bounds = append(bounds, b)
+	}
+	return bounds
+}
+
+// GetMaxReplicas returns the max replica count of the bounds or the default value if not defined
+func (b ReplicaCountBounds) GetMaxReplicas() int32 {
+	if b.MaxReplicaCount != nil {
+		return *b.MaxReplicaCount
 	}
 	return defaultHPAMaxReplicas
 }
 
+// GetMinReplicas returns the min replica count of the bounds or 0 if not defined
+func (b ReplicaCountBounds) GetMinReplicas() int32 {
+	if b.MinReplicaCount != nil {
+		return *b.MinReplicaCount
+	}
+	return 0
+}
+
 // checkReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
 // i.e. that Min is not greater than Max or Idle greater or equal to Min
 func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
+	if err := checkReplicaCountBounds(scaledObject.Spec.IdleReplicaCount, scaledObject.Spec.MinReplicaCount, scaledObject.Spec.MaxReplicaCount); err != nil {
+		return err
+	}
+
+	// replica counts overridden by schedules have to be valid as well
+	for _, bounds := range scaledObject.GetReplicaCountBounds()[1:] {
+		if err := checkReplicaCountBounds(scaledObject.Spec.IdleReplicaCount, bounds.MinReplicaCount, bounds.MaxReplicaCount); err != nil {
+			return fmt.Errorf("%s: %w", bounds.Window, err)
+		}
+	}
+
+	return nil
+}
+
+func checkReplicaCountBounds(idleReplicaCount, minReplicaCount, maxReplicaCount *int32) error {
 	min := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		min = *scaledObject.GetHPAMinReplicas()
+	if minReplicaCount != nil {
+		min = *minReplicaCount
+		if min <= 0 {
+			min = defaultHPAMinReplicas
+		}
+	}
+	max := defaultHPAMaxReplicas
+	if maxReplicaCount != nil {
+		max = *maxReplicaCount
 	}
-	max := scaledObject.GetHPAMaxReplicas()
 
 	if min > max {
 		return fmt.Errorf("MinReplicaCount=%d must be less than MaxReplicaCount=%d", min, max)
 	}
 
-	if scaledObject.Spec.IdleReplicaCount != nil && *scaledObject.Spec.IdleReplicaCount >= min {
-		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, min)
+	if idleReplicaCount != nil && *idleReplicaCount >= min {
+		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *idleReplicaCount, min)
 	}
 
 	return nil
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplicaCountScheduleEvaluate(t *testing.T) {
+	schedule := ReplicaCountSchedule{Start: "0 8 * * *", End: "0 18 * * *", Timezone: "UTC"}
+
+	tests := []struct {
+		name               string
+		now                time.Time
+		expectedActive     bool
+		expectedTransition time.Time
+	}{
+		{
+			name:               "before the window",
+			now:                time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC),
+			expectedActive:     false,
+			expectedTransition: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:               "within the window",
+			now:                time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			expectedActive:     true,
+			expectedTransition: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:               "after the window",
+			now:                time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+			expectedActive:     false,
+			expectedTransition: time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		active, transition, err := schedule.Evaluate(test.now)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if active != test.expectedActive {
+			t.Errorf("%s: expected active %v, got %v", test.name, test.expectedActive, active)
+		}
+		if !transition.Equal(test.expectedTransition) {
+			t.Errorf("%s: expected transition %v, got %v", test.name, test.expectedTransition, transition)
+		}
+	}
+
+	if _, _, err := (ReplicaCountSchedule{Start: "invalid", End: "0 18 * * *"}).Evaluate(time.Now()); err == nil {
+		t.Error("expected error for invalid start schedule")
+	}
+	if _, _, err := (ReplicaCountSchedule{Start: "0 8 * * *", End: "0 18 * * *", Timezone: "Invalid/Zone"}).Evaluate(time.Now()); err == nil {
+		t.Error("expected error for invalid timezone")
+	}
+}
+
+func TestCheckReplicaCountBoundsAreValidWithSchedules(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        ScaledObjectSpec
+		expectError bool
+	}{
+		{
+			name: "schedule within bounds",
+			spec: ScaledObjectSpec{
+				MinReplicaCount: int32Ptr(1),
+				MaxReplicaCount: int32Ptr(10),
+				ReplicaCountSchedules: []ReplicaCountSchedule{
+					{Start: "0 8 * * *", End: "0 18 * * *", MinReplicaCount: int32Ptr(5)},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "schedule min exceeds max",
+			spec: ScaledObjectSpec{
+				MinReplicaCount: int32Ptr(1),
+				MaxReplicaCount: int32Ptr(10),
+				ReplicaCountSchedules: []ReplicaCountSchedule{
+					{Start: "0 8 * * *", End: "0 18 * * *", MinReplicaCount: int32Ptr(20)},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "schedule max is lower than min",
+			spec: ScaledObjectSpec{
+				MinReplicaCount: int32Ptr(5),
+				ReplicaCountSchedules: []ReplicaCountSchedule{
+					{Start: "0 8 * * *", End: "0 18 * * *", MaxReplicaCount: int32Ptr(3)},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "schedule min is not greater than idle",
+			spec: ScaledObjectSpec{
+				IdleReplicaCount: int32Ptr(2),
+				MinReplicaCount:  int32Ptr(3),
+				ReplicaCountSchedules: []ReplicaCountSchedule{
+					{Start: "0 8 * * *", End: "0 18 * * *", MinReplicaCount: int32Ptr(2)},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := CheckReplicaCountBoundsAreValid(&ScaledObject{Spec: test.spec})
+		if test.expectError && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+	}
+}
+
+func TestValidateZeroScalingStagingWithSchedules(t *testing.T) {
+	staging := &ZeroScalingStaging{
+		ActivationReplicaCount: int32Ptr(3),
+		ScaleDownSteps:         []ScaleDownStep{{Replicas: 2, DurationSeconds: 300}},
+	}
+	tests := []struct {
+		name        string
+		spec        ScaledObjectSpec
+		expectError bool
+	}{
+		{
+			name: "schedule within bounds",
+			spec: ScaledObjectSpec{
+				MinReplicaCount: int32Ptr(0),
+				MaxReplicaCount: int32Ptr(10),
+				Advanced:        &AdvancedConfig{DirectScaling: true},
+				ReplicaCountSchedules: []ReplicaCountSchedule{
+					{Start: "0 8 * * *", End: "0 18 * * *", MaxReplicaCount: int32Ptr(5)},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "schedule max below activationReplicaCount",
+			spec: ScaledObjectSpec{
+				MinReplicaCount: int32Ptr(0),
+				MaxReplicaCount: int32Ptr(10),
+				Advanced:        &AdvancedConfig{DirectScaling: true},
+				ReplicaCountSchedules: []ReplicaCountSchedule{
+					{Start: "0 8 * * *", End: "0 18 * * *", MaxReplicaCount: int32Ptr(2)},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "scaleDownSteps with HPA",
+			spec: ScaledObjectSpec{
+				MinReplicaCount: int32Ptr(0),
+				MaxReplicaCount: int32Ptr(10),
+			},
+			expectError: false,
+		},
+		{
+			name: "scaleDownSteps with HPA and idleReplicaCount",
+			spec: ScaledObjectSpec{
+				IdleReplicaCount: int32Ptr(0),
+				MinReplicaCount:  int32Ptr(2),
+				MaxReplicaCount:  int32Ptr(10),
+			},
+			expectError: false,
+		},
+		{
+			name: "scaleDownSteps below minReplicaCount with HPA",
+			spec: ScaledObjectSpec{
+				IdleReplicaCount: int32Ptr(0),
+				MinReplicaCount:  int32Ptr(3),
+				MaxReplicaCount:  int32Ptr(10),
+			},
+			expectError: true,
+		},
+		{
+			name: "scaleDownSteps below minReplicaCount with directScaling",
+			spec: ScaledObjectSpec{
+				IdleReplicaCount: int32Ptr(0),
+				MinReplicaCount:  int32Ptr(3),
+				MaxReplicaCount:  int32Ptr(10),
+				Advanced:         &AdvancedConfig{DirectScaling: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "schedule min keeping replicas",
+			spec: ScaledObjectSpec{
+				MinReplicaCount: int32Ptr(0),
+				MaxReplicaCount: int32Ptr(10),
+				Advanced:        &AdvancedConfig{DirectScaling: true},
+				ReplicaCountSchedules: []ReplicaCountSchedule{
+					{Start: "0 8 * * *", End: "0 18 * * *", MinReplicaCount: int32Ptr(1)},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := validateZeroScalingStaging(&ScaledObject{Spec: test.spec}, staging)
+		if test.expectError && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+	}
+}
+
+func TestGetActiveReplicaCountSchedule(t *testing.T) {
+	so := &ScaledObject{
+		Spec: ScaledObjectSpec{
+			ReplicaCountSchedules: []ReplicaCountSchedule{
+				{Start: "0 8 * * *", End: "0 18 * * *", MinReplicaCount: int32Ptr(5)},
+				{Start: "0 0 * * *", End: "0 12 * * *", MaxReplicaCount: int32Ptr(3)},
+			},
+		},
+	}
+
+	if schedule := so.GetActiveReplicaCountSchedule(time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)); schedule != nil {
+		t.Errorf("expected no active schedule, got %v", schedule)
+	}
+
+	// the first active schedule takes precedence
+	schedule := so.GetActiveReplicaCountSchedule(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	if schedule == nil || schedule.MinReplicaCount == nil || *schedule.MinReplicaCount != 5 {
+		t.Errorf("expected the first schedule to be active, got %v", schedule)
+	}
+
+	next := so.GetNextReplicaCountScheduleTransition(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	if !next.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected next transition at 12:00, got %v", next)
+	}
+}
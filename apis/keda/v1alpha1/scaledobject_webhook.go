@@ -29,6 +29,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	metricscollector "github.com/kedacore/keda/v2/pkg/metricscollector/webhook"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 var scaledobjectlog = logf.Log.WithName("scaledobject-validation-webhook")
@@ -135,6 +137,10 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		verifyScaledObjects,
 		verifyHpas,
 		verifyReplicaCount,
+		verifyReplicaCountSchedules,
+		verifyZeroScalingStaging,
+		verifyDirectScaling,
+		verifyInlineSecrets,
 	}
 
 	for i := range verifyFunctions {
@@ -168,6 +174,122 @@ func verifyReplicaCount(incomingSo *ScaledObject, action string, _ bool) error {
 	return nil
 }
 
+func verifyReplicaCountSchedules(incomingSo *ScaledObject, action string, _ bool) error {
+	for _, schedule := range incomingSo.Spec.ReplicaCountSchedules {
+		var err error
+		if schedule.MinReplicaCount == nil && schedule.MaxReplicaCount == nil {
+			err = fmt.Errorf("replicaCountSchedule (start=%q, end=%q) has to override minReplicaCount or maxReplicaCount", schedule.Start, schedule.End)
+		} else {
+			err = schedule.Validate()
+		}
+		if err != nil {
+			scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-replica-count-schedule")
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyZeroScalingStaging(incomingSo *ScaledObject, action string, _ bool) error {
+	staging := incomingSo.GetZeroScalingStaging()
+	if staging == nil {
+		return nil
+	}
+
+	err := validateZeroScalingStaging(incomingSo, staging)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-zero-scaling-staging")
+	}
+	return err
+}
+
+// validateZeroScalingStaging checks the staging against the replica counts of the spec and of every replica count
+// schedule window, so the result doesn't depend on the window active at admission time
+func validateZeroScalingStaging(so *ScaledObject, staging *ZeroScalingStaging) error {
+	bounds := so.GetReplicaCountBounds()
+	for _, b := range bounds {
+		maxReplicaCount := b.GetMaxReplicas()
+		if staging.ActivationReplicaCount != nil {
+			if *staging.ActivationReplicaCount < 1 || *staging.ActivationReplicaCount > maxReplicaCount {
+				return fmt.Errorf("%s: activationReplicaCount=%d must be between 1 and MaxReplicaCount=%d", b.Window, *staging.ActivationReplicaCount, maxReplicaCount)
+			}
+		}
+	}
+
+	if len(staging.ScaleDownSteps) == 0 {
+		return nil
+	}
+	// the target is scaled to zero (or idle) replicas only with minReplicaCount 0 or idleReplicaCount
+	scaleDownTo := int32(0)
+	if so.Spec.IdleReplicaCount != nil {
+		scaleDownTo = *so.Spec.IdleReplicaCount
+	} else {
+		for _, b := range bounds {
+			if b.MinReplicaCount != nil && *b.MinReplicaCount > 0 {
+				return fmt.Errorf("%s: scaleDownSteps require minReplicaCount=0 or idleReplicaCount to be set", b.Window)
+			}
+		}
+	}
+	for _, b := range bounds {
+		maxReplicaCount := b.GetMaxReplicas()
+		previous := maxReplicaCount + 1
+		for i, step := range staging.ScaleDownSteps {
+			if step.Replicas >= previous || step.Replicas <= scaleDownTo {
+				return fmt.Errorf("%s: scaleDownSteps[%d].replicas=%d must be lower than the previous step (and MaxReplicaCount=%d) and greater than %d", b.Window, i, step.Replicas, maxReplicaCount, scaleDownTo)
+			}
+			// an HPA keeps the target on at least minReplicaCount replicas and would undo a step below it
+			if !so.IsUsingDirectScaling() && step.Replicas > 0 && step.Replicas < b.GetMinReplicas() {
+				return fmt.Errorf("%s: scaleDownSteps[%d].replicas=%d must be greater than or equal to MinReplicaCount=%d when the HPA is used", b.Window, i, step.Replicas, b.GetMinReplicas())
+			}
+			if step.DurationSeconds < 0 {
+				return fmt.Errorf("scaleDownSteps[%d].durationSeconds=%d must not be negative", i, step.DurationSeconds)
+			}
+			previous = step.Replicas
+		}
+	}
+	return nil
+}
+
+func verifyDirectScaling(incomingSo *ScaledObject, action string, _ bool) error {
+	if !incomingSo.IsUsingDirectScaling() {
+		return nil
+	}
+
+	var err error
+	switch {
+	case !isDirectScalingTarget(incomingSo.Spec.ScaleTargetRef):
+		err = fmt.Errorf("direct scaling supports only Deployments and StatefulSets as scale target")
+	case incomingSo.IsUsingModifiers():
+		err = fmt.Errorf("direct scaling can't be used together with scalingModifiers")
+	default:
+		for _, trigger := range incomingSo.Spec.Triggers {
+			if trigger.Type == cpuString || trigger.Type == memoryString {
+				err = fmt.Errorf("direct scaling doesn't support %s trigger", trigger.Type)
+				break
+			}
+		}
+	}
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "direct-scaling")
+	}
+	return err
+}
+
+// isDirectScalingTarget checks whether the scale target is a Deployment or StatefulSet,
+// an empty Kind defaults to Deployment
+func isDirectScalingTarget(target *ScaleTarget) bool {
+	if target == nil {
+		return false
+	}
+	if target.APIVersion != "" && target.APIVersion != "apps/v1" {
+		return false
+	}
+	return target.Kind == "" || target.Kind == "Deployment" || target.Kind == "StatefulSet"
+}
+
 func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	var triggers []ScaleTriggers
 	var name string
@@ -188,7 +310,11 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	err := ValidateTriggers(triggers)
 	if err != nil {
 		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
-		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-triggers")
+		if _, ok := incomingObject.(*ScaledJob); ok {
+			metricscollector.RecordScaledJobValidatingErrors(namespace, action, "incorrect-triggers")
+		} else {
+			metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-triggers")
+		}
 	}
 	return err
 }
@@ -308,32 +434,12 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 	for _, trigger := range incomingSo.Spec.Triggers {
 		if trigger.Type == cpuString || trigger.Type == memoryString {
 			if podSpec == nil {
-				key := types.NamespacedName{
-					Namespace: incomingSo.Namespace,
-					Name:      incomingSo.Spec.ScaleTargetRef.Name,
-				}
-				incomingSoGckr, err := ParseGVKR(restMapper, incomingSo.Spec.ScaleTargetRef.APIVersion, incomingSo.Spec.ScaleTargetRef.Kind)
+				var err error
+				podSpec, err = getScaleTargetPodSpec(incomingSo)
 				if err != nil {
-					scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from incoming ScaledObject", "apiVersion", incomingSo.Spec.ScaleTargetRef.APIVersion, "kind", incomingSo.Spec.ScaleTargetRef.Kind)
 					return err
 				}
-
-				switch incomingSoGckr.GVKString() {
-				case "apps/v1.Deployment":
-					deployment := &appsv1.Deployment{}
-					err := kc.Get(context.Background(), key, deployment, &client.GetOptions{})
-					if err != nil {
-						return err
-					}
-					podSpec = &deployment.Spec.Template.Spec
-				case "apps/v1.StatefulSet":
-					statefulset := &appsv1.StatefulSet{}
-					err := kc.Get(context.Background(), key, statefulset, &client.GetOptions{})
-					if err != nil {
-						return err
-					}
-					podSpec = &statefulset.Spec.Template.Spec
-				default:
+				if podSpec == nil {
 					return nil
 				}
 			}
@@ -368,7 +474,15 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 				}
 			}
 
-			if (scaleToZeroErr && incomingSo.Spec.MinReplicaCount == nil) || (scaleToZeroErr && *incomingSo.Spec.MinReplicaCount == 0) {
+			// every replica count schedule window has to keep at least one replica as well
+			scalesToZero := false
+			for _, bounds := range incomingSo.GetReplicaCountBounds() {
+				if bounds.MinReplicaCount == nil || *bounds.MinReplicaCount == 0 {
+					scalesToZero = true
+				}
+			}
+
+			if scaleToZeroErr && scalesToZero {
 				err := fmt.Errorf("scaledobject has only cpu/memory triggers AND minReplica is 0 (scale to zero doesn't work in this case)")
 				scaledobjectlog.Error(err, "validation error")
 				metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "scale-to-zero-requirements-not-met")
@@ -379,6 +493,65 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 	return nil
 }
 
+// verifyInlineSecrets checks that the literal environment variables read by the TriggerAuthentications of the triggers
+// are encrypted when KEDA requires inline secrets to be encrypted
+func verifyInlineSecrets(incomingSo *ScaledObject, action string, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+	required, err := util.GetRequireEncryptedInlineSecrets()
+	if err != nil || !required {
+		return err
+	}
+	podSpec, err := getScaleTargetPodSpec(incomingSo)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the literal values are checked by the operator once the scale target is created
+			return nil
+		}
+		return err
+	}
+	if err := validateInlineEnvSecrets(incomingSo.Namespace, incomingSo.Spec.Triggers, podSpec); err != nil {
+		scaledobjectlog.Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "plaintext-inline-secret")
+		return err
+	}
+	return nil
+}
+
+// getScaleTargetPodSpec returns the pod template of the Deployment or StatefulSet scale target,
+// nil is returned for other kinds of scale targets
+func getScaleTargetPodSpec(incomingSo *ScaledObject) (*corev1.PodSpec, error) {
+	key := types.NamespacedName{
+		Namespace: incomingSo.Namespace,
+		Name:      incomingSo.Spec.ScaleTargetRef.Name,
+	}
+	incomingSoGckr, err := ParseGVKR(restMapper, incomingSo.Spec.ScaleTargetRef.APIVersion, incomingSo.Spec.ScaleTargetRef.Kind)
+	if err != nil {
+		scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from incoming ScaledObject", "apiVersion", incomingSo.Spec.ScaleTargetRef.APIVersion, "kind", incomingSo.Spec.ScaleTargetRef.Kind)
+		return nil, err
+	}
+
+	switch incomingSoGckr.GVKString() {
+	case "apps/v1.Deployment":
+		deployment := &appsv1.Deployment{}
+		err := kc.Get(context.Background(), key, deployment, &client.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &deployment.Spec.Template.Spec, nil
+	case "apps/v1.StatefulSet":
+		statefulset := &appsv1.StatefulSet{}
+		err := kc.Get(context.Background(), key, statefulset, &client.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &statefulset.Spec.Template.Spec, nil
+	default:
+		return nil, nil
+	}
+}
+
 // ValidateAndCompileScalingModifiers validates all combinations of given arguments
 // and their values. Expects the whole structure's path to be defined (like .Advanced).
 // As part of formula validation this function also compiles the formula
@@ -20,6 +20,9 @@ import (
 	"fmt"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 // ScaleTriggers reference the scaler that will be used
@@ -49,6 +52,7 @@ type AuthenticationRef struct {
 // ValidateTriggers checks that general trigger metadata are valid, it checks:
 // - triggerNames in ScaledObject are unique
 // - useCachedMetrics is defined only for a supported triggers
+// - kubernetes-resource triggers only read the kinds allowed by the operator
 func ValidateTriggers(triggers []ScaleTriggers) error {
 	triggersCount := len(triggers)
 
@@ -75,8 +79,32 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 				}
 				triggerNames[name] = true
 			}
+
+			if trigger.Type == "kubernetes-resource" {
+				if err := validateKubernetesResourceTrigger(trigger); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	return nil
 }
+
+// validateKubernetesResourceTrigger checks that the kind read by a kubernetes-resource trigger is listed in
+// KEDA_KUBERNETES_RESOURCE_ALLOWED_KINDS, the other metadata are validated by the scaler
+func validateKubernetesResourceTrigger(trigger ScaleTriggers) error {
+	apiVersion, kind := trigger.Metadata["apiVersion"], trigger.Metadata["kind"]
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return fmt.Errorf("invalid apiVersion %q of the kubernetes-resource trigger: %w", apiVersion, err)
+	}
+	allowed, err := util.IsKubernetesResourceKindAllowed(groupVersion.WithKind(kind))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("kind %q of apiVersion %q isn't allowed for the kubernetes-resource trigger, it must be listed in %s", kind, apiVersion, util.KubernetesResourceAllowedKindsEnvVar)
+	}
+	return nil
+}
@@ -4,9 +4,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 func TestValidateTriggers(t *testing.T) {
+	t.Setenv(util.KubernetesResourceAllowedKindsEnvVar, "v1/Pod,tekton.dev/v1/PipelineRun")
+
 	tests := []struct {
 		name           string
 		triggers       []ScaleTriggers
@@ -84,6 +88,26 @@ func TestValidateTriggers(t *testing.T) {
 			},
 			expectedErrMsg: "",
 		},
+		{
+			name: "allowed kind for kubernetes-resource scaler",
+			triggers: []ScaleTriggers{
+				{
+					Type:     "kubernetes-resource",
+					Metadata: map[string]string{"apiVersion": "tekton.dev/v1", "kind": "PipelineRun"},
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "kind not allowed for kubernetes-resource scaler",
+			triggers: []ScaleTriggers{
+				{
+					Type:     "kubernetes-resource",
+					Metadata: map[string]string{"apiVersion": "v1", "kind": "Secret"},
+				},
+			},
+			expectedErrMsg: "kind \"Secret\" of apiVersion \"v1\" isn't allowed for the kubernetes-resource trigger, it must be listed in KEDA_KUBERNETES_RESOURCE_ALLOWED_KINDS",
+		},
 		{
 			name:           "empty triggers array should be blocked",
 			triggers:       []ScaleTriggers{},
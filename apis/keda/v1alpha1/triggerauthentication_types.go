@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -84,6 +88,12 @@ type TriggerAuthenticationSpec struct {
 	// +optional
 	Env []AuthEnvironment `json:"env,omitempty"`
 
+	// +optional
+	FilePath []AuthFilePath `json:"filePath,omitempty"`
+
+	// +optional
+	ServiceAccountToken *AuthServiceAccountToken `json:"serviceAccountToken,omitempty"`
+
 	// +optional
 	HashiCorpVault *HashiCorpVault `json:"hashiCorpVault,omitempty"`
 
@@ -95,6 +105,9 @@ type TriggerAuthenticationSpec struct {
 
 	// +optional
 	AwsSecretManager *AwsSecretManager `json:"awsSecretManager,omitempty"`
+
+	// +optional
+	ExternalSecretProvider *ExternalSecretProvider `json:"externalSecretProvider,omitempty"`
 }
 
 // TriggerAuthenticationStatus defines the observed state of TriggerAuthentication
@@ -103,6 +116,104 @@ type TriggerAuthenticationStatus struct {
 	ScaledObjectNamesStr string `json:"scaledobjects,omitempty"`
 	// +optional
 	ScaledJobNamesStr string `json:"scaledjobs,omitempty"`
+	// +optional
+	Dependents []AuthenticationDependent `json:"dependents,omitempty"`
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+	// +optional
+	SourceErrors []AuthenticationSourceError `json:"sourceErrors,omitempty"`
+	// +optional
+	LastSuccessfulResolutionTime *metav1.Time `json:"lastSuccessfulResolutionTime,omitempty"`
+}
+
+// AuthenticationDependent references a ScaledObject or ScaledJob using the authentication
+type AuthenticationDependent struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// AuthenticationSourceError describes the last resolution error of an authentication source
+type AuthenticationSourceError struct {
+	// Source is the name of the spec field that failed to resolve, e.g. hashiCorpVault
+	Source  string      `json:"source"`
+	Message string      `json:"message"`
+	Time    metav1.Time `json:"time"`
+}
+
+// AddDependent adds the dependent to the status if it isn't present yet
+func (s *TriggerAuthenticationStatus) AddDependent(dependent AuthenticationDependent) {
+	for _, d := range s.Dependents {
+		if d == dependent {
+			return
+		}
+	}
+	s.Dependents = append(s.Dependents, dependent)
+}
+
+// RemoveDependent removes the dependent from the status
+func (s *TriggerAuthenticationStatus) RemoveDependent(dependent AuthenticationDependent) {
+	dependents := s.Dependents[:0]
+	for _, d := range s.Dependents {
+		if d != dependent {
+			dependents = append(dependents, d)
+		}
+	}
+	if len(dependents) == 0 {
+		dependents = nil
+	}
+	s.Dependents = dependents
+}
+
+// TriggerAuthenticationResolutionTimeRefreshInterval limits how often successful resolutions refresh LastSuccessfulResolutionTime,
+// so resolving an unchanged TriggerAuthentication on every scaler build doesn't patch its status each time
+const TriggerAuthenticationResolutionTimeRefreshInterval = 5 * time.Minute
+
+// SetResolutionResult updates the Ready condition and source errors with the outcome of a resolution,
+// a resolution without source errors also records the last successful resolution time.
+// It returns false when the status is left unchanged: the condition and the source messages are the same as before and,
+// for a successful resolution, the last successful resolution time is more recent than TriggerAuthenticationResolutionTimeRefreshInterval
+func (s *TriggerAuthenticationStatus) SetResolutionResult(sourceErrors []AuthenticationSourceError, now metav1.Time) bool {
+	if s.Conditions == nil {
+		s.Conditions = Conditions{{Type: ConditionReady, Status: metav1.ConditionUnknown}}
+	}
+	ready := s.Conditions.GetReadyCondition()
+
+	if len(sourceErrors) == 0 {
+		if ready.IsTrue() && s.SourceErrors == nil && s.LastSuccessfulResolutionTime != nil &&
+			now.Sub(s.LastSuccessfulResolutionTime.Time) < TriggerAuthenticationResolutionTimeRefreshInterval {
+			return false
+		}
+		s.SourceErrors = nil
+		s.LastSuccessfulResolutionTime = &now
+		s.Conditions.SetReadyCondition(metav1.ConditionTrue, TriggerAuthenticationConditionReadySuccessReason, TriggerAuthenticationConditionReadySuccessMessage)
+		return true
+	}
+
+	sources := make([]string, 0, len(sourceErrors))
+	for _, sourceError := range sourceErrors {
+		sources = append(sources, sourceError.Source)
+	}
+	message := fmt.Sprintf("failed to resolve authentication sources: %s", strings.Join(sources, ", "))
+	if ready.IsFalse() && ready.Message == message && sameSourceErrors(s.SourceErrors, sourceErrors) {
+		return false
+	}
+	s.SourceErrors = sourceErrors
+	s.Conditions.SetReadyCondition(metav1.ConditionFalse, TriggerAuthenticationConditionReadyFailedReason, message)
+	return true
+}
+
+// sameSourceErrors compares the sources and messages of the errors, ignoring the time they were reported
+func sameSourceErrors(a, b []AuthenticationSourceError) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Source != b[i].Source || a[i].Message != b[i].Message {
+			return false
+		}
+	}
+	return true
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -127,6 +238,7 @@ const (
 	PodIdentityProviderAwsEKS        PodIdentityProvider = "aws-eks"
 	PodIdentityProviderAwsKiam       PodIdentityProvider = "aws-kiam"
 	PodIdentityProviderAws           PodIdentityProvider = "aws"
+	PodIdentityProviderOIDC          PodIdentityProvider = "oidc"
 )
 
 // PodIdentityAnnotationEKS specifies aws role arn for aws-eks Identity Provider
@@ -139,7 +251,7 @@ const (
 // AuthPodIdentity allows users to select the platform native identity
 // mechanism
 type AuthPodIdentity struct {
-	// +kubebuilder:validation:Enum=azure;azure-workload;gcp;aws;aws-eks;aws-kiam;none
+	// +kubebuilder:validation:Enum=azure;azure-workload;gcp;aws;aws-eks;aws-kiam;oidc;none
 	Provider PodIdentityProvider `json:"provider"`
 
 	// +optional
@@ -161,6 +273,38 @@ type AuthPodIdentity struct {
 	// +optional
 	// IdentityOwner configures which identity has to be used during auto discovery, keda or the scaled workload. Mutually exclusive with roleArn
 	IdentityOwner *string `json:"identityOwner"`
+
+	// +optional
+	// OIDC configures the token exchange of the oidc provider
+	OIDC *AuthPodIdentityOIDC `json:"oidc,omitempty"`
+}
+
+// AuthPodIdentityOIDC configures the exchange of a ServiceAccount token for an access token at an
+// RFC 8693 token exchange endpoint, eg: Keycloak or an on-prem STS federated with the cluster issuer.
+// The token of the scaled workload ServiceAccount is exchanged unless identityOwner is keda,
+// which is only allowed in ClusterTriggerAuthentication
+type AuthPodIdentityOIDC struct {
+	// TokenURL is the RFC 8693 token exchange endpoint
+	TokenURL string `json:"tokenUrl"`
+
+	// +kubebuilder:validation:MinLength=1
+	// ServiceAccountTokenAudience is the audience of the exchanged ServiceAccount token, the audiences of the API server aren't allowed
+	ServiceAccountTokenAudience string `json:"serviceAccountTokenAudience"`
+
+	// +optional
+	// ClientID is sent to the token exchange endpoint when set
+	ClientID string `json:"clientId,omitempty"`
+
+	// +optional
+	// Audience is the logical name of the service the access token is requested for
+	Audience string `json:"audience,omitempty"`
+
+	// +optional
+	// Resource is the URI of the service the access token is requested for
+	Resource string `json:"resource,omitempty"`
+
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 func (a *AuthPodIdentity) GetIdentityID() string {
@@ -186,7 +330,8 @@ func (a *AuthPodIdentity) GetIdentityAuthorityHost() string {
 
 func (a *AuthPodIdentity) IsWorkloadIdentityOwner() bool {
 	if a.IdentityOwner == nil {
-		return false
+		// oidc exchanges the token of the scaled workload by default
+		return a.Provider == PodIdentityProviderOIDC
 	}
 	return *a.IdentityOwner == workloadString
 }
@@ -195,7 +340,52 @@ func (a *AuthPodIdentity) IsWorkloadIdentityOwner() bool {
 type AuthConfigMapTargetRef AuthTargetRef
 
 // AuthSecretTargetRef is used to authenticate using a reference to a secret
-type AuthSecretTargetRef AuthTargetRef
+type AuthSecretTargetRef struct {
+	Parameter string `json:"parameter"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+
+	// Namespace of the secret, a secret outside of the namespace where the TriggerAuthentication looks for secrets
+	// has to be granted by a TriggerAuthenticationGrant in the namespace of the secret
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AuthFilePath is used to authenticate using a file mounted into KEDA, eg: by the Secrets Store CSI driver.
+// Path is relative to the allowed root directory for ClusterTriggerAuthentication and to its <namespace> subdirectory
+// for TriggerAuthentication, and Key optionally extracts a value from a JSON file.
+type AuthFilePath struct {
+	Parameter string `json:"parameter"`
+	Path      string `json:"path"`
+
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// AuthServiceAccountToken is used to authenticate using a short-lived ServiceAccount token requested through
+// the TokenRequest API, the token is exposed as serviceAccountToken parameter and requested again before it expires
+type AuthServiceAccountToken struct {
+	// +kubebuilder:validation:MinLength=1
+	// Audience the token is intended for, the audiences of the API server aren't allowed
+	Audience string `json:"audience"`
+
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+
+	// +kubebuilder:validation:Enum=keda;workload
+	// +optional
+	// IdentityOwner configures which ServiceAccount the token is requested for, the scaled workload (default) or keda,
+	// which is only allowed in ClusterTriggerAuthentication
+	IdentityOwner *string `json:"identityOwner,omitempty"`
+}
+
+func (a *AuthServiceAccountToken) IsWorkloadIdentityOwner() bool {
+	if a.IdentityOwner == nil {
+		return true
+	}
+	return *a.IdentityOwner == workloadString
+}
 
 // AuthTargetRef is used to authenticate using a reference to a resource
 type AuthTargetRef struct {
@@ -240,6 +430,61 @@ type Credential struct {
 
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// +optional
+	AppRole *VaultAppRoleCredential `json:"appRole,omitempty"`
+
+	// +optional
+	JWT *VaultJWTCredential `json:"jwt,omitempty"`
+
+	// +optional
+	AWS *VaultAWSCredential `json:"aws,omitempty"`
+
+	// +optional
+	Cert *VaultCertCredential `json:"cert,omitempty"`
+}
+
+// VaultAppRoleCredential defines the role and secret IDs used for the approle authentication method
+type VaultAppRoleCredential struct {
+	RoleID VaultCredentialValue `json:"roleId"`
+
+	// +optional
+	SecretID *VaultCredentialValue `json:"secretId,omitempty"`
+}
+
+// VaultJWTCredential defines the service account token requested for the jwt authentication method
+type VaultJWTCredential struct {
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// +kubebuilder:default=vault
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// VaultAWSCredential defines the AWS configuration used for the aws authentication method
+type VaultAWSCredential struct {
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// +optional
+	RoleArn string `json:"roleArn,omitempty"`
+
+	// +optional
+	ServerID string `json:"serverId,omitempty"`
+}
+
+// VaultCertCredential defines the client certificate used for the cert authentication method
+type VaultCertCredential struct {
+	Cert VaultCredentialValue `json:"cert"`
+	Key  VaultCredentialValue `json:"key"`
+}
+
+type VaultCredentialValue struct {
+	ValueFrom ValueFromSecret `json:"valueFrom"`
 }
 
 // VaultAuthentication contains the list of Hashicorp Vault authentication methods
@@ -249,7 +494,10 @@ type VaultAuthentication string
 const (
 	VaultAuthenticationToken      VaultAuthentication = "token"
 	VaultAuthenticationKubernetes VaultAuthentication = "kubernetes"
-	// VaultAuthenticationAWS                            = "aws"
+	VaultAuthenticationAppRole    VaultAuthentication = "approle"
+	VaultAuthenticationJWT        VaultAuthentication = "jwt"
+	VaultAuthenticationAWS        VaultAuthentication = "aws"
+	VaultAuthenticationCert       VaultAuthentication = "cert"
 )
 
 // VaultSecretType defines the type of vault secret
@@ -382,6 +630,36 @@ type AwsSecretManagerSecret struct {
 	VersionStage string `json:"versionStage,omitempty"`
 }
 
+// ExternalSecretProvider is used to read secrets from a gRPC server implementing the ExternalSecretProvider API,
+// which allows integrating secret stores that KEDA doesn't support natively
+type ExternalSecretProvider struct {
+	// Address of the gRPC server, eg: secret-provider.keda:9090
+	Address string                         `json:"address"`
+	Secrets []ExternalSecretProviderSecret `json:"secrets"`
+	// Metadata is sent to the server with every request, eg: to select the vault or the project to read the secrets from
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// +optional
+	TLS *ExternalSecretProviderTLS `json:"tls,omitempty"`
+}
+
+type ExternalSecretProviderSecret struct {
+	Parameter string `json:"parameter"`
+	Name      string `json:"name"`
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// ExternalSecretProviderTLS configures the TLS connection to the gRPC server, the connection is insecure when it isn't set
+type ExternalSecretProviderTLS struct {
+	// +optional
+	CACert *ValueFromSecret `json:"caCert,omitempty"`
+	// +optional
+	ClientCert *ValueFromSecret `json:"clientCert,omitempty"`
+	// +optional
+	ClientKey *ValueFromSecret `json:"clientKey,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&ClusterTriggerAuthentication{}, &ClusterTriggerAuthenticationList{})
 	SchemeBuilder.Register(&TriggerAuthentication{}, &TriggerAuthenticationList{})
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTriggerAuthenticationStatusDependents(t *testing.T) {
+	scaledObject := AuthenticationDependent{Kind: "ScaledObject", Namespace: "default", Name: "app"}
+	scaledJob := AuthenticationDependent{Kind: "ScaledJob", Namespace: "default", Name: "app"}
+
+	status := TriggerAuthenticationStatus{}
+	status.AddDependent(scaledObject)
+	status.AddDependent(scaledJob)
+	status.AddDependent(scaledObject)
+	if len(status.Dependents) != 2 {
+		t.Fatalf("expected 2 dependents, got %v", status.Dependents)
+	}
+
+	status.RemoveDependent(scaledObject)
+	if len(status.Dependents) != 1 || status.Dependents[0] != scaledJob {
+		t.Fatalf("expected only %v, got %v", scaledJob, status.Dependents)
+	}
+
+	status.RemoveDependent(scaledJob)
+	if status.Dependents != nil {
+		t.Fatalf("expected no dependents, got %v", status.Dependents)
+	}
+}
+
+func TestTriggerAuthenticationStatusSetResolutionResult(t *testing.T) {
+	now := metav1.Now()
+	status := TriggerAuthenticationStatus{}
+
+	status.SetResolutionResult([]AuthenticationSourceError{{Source: "hashiCorpVault", Message: "permission denied", Time: now}}, now)
+	ready := status.Conditions.GetReadyCondition()
+	if !ready.IsFalse() || ready.Reason != TriggerAuthenticationConditionReadyFailedReason {
+		t.Errorf("expected Ready condition to be False, got %v", ready)
+	}
+	if len(status.SourceErrors) != 1 || status.LastSuccessfulResolutionTime != nil {
+		t.Errorf("expected a single source error and no successful resolution, got %v", status)
+	}
+
+	status.SetResolutionResult(nil, now)
+	ready = status.Conditions.GetReadyCondition()
+	if !ready.IsTrue() || ready.Reason != TriggerAuthenticationConditionReadySuccessReason {
+		t.Errorf("expected Ready condition to be True, got %v", ready)
+	}
+	if status.SourceErrors != nil || status.LastSuccessfulResolutionTime == nil {
+		t.Errorf("expected source errors to be cleared and the successful resolution recorded, got %v", status)
+	}
+}
+
+func TestTriggerAuthenticationStatusSetResolutionResultUnchanged(t *testing.T) {
+	now := metav1.Now()
+	later := metav1.NewTime(now.Add(time.Minute))
+	status := TriggerAuthenticationStatus{}
+
+	if !status.SetResolutionResult([]AuthenticationSourceError{{Source: "hashiCorpVault", Message: "permission denied", Time: now}}, now) {
+		t.Error("expected the first failed resolution to change the status")
+	}
+	if status.SetResolutionResult([]AuthenticationSourceError{{Source: "hashiCorpVault", Message: "permission denied", Time: later}}, later) {
+		t.Error("expected the same failure to leave the status unchanged")
+	}
+	if status.SourceErrors[0].Time != now {
+		t.Errorf("expected the source error to keep its first report time, got %v", status.SourceErrors[0].Time)
+	}
+	if !status.SetResolutionResult([]AuthenticationSourceError{{Source: "hashiCorpVault", Message: "lease expired", Time: later}}, later) {
+		t.Error("expected a different failure message to change the status")
+	}
+
+	if !status.SetResolutionResult(nil, now) {
+		t.Error("expected a successful resolution after a failure to change the status")
+	}
+	if status.SetResolutionResult(nil, later) {
+		t.Error("expected a recent successful resolution to leave the status unchanged")
+	}
+	refresh := metav1.NewTime(now.Add(TriggerAuthenticationResolutionTimeRefreshInterval))
+	if !status.SetResolutionResult(nil, refresh) || !status.LastSuccessfulResolutionTime.Equal(&refresh) {
+		t.Errorf("expected the last successful resolution time to be refreshed, got %v", status.LastSuccessfulResolutionTime)
+	}
+}
@@ -17,15 +17,26 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kedacore/keda/v2/pkg/kms"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
@@ -33,6 +44,17 @@ const (
 	workloadString = "workload"
 )
 
+// KubernetesAPIServerAudiences are the audiences the kube-apiserver is commonly configured with, a ServiceAccount
+// token requested with one of them would let whoever receives it act as the ServiceAccount in the cluster
+var KubernetesAPIServerAudiences = []string{
+	"https://kubernetes.default.svc",
+	"https://kubernetes.default.svc.cluster.local",
+	"kubernetes.default.svc",
+	"kubernetes.default.svc.cluster.local",
+	"https://kubernetes.default",
+	"kubernetes",
+}
+
 var triggerauthenticationlog = logf.Log.WithName("triggerauthentication-validation-webhook")
 
 func (ta *TriggerAuthentication) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -55,7 +77,7 @@ var _ webhook.Validator = &TriggerAuthentication{}
 func (ta *TriggerAuthentication) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(ta, "", "  ")
 	triggerauthenticationlog.Info(fmt.Sprintf("validating triggerauthentication creation for %s", string(val)))
-	return validateSpec(&ta.Spec)
+	return validateTriggerAuthentication(ta.Namespace, &ta.Spec)
 }
 
 func (ta *TriggerAuthentication) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -67,7 +89,7 @@ func (ta *TriggerAuthentication) ValidateUpdate(old runtime.Object) (admission.W
 		triggerauthenticationlog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return validateSpec(&ta.Spec)
+	return validateTriggerAuthentication(ta.Namespace, &ta.Spec)
 }
 
 func (ta *TriggerAuthentication) ValidateDelete() (admission.Warnings, error) {
@@ -82,7 +104,7 @@ var _ webhook.Validator = &ClusterTriggerAuthentication{}
 func (cta *ClusterTriggerAuthentication) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(cta, "", "  ")
 	triggerauthenticationlog.Info(fmt.Sprintf("validating clustertriggerauthentication creation for %s", string(val)))
-	return validateSpec(&cta.Spec)
+	return validateClusterTriggerAuthentication(&cta.Spec)
 }
 
 func (cta *ClusterTriggerAuthentication) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -95,7 +117,7 @@ func (cta *ClusterTriggerAuthentication) ValidateUpdate(old runtime.Object) (adm
 		return nil, nil
 	}
 
-	return validateSpec(&cta.Spec)
+	return validateClusterTriggerAuthentication(&cta.Spec)
 }
 
 func (cta *ClusterTriggerAuthentication) ValidateDelete() (admission.Warnings, error) {
@@ -111,6 +133,41 @@ func isTriggerAuthenticationRemovingFinalizer(om metav1.ObjectMeta, oldOm metav1
 	return len(om.Finalizers) == 0 && len(oldOm.Finalizers) == 1 && taSpecString == oldTaSpecString
 }
 
+func validateTriggerAuthentication(namespace string, spec *TriggerAuthenticationSpec) (admission.Warnings, error) {
+	warnings, err := validateSpec(spec)
+	if err != nil {
+		return warnings, err
+	}
+	if spec.ServiceAccountToken != nil && !spec.ServiceAccountToken.IsWorkloadIdentityOwner() {
+		return warnings, fmt.Errorf("identityOwner keda of serviceAccountToken is only allowed in ClusterTriggerAuthentication")
+	}
+	if spec.PodIdentity != nil && spec.PodIdentity.Provider == PodIdentityProviderOIDC && !spec.PodIdentity.IsWorkloadIdentityOwner() {
+		return warnings, fmt.Errorf("identityOwner keda of oidc PodIdentity is only allowed in ClusterTriggerAuthentication")
+	}
+	if len(spec.FilePath) > 0 && strings.EqualFold(util.GetRestrictSecretAccess(), "true") {
+		return warnings, fmt.Errorf("filePath of TriggerAuthentication isn't allowed when secret access is restricted, please use ClusterTriggerAuthentication instead")
+	}
+	if err := validateFilePaths(spec.FilePath, util.GetNamespacedAuthFilePathRoot(util.GetAuthFilePathRoot(), namespace)); err != nil {
+		return warnings, err
+	}
+	return warnings, validateSecretReferenceGrants(namespace, spec.SecretTargetRef)
+}
+
+func validateClusterTriggerAuthentication(spec *TriggerAuthenticationSpec) (admission.Warnings, error) {
+	warnings, err := validateSpec(spec)
+	if err != nil {
+		return warnings, err
+	}
+	if err := validateFilePaths(spec.FilePath, util.GetAuthFilePathRoot()); err != nil {
+		return warnings, err
+	}
+	namespace, err := util.GetClusterObjectNamespace()
+	if err != nil {
+		return warnings, fmt.Errorf("error getting cluster object namespace: %w", err)
+	}
+	return warnings, validateSecretReferenceGrants(namespace, spec.SecretTargetRef)
+}
+
 func validateSpec(spec *TriggerAuthenticationSpec) (admission.Warnings, error) {
 	if spec.PodIdentity != nil {
 		switch spec.PodIdentity.Provider {
@@ -130,9 +187,219 @@ func validateSpec(spec *TriggerAuthenticationSpec) (admission.Warnings, error) {
 			if spec.PodIdentity.RoleArn != nil && *spec.PodIdentity.RoleArn != "" && spec.PodIdentity.IsWorkloadIdentityOwner() {
 				return nil, fmt.Errorf("roleArn of PodIdentity can't be set if KEDA isn't identityOwner")
 			}
-		default:
-			return nil, nil
+		case PodIdentityProviderOIDC:
+			if err := validateOIDCPodIdentity(spec.PodIdentity.OIDC); err != nil {
+				return nil, err
+			}
 		}
 	}
+	if spec.HashiCorpVault != nil {
+		if err := validateHashiCorpVault(spec.HashiCorpVault); err != nil {
+			return nil, err
+		}
+	}
+	if spec.ServiceAccountToken != nil {
+		if err := validateServiceAccountTokenAudience("serviceAccountToken.audience", spec.ServiceAccountToken.Audience); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateInlineSecrets(spec); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
+
+// inlineSecretFields returns the values of the spec fields holding secrets inline, keyed by their field path
+func inlineSecretFields(spec *TriggerAuthenticationSpec) map[string]string {
+	fields := map[string]string{}
+	if spec.HashiCorpVault != nil && spec.HashiCorpVault.Credential != nil {
+		fields[kms.HashiCorpVaultTokenFieldPath] = spec.HashiCorpVault.Credential.Token
+	}
+	return fields
+}
+
+// validateInlineSecrets rejects plaintext values in inline secret fields when KEDA requires them to be encrypted
+func validateInlineSecrets(spec *TriggerAuthenticationSpec) error {
+	required, err := util.GetRequireEncryptedInlineSecrets()
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", util.RequireEncryptedInlineSecretsEnvVar, err)
+	}
+	if !required {
+		return nil
+	}
+	for field, value := range inlineSecretFields(spec) {
+		if value != "" && !kms.IsEnvelope(value) {
+			return fmt.Errorf("%s has to be encrypted with %s prefix when %s is enabled", field, kms.EnvelopePrefix, util.RequireEncryptedInlineSecretsEnvVar)
+		}
+	}
+	return nil
+}
+
+// validateInlineEnvSecrets rejects plaintext literal values of the container environment variables read through the env
+// of the TriggerAuthentications referenced by the triggers when KEDA requires inline secrets to be encrypted
+func validateInlineEnvSecrets(namespace string, triggers []ScaleTriggers, podSpec *corev1.PodSpec) error {
+	required, err := util.GetRequireEncryptedInlineSecrets()
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", util.RequireEncryptedInlineSecretsEnvVar, err)
+	}
+	if !required || podSpec == nil {
+		return nil
+	}
+	for _, trigger := range triggers {
+		if trigger.AuthenticationRef == nil {
+			continue
+		}
+		spec, err := getAuthenticationSpec(trigger.AuthenticationRef, namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// the authentication is reported by the operator once it's resolved
+				continue
+			}
+			return err
+		}
+		for _, env := range spec.Env {
+			value, ok := getLiteralContainerEnv(podSpec, env.ContainerName, env.Name)
+			if ok && value != "" && !kms.IsEnvelope(value) {
+				return fmt.Errorf("environment variable %s read by %s has to be encrypted with %s prefix when %s is enabled",
+					env.Name, trigger.AuthenticationRef.Name, kms.EnvelopePrefix, util.RequireEncryptedInlineSecretsEnvVar)
+			}
+		}
+	}
+	return nil
+}
+
+// getAuthenticationSpec returns the spec of the referenced TriggerAuthentication or ClusterTriggerAuthentication
+func getAuthenticationSpec(ref *AuthenticationRef, namespace string) (*TriggerAuthenticationSpec, error) {
+	if ref.Kind == "ClusterTriggerAuthentication" {
+		cta := &ClusterTriggerAuthentication{}
+		if err := kc.Get(context.Background(), types.NamespacedName{Name: ref.Name}, cta); err != nil {
+			return nil, err
+		}
+		return &cta.Spec, nil
+	}
+	ta := &TriggerAuthentication{}
+	if err := kc.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, ta); err != nil {
+		return nil, err
+	}
+	return &ta.Spec, nil
+}
+
+// getLiteralContainerEnv returns the literal value of the environment variable of the container with the name,
+// or of the first container when the name is empty
+func getLiteralContainerEnv(podSpec *corev1.PodSpec, containerName, name string) (string, bool) {
+	for i, container := range podSpec.Containers {
+		if (containerName == "" && i > 0) || (containerName != "" && container.Name != containerName) {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == name && env.ValueFrom == nil {
+				return env.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// validateSecretReferenceGrants checks that secrets outside of the namespace are granted to it by a TriggerAuthenticationGrant
+func validateSecretReferenceGrants(namespace string, secretTargetRefs []AuthSecretTargetRef) error {
+	for _, ref := range secretTargetRefs {
+		if ref.Namespace == "" || ref.Namespace == namespace {
+			continue
+		}
+		grants := &TriggerAuthenticationGrantList{}
+		if err := kc.List(context.Background(), grants, client.InNamespace(ref.Namespace)); err != nil {
+			return fmt.Errorf("error listing TriggerAuthenticationGrants in namespace %s: %w", ref.Namespace, err)
+		}
+		if !IsSecretReferenceGranted(grants.Items, namespace, ref.Name) {
+			return fmt.Errorf("secret %s/%s of parameter %s isn't granted to namespace %s by any TriggerAuthenticationGrant", ref.Namespace, ref.Name, ref.Parameter, namespace)
+		}
+	}
+	return nil
+}
+
+func validateOIDCPodIdentity(oidc *AuthPodIdentityOIDC) error {
+	if oidc == nil || oidc.TokenURL == "" {
+		return fmt.Errorf("oidc.tokenUrl of PodIdentity should be set when provider is %s", PodIdentityProviderOIDC)
+	}
+	tokenURL, err := url.ParseRequestURI(oidc.TokenURL)
+	if err != nil {
+		return fmt.Errorf("oidc.tokenUrl of PodIdentity is not a valid URL: %w", err)
+	}
+	if tokenURL.Scheme != "https" && tokenURL.Scheme != "http" {
+		return fmt.Errorf("oidc.tokenUrl of PodIdentity should use http or https scheme, got %q", tokenURL.Scheme)
+	}
+	return validateServiceAccountTokenAudience("oidc.serviceAccountTokenAudience of PodIdentity", oidc.ServiceAccountTokenAudience)
+}
+
+func validateFilePaths(filePaths []AuthFilePath, root string) error {
+	for _, filePath := range filePaths {
+		if filePath.Parameter == "" {
+			return fmt.Errorf("parameter of filePath %s should not be empty", filePath.Path)
+		}
+		if _, err := util.ResolvePathWithinRoot(root, filePath.Path); err != nil {
+			return fmt.Errorf("filePath of parameter %s is not allowed: %w", filePath.Parameter, err)
+		}
+	}
+	return nil
+}
+
+// validateServiceAccountTokenAudience requires the audience a ServiceAccount token is requested for,
+// the token would be accepted by the kube-apiserver otherwise
+func validateServiceAccountTokenAudience(field, audience string) error {
+	if audience == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if slices.Contains(KubernetesAPIServerAudiences, audience) {
+		return fmt.Errorf("%s can't be the kube-apiserver audience %s", field, audience)
+	}
+	return nil
+}
+
+func validateHashiCorpVault(vault *HashiCorpVault) error {
+	credential := vault.Credential
+	if credential == nil {
+		credential = &Credential{}
+	}
+
+	switch vault.Authentication {
+	case VaultAuthenticationAppRole:
+		if credential.AppRole == nil {
+			return fmt.Errorf("credential.appRole of hashiCorpVault is required when authentication is %s", vault.Authentication)
+		}
+		if !isValidVaultCredentialValue(&credential.AppRole.RoleID) {
+			return fmt.Errorf("credential.appRole.roleId of hashiCorpVault should reference a secret name and key")
+		}
+		if credential.AppRole.SecretID != nil && !isValidVaultCredentialValue(credential.AppRole.SecretID) {
+			return fmt.Errorf("credential.appRole.secretId of hashiCorpVault should reference a secret name and key")
+		}
+	case VaultAuthenticationJWT:
+		if vault.Role == "" {
+			return fmt.Errorf("role of hashiCorpVault is required when authentication is %s", vault.Authentication)
+		}
+		if credential.JWT == nil || credential.JWT.ServiceAccountName == "" {
+			return fmt.Errorf("credential.jwt.serviceAccountName of hashiCorpVault is required when authentication is %s", vault.Authentication)
+		}
+		if slices.Contains(KubernetesAPIServerAudiences, credential.JWT.Audience) {
+			return fmt.Errorf("credential.jwt.audience of hashiCorpVault can't be the kube-apiserver audience %s", credential.JWT.Audience)
+		}
+		if credential.JWT.ExpirationSeconds != nil && *credential.JWT.ExpirationSeconds < 600 {
+			return fmt.Errorf("credential.jwt.expirationSeconds of hashiCorpVault should be at least 600")
+		}
+	case VaultAuthenticationAWS:
+		if vault.Role == "" {
+			return fmt.Errorf("role of hashiCorpVault is required when authentication is %s", vault.Authentication)
+		}
+	case VaultAuthenticationCert:
+		if credential.Cert == nil {
+			return fmt.Errorf("credential.cert of hashiCorpVault is required when authentication is %s", vault.Authentication)
+		}
+		if !isValidVaultCredentialValue(&credential.Cert.Cert) || !isValidVaultCredentialValue(&credential.Cert.Key) {
+			return fmt.Errorf("credential.cert.cert and credential.cert.key of hashiCorpVault should reference a secret name and key")
+		}
+	}
+	return nil
+}
+
+func isValidVaultCredentialValue(value *VaultCredentialValue) bool {
+	return value.ValueFrom.SecretKeyRef.Name != "" && value.ValueFrom.SecretKeyRef.Key != ""
+}
@@ -18,10 +18,14 @@ package v1alpha1
 
 import (
 	"context"
+	"os"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kedacore/keda/v2/pkg/kms"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 var _ = It("validate triggerauthentication when IdentityID is nil, roleArn is empty and identityOwner is nil", func() {
@@ -327,6 +331,348 @@ var _ = It("validate clustertriggerauthentication when RoleArn is not empty and
 	}).ShouldNot(HaveOccurred())
 })
 
+var _ = It("validate triggerauthentication when hashiCorpVault approle has no role id secret", func() {
+	namespaceName := "vaultapproleinvalid"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationAppRole, "", &Credential{
+		AppRole: &VaultAppRoleCredential{},
+	})
+	ta := createTriggerAuthentication("vaultapproleinvalidta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when hashiCorpVault approle references a secret", func() {
+	namespaceName := "vaultapprole"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationAppRole, "", &Credential{
+		AppRole: &VaultAppRoleCredential{
+			RoleID: createVaultCredentialValue("vault-approle", "roleId"),
+		},
+	})
+	ta := createTriggerAuthentication("vaultapproleta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when hashiCorpVault jwt has no role", func() {
+	namespaceName := "vaultjwtnorole"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationJWT, "", &Credential{
+		JWT: &VaultJWTCredential{ServiceAccountName: "keda"},
+	})
+	ta := createTriggerAuthentication("vaultjwtnoroleta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when hashiCorpVault jwt has role and service account", func() {
+	namespaceName := "vaultjwt"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationJWT, "keda", &Credential{
+		JWT: &VaultJWTCredential{ServiceAccountName: "keda"},
+	})
+	ta := createTriggerAuthentication("vaultjwtta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when hashiCorpVault aws has no role", func() {
+	namespaceName := "vaultawsnorole"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationAWS, "", nil)
+	ta := createTriggerAuthentication("vaultawsnoroleta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when hashiCorpVault cert has no key", func() {
+	namespaceName := "vaultcertnokey"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationCert, "", &Credential{
+		Cert: &VaultCertCredential{
+			Cert: createVaultCredentialValue("vault-cert", "tls.crt"),
+		},
+	})
+	ta := createTriggerAuthentication("vaultcertnokeyta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when filePath is within the allowed root", func() {
+	namespaceName := "filepathwithinroot"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		FilePath: []AuthFilePath{{Parameter: "password", Path: "db/credentials.json", Key: "password"}},
+	}
+	ta := createTriggerAuthentication("filepathwithinrootta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when filePath is outside the allowed root", func() {
+	namespaceName := "filepathoutsideroot"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		FilePath: []AuthFilePath{{Parameter: "password", Path: "../../etc/passwd"}},
+	}
+	ta := createTriggerAuthentication("filepathoutsiderootta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when filePath is outside its namespace directory", func() {
+	namespaceName := "filepathoutsidenamespace"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		FilePath: []AuthFilePath{{Parameter: "password", Path: "../othernamespace/password"}},
+	}
+	ta := createTriggerAuthentication("filepathoutsidenamespaceta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when serviceAccountToken has an audience", func() {
+	namespaceName := "satokenaudience"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		ServiceAccountToken: &AuthServiceAccountToken{Audience: "thanos"},
+	}
+	ta := createTriggerAuthentication("satokenaudienceta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when serviceAccountToken has no audience", func() {
+	namespaceName := "satokennoaudience"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		ServiceAccountToken: &AuthServiceAccountToken{},
+	}
+	ta := createTriggerAuthentication("satokennoaudienceta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when serviceAccountToken has the kube-apiserver audience", func() {
+	namespaceName := "satokenapiserveraudience"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		ServiceAccountToken: &AuthServiceAccountToken{Audience: "https://kubernetes.default.svc"},
+	}
+	ta := createTriggerAuthentication("satokenapiserveraudienceta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when serviceAccountToken is owned by keda", func() {
+	namespaceName := "satokenkedaowner"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	identityOwner := kedaString
+	spec := TriggerAuthenticationSpec{
+		ServiceAccountToken: &AuthServiceAccountToken{Audience: "thanos", IdentityOwner: &identityOwner},
+	}
+	ta := createTriggerAuthentication("satokenkedaownerta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when oidc PodIdentity has a token url", func() {
+	namespaceName := "oidctokenurl"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithPodIdentity(PodIdentityProviderOIDC, nil, nil, nil, nil, nil)
+	spec.PodIdentity.OIDC = &AuthPodIdentityOIDC{TokenURL: "https://sts.example.com/token", ServiceAccountTokenAudience: "sts", Audience: "prometheus"}
+	ta := createTriggerAuthentication("oidctokenurlta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when oidc PodIdentity has no service account token audience", func() {
+	namespaceName := "oidcnoaudience"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithPodIdentity(PodIdentityProviderOIDC, nil, nil, nil, nil, nil)
+	spec.PodIdentity.OIDC = &AuthPodIdentityOIDC{TokenURL: "https://sts.example.com/token", Audience: "prometheus"}
+	ta := createTriggerAuthentication("oidcnoaudienceta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when oidc PodIdentity has the kube-apiserver audience", func() {
+	namespaceName := "oidcapiserveraudience"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithPodIdentity(PodIdentityProviderOIDC, nil, nil, nil, nil, nil)
+	spec.PodIdentity.OIDC = &AuthPodIdentityOIDC{TokenURL: "https://sts.example.com/token", ServiceAccountTokenAudience: "kubernetes.default.svc", Audience: "prometheus"}
+	ta := createTriggerAuthentication("oidcapiserveraudienceta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when oidc PodIdentity is owned by keda", func() {
+	namespaceName := "oidckedaowner"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	identityOwner := kedaString
+	spec := createTriggerAuthenticationSpecWithPodIdentity(PodIdentityProviderOIDC, nil, nil, nil, nil, &identityOwner)
+	spec.PodIdentity.OIDC = &AuthPodIdentityOIDC{TokenURL: "https://sts.example.com/token", ServiceAccountTokenAudience: "sts", Audience: "prometheus"}
+	ta := createTriggerAuthentication("oidckedaownerta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when oidc PodIdentity has no token url", func() {
+	namespaceName := "oidcnotokenurl"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := createTriggerAuthenticationSpecWithPodIdentity(PodIdentityProviderOIDC, nil, nil, nil, nil, nil)
+	ta := createTriggerAuthentication("oidcnotokenurlta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when secretTargetRef in another namespace is granted", func() {
+	namespaceName := "secretgranted"
+	secretNamespaceName := "secretgrantedshared"
+	for _, name := range []string{namespaceName, secretNamespaceName} {
+		err := k8sClient.Create(context.Background(), createNamespace(name))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	grant := createTriggerAuthenticationGrant("shared-broker", secretNamespaceName, namespaceName, "broker-credentials")
+	err := k8sClient.Create(context.Background(), grant)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		SecretTargetRef: []AuthSecretTargetRef{{Parameter: "password", Name: "broker-credentials", Key: "password", Namespace: secretNamespaceName}},
+	}
+	ta := createTriggerAuthentication("secretgrantedta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when secretTargetRef in another namespace isn't granted", func() {
+	namespaceName := "secretnotgranted"
+	secretNamespaceName := "secretnotgrantedshared"
+	for _, name := range []string{namespaceName, secretNamespaceName} {
+		err := k8sClient.Create(context.Background(), createNamespace(name))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	grant := createTriggerAuthenticationGrant("shared-broker", secretNamespaceName, "othernamespace", "broker-credentials")
+	err := k8sClient.Create(context.Background(), grant)
+	Expect(err).ToNot(HaveOccurred())
+
+	spec := TriggerAuthenticationSpec{
+		SecretTargetRef: []AuthSecretTargetRef{{Parameter: "password", Name: "broker-credentials", Key: "password", Namespace: secretNamespaceName}},
+	}
+	ta := createTriggerAuthentication("secretnotgrantedta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when plaintext vault token is set and encrypted inline secrets are required", func() {
+	namespaceName := "plaintextvaulttoken"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(os.Setenv(util.RequireEncryptedInlineSecretsEnvVar, "true")).To(Succeed())
+	defer os.Unsetenv(util.RequireEncryptedInlineSecretsEnvVar)
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationToken, "", &Credential{Token: "plaintext-token"})
+	ta := createTriggerAuthentication("plaintextvaulttokenta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).Should(HaveOccurred())
+})
+
+var _ = It("validate triggerauthentication when encrypted vault token is set and encrypted inline secrets are required", func() {
+	namespaceName := "encryptedvaulttoken"
+	namespace := createNamespace(namespaceName)
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(os.Setenv(util.RequireEncryptedInlineSecretsEnvVar, "true")).To(Succeed())
+	defer os.Unsetenv(util.RequireEncryptedInlineSecretsEnvVar)
+
+	spec := createTriggerAuthenticationSpecWithVault(VaultAuthenticationToken, "", &Credential{Token: kms.EnvelopePrefix + "eyJwcm92aWRlciI6ImFlcy1rZXlmaWxlIn0"})
+	ta := createTriggerAuthentication("encryptedvaulttokenta", namespaceName, "TriggerAuthentication", spec)
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), ta)
+	}).ShouldNot(HaveOccurred())
+})
+
 func createTriggerAuthenticationSpecWithPodIdentity(provider PodIdentityProvider, roleArn, identityID, identityTenantID, identityAuthorityHost, identityOwner *string) TriggerAuthenticationSpec {
 	return TriggerAuthenticationSpec{
 		PodIdentity: &AuthPodIdentity{
@@ -340,6 +686,28 @@ func createTriggerAuthenticationSpecWithPodIdentity(provider PodIdentityProvider
 	}
 }
 
+func createTriggerAuthenticationSpecWithVault(authentication VaultAuthentication, role string, credential *Credential) TriggerAuthenticationSpec {
+	return TriggerAuthenticationSpec{
+		HashiCorpVault: &HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: authentication,
+			Role:           role,
+			Credential:     credential,
+			Secrets: []VaultSecret{
+				{Parameter: "password", Path: "secret/data/keda", Key: "password"},
+			},
+		},
+	}
+}
+
+func createVaultCredentialValue(name, key string) VaultCredentialValue {
+	return VaultCredentialValue{
+		ValueFrom: ValueFromSecret{
+			SecretKeyRef: SecretKeyRef{Name: name, Key: key},
+		},
+	}
+}
+
 func createTriggerAuthentication(name, namespace, targetKind string, spec TriggerAuthenticationSpec) *TriggerAuthentication {
 	return &TriggerAuthentication{
 		ObjectMeta: metav1.ObjectMeta{
@@ -353,3 +721,16 @@ func createTriggerAuthentication(name, namespace, targetKind string, spec Trigge
 		Spec: spec,
 	}
 }
+
+func createTriggerAuthenticationGrant(name, namespace, fromNamespace, secretName string) *TriggerAuthenticationGrant {
+	return &TriggerAuthenticationGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: TriggerAuthenticationGrantSpec{
+			From: []TriggerAuthenticationGrantFrom{{Namespace: fromNamespace}},
+			To:   []TriggerAuthenticationGrantTo{{Name: secretName}},
+		},
+	}
+}
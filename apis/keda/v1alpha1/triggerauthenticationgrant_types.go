@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TriggerAuthenticationGrant allows TriggerAuthentications in other namespaces to reference Secrets
+// from the namespace of the grant
+// +genclient
+// +genclient:noStatus
+// +kubebuilder:resource:path=triggerauthenticationgrants,scope=Namespaced,shortName=tagrant
+// +kubebuilder:printcolumn:name="From",type="string",JSONPath=".spec.from[*].namespace"
+// +kubebuilder:printcolumn:name="Secrets",type="string",JSONPath=".spec.to[*].name"
+type TriggerAuthenticationGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TriggerAuthenticationGrantSpec `json:"spec"`
+}
+
+// TriggerAuthenticationGrantSpec defines which namespaces are allowed to reference which Secrets
+type TriggerAuthenticationGrantSpec struct {
+	// From lists the namespaces whose TriggerAuthentications can reference the Secrets,
+	// for ClusterTriggerAuthentications it's the namespace where KEDA looks for cluster object resources
+	// +kubebuilder:validation:MinItems=1
+	From []TriggerAuthenticationGrantFrom `json:"from"`
+
+	// To lists the Secrets that can be referenced
+	// +kubebuilder:validation:MinItems=1
+	To []TriggerAuthenticationGrantTo `json:"to"`
+}
+
+// TriggerAuthenticationGrantFrom identifies a namespace allowed to reference the granted Secrets
+type TriggerAuthenticationGrantFrom struct {
+	Namespace string `json:"namespace"`
+}
+
+// TriggerAuthenticationGrantTo identifies a Secret that can be referenced
+type TriggerAuthenticationGrantTo struct {
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TriggerAuthenticationGrantList contains a list of TriggerAuthenticationGrant
+type TriggerAuthenticationGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []TriggerAuthenticationGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TriggerAuthenticationGrant{}, &TriggerAuthenticationGrantList{})
+}
+
+// Allows returns whether the grant allows the namespace to reference the Secret
+func (g *TriggerAuthenticationGrant) Allows(namespace, secretName string) bool {
+	fromAllowed := false
+	for _, from := range g.Spec.From {
+		if from.Namespace == namespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+
+	for _, to := range g.Spec.To {
+		if to.Name == secretName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSecretReferenceGranted returns whether any of the grants allows the namespace to reference the Secret
+func IsSecretReferenceGranted(grants []TriggerAuthenticationGrant, namespace, secretName string) bool {
+	for i := range grants {
+		if grants[i].Allows(namespace, secretName) {
+			return true
+		}
+	}
+	return false
+}
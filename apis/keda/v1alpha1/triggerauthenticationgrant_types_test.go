@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestIsSecretReferenceGranted(t *testing.T) {
+	grants := []TriggerAuthenticationGrant{
+		*createTriggerAuthenticationGrant("tenant-a", "shared", "tenant-a", "broker"),
+		*createTriggerAuthenticationGrant("tenant-b", "shared", "tenant-b", "database"),
+	}
+
+	tests := []struct {
+		name       string
+		namespace  string
+		secretName string
+		expected   bool
+	}{
+		{name: "granted secret", namespace: "tenant-a", secretName: "broker", expected: true},
+		{name: "secret granted to another namespace", namespace: "tenant-a", secretName: "database", expected: false},
+		{name: "namespace without grant", namespace: "tenant-c", secretName: "broker", expected: false},
+		{name: "secret without grant", namespace: "tenant-b", secretName: "broker", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if granted := IsSecretReferenceGranted(grants, test.namespace, test.secretName); granted != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, granted)
+			}
+		})
+	}
+}
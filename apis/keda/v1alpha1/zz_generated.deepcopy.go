@@ -35,6 +35,11 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.ScalingModifiers = in.ScalingModifiers
+	if in.ZeroScalingStaging != nil {
+		in, out := &in.ZeroScalingStaging, &out.ZeroScalingStaging
+		*out = new(ZeroScalingStaging)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -77,6 +82,21 @@ func (in *AuthEnvironment) DeepCopy() *AuthEnvironment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthFilePath) DeepCopyInto(out *AuthFilePath) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthFilePath.
+func (in *AuthFilePath) DeepCopy() *AuthFilePath {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthFilePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthPodIdentity) DeepCopyInto(out *AuthPodIdentity) {
 	*out = *in
@@ -105,6 +125,11 @@ func (in *AuthPodIdentity) DeepCopyInto(out *AuthPodIdentity) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(AuthPodIdentityOIDC)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthPodIdentity.
@@ -117,6 +142,26 @@ func (in *AuthPodIdentity) DeepCopy() *AuthPodIdentity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthPodIdentityOIDC) DeepCopyInto(out *AuthPodIdentityOIDC) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthPodIdentityOIDC.
+func (in *AuthPodIdentityOIDC) DeepCopy() *AuthPodIdentityOIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthPodIdentityOIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthSecretTargetRef) DeepCopyInto(out *AuthSecretTargetRef) {
 	*out = *in
@@ -132,6 +177,31 @@ func (in *AuthSecretTargetRef) DeepCopy() *AuthSecretTargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthServiceAccountToken) DeepCopyInto(out *AuthServiceAccountToken) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IdentityOwner != nil {
+		in, out := &in.IdentityOwner, &out.IdentityOwner
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthServiceAccountToken.
+func (in *AuthServiceAccountToken) DeepCopy() *AuthServiceAccountToken {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthServiceAccountToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthTargetRef) DeepCopyInto(out *AuthTargetRef) {
 	*out = *in
@@ -147,6 +217,21 @@ func (in *AuthTargetRef) DeepCopy() *AuthTargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationDependent) DeepCopyInto(out *AuthenticationDependent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationDependent.
+func (in *AuthenticationDependent) DeepCopy() *AuthenticationDependent {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationDependent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationRef) DeepCopyInto(out *AuthenticationRef) {
 	*out = *in
@@ -162,6 +247,22 @@ func (in *AuthenticationRef) DeepCopy() *AuthenticationRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationSourceError) DeepCopyInto(out *AuthenticationSourceError) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSourceError.
+func (in *AuthenticationSourceError) DeepCopy() *AuthenticationSourceError {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationSourceError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsSecretManager) DeepCopyInto(out *AwsSecretManager) {
 	*out = *in
@@ -360,7 +461,7 @@ func (in *ClusterTriggerAuthentication) DeepCopyInto(out *ClusterTriggerAuthenti
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTriggerAuthentication.
@@ -450,6 +551,26 @@ func (in Conditions) DeepCopy() Conditions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Credential) DeepCopyInto(out *Credential) {
 	*out = *in
+	if in.AppRole != nil {
+		in, out := &in.AppRole, &out.AppRole
+		*out = new(VaultAppRoleCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(VaultJWTCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(VaultAWSCredential)
+		**out = **in
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(VaultCertCredential)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
@@ -462,6 +583,108 @@ func (in *Credential) DeepCopy() *Credential {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretProvider) DeepCopyInto(out *ExternalSecretProvider) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]ExternalSecretProviderSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalSecretProviderTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretProvider.
+func (in *ExternalSecretProvider) DeepCopy() *ExternalSecretProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretProviderSecret) DeepCopyInto(out *ExternalSecretProviderSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretProviderSecret.
+func (in *ExternalSecretProviderSecret) DeepCopy() *ExternalSecretProviderSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretProviderSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretProviderTLS) DeepCopyInto(out *ExternalSecretProviderTLS) {
+	*out = *in
+	if in.CACert != nil {
+		in, out := &in.CACert, &out.CACert
+		*out = new(ValueFromSecret)
+		**out = **in
+	}
+	if in.ClientCert != nil {
+		in, out := &in.ClientCert, &out.ClientCert
+		*out = new(ValueFromSecret)
+		**out = **in
+	}
+	if in.ClientKey != nil {
+		in, out := &in.ClientKey, &out.ClientKey
+		*out = new(ValueFromSecret)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretProviderTLS.
+func (in *ExternalSecretProviderTLS) DeepCopy() *ExternalSecretProviderTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretProviderTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureBackoff) DeepCopyInto(out *FailureBackoff) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDelaySeconds != nil {
+		in, out := &in.MaxDelaySeconds, &out.MaxDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureBackoff.
+func (in *FailureBackoff) DeepCopy() *FailureBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fallback) DeepCopyInto(out *Fallback) {
 	*out = *in
@@ -580,7 +803,7 @@ func (in *HashiCorpVault) DeepCopyInto(out *HashiCorpVault) {
 	if in.Credential != nil {
 		in, out := &in.Credential, &out.Credential
 		*out = new(Credential)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -634,6 +857,31 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaCountSchedule) DeepCopyInto(out *ReplicaCountSchedule) {
+	*out = *in
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaCountSchedule.
+func (in *ReplicaCountSchedule) DeepCopy() *ReplicaCountSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaCountSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
@@ -649,6 +897,21 @@ func (in *Rollout) DeepCopy() *Rollout {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDownStep) DeepCopyInto(out *ScaleDownStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDownStep.
+func (in *ScaleDownStep) DeepCopy() *ScaleDownStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDownStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTarget) DeepCopyInto(out *ScaleTarget) {
 	*out = *in
@@ -785,6 +1048,11 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		**out = **in
 	}
 	in.ScalingStrategy.DeepCopyInto(&out.ScalingStrategy)
+	if in.FailureBackoff != nil {
+		in, out := &in.FailureBackoff, &out.FailureBackoff
+		*out = new(FailureBackoff)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -935,6 +1203,13 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ReplicaCountSchedules != nil {
+		in, out := &in.ReplicaCountSchedules, &out.ReplicaCountSchedules
+		*out = make([]ReplicaCountSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Advanced != nil {
 		in, out := &in.Advanced, &out.Advanced
 		*out = new(AdvancedConfig)
@@ -1008,6 +1283,11 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ScalingStage != nil {
+		in, out := &in.ScalingStage, &out.ScalingStage
+		*out = new(ScalingStageStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectStatus.
@@ -1035,6 +1315,22 @@ func (in *ScalingModifiers) DeepCopy() *ScalingModifiers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingStageStatus) DeepCopyInto(out *ScalingStageStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingStageStatus.
+func (in *ScalingStageStatus) DeepCopy() *ScalingStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingStrategy) DeepCopyInto(out *ScalingStrategy) {
 	*out = *in
@@ -1081,7 +1377,7 @@ func (in *TriggerAuthentication) DeepCopyInto(out *TriggerAuthentication) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthentication.
@@ -1102,6 +1398,119 @@ func (in *TriggerAuthentication) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrant) DeepCopyInto(out *TriggerAuthenticationGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrant.
+func (in *TriggerAuthenticationGrant) DeepCopy() *TriggerAuthenticationGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerAuthenticationGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantFrom) DeepCopyInto(out *TriggerAuthenticationGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantFrom.
+func (in *TriggerAuthenticationGrantFrom) DeepCopy() *TriggerAuthenticationGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantList) DeepCopyInto(out *TriggerAuthenticationGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TriggerAuthenticationGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantList.
+func (in *TriggerAuthenticationGrantList) DeepCopy() *TriggerAuthenticationGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerAuthenticationGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantSpec) DeepCopyInto(out *TriggerAuthenticationGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]TriggerAuthenticationGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]TriggerAuthenticationGrantTo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantSpec.
+func (in *TriggerAuthenticationGrantSpec) DeepCopy() *TriggerAuthenticationGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantTo) DeepCopyInto(out *TriggerAuthenticationGrantTo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantTo.
+func (in *TriggerAuthenticationGrantTo) DeepCopy() *TriggerAuthenticationGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerAuthenticationList) DeepCopyInto(out *TriggerAuthenticationList) {
 	*out = *in
@@ -1157,6 +1566,16 @@ func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec
 		*out = make([]AuthEnvironment, len(*in))
 		copy(*out, *in)
 	}
+	if in.FilePath != nil {
+		in, out := &in.FilePath, &out.FilePath
+		*out = make([]AuthFilePath, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(AuthServiceAccountToken)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HashiCorpVault != nil {
 		in, out := &in.HashiCorpVault, &out.HashiCorpVault
 		*out = new(HashiCorpVault)
@@ -1177,6 +1596,11 @@ func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec
 		*out = new(AwsSecretManager)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalSecretProvider != nil {
+		in, out := &in.ExternalSecretProvider, &out.ExternalSecretProvider
+		*out = new(ExternalSecretProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationSpec.
@@ -1192,6 +1616,27 @@ func (in *TriggerAuthenticationSpec) DeepCopy() *TriggerAuthenticationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerAuthenticationStatus) DeepCopyInto(out *TriggerAuthenticationStatus) {
 	*out = *in
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = make([]AuthenticationDependent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceErrors != nil {
+		in, out := &in.SourceErrors, &out.SourceErrors
+		*out = make([]AuthenticationSourceError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSuccessfulResolutionTime != nil {
+		in, out := &in.LastSuccessfulResolutionTime, &out.LastSuccessfulResolutionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationStatus.
@@ -1220,6 +1665,95 @@ func (in *ValueFromSecret) DeepCopy() *ValueFromSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAWSCredential) DeepCopyInto(out *VaultAWSCredential) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAWSCredential.
+func (in *VaultAWSCredential) DeepCopy() *VaultAWSCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAWSCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAppRoleCredential) DeepCopyInto(out *VaultAppRoleCredential) {
+	*out = *in
+	out.RoleID = in.RoleID
+	if in.SecretID != nil {
+		in, out := &in.SecretID, &out.SecretID
+		*out = new(VaultCredentialValue)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAppRoleCredential.
+func (in *VaultAppRoleCredential) DeepCopy() *VaultAppRoleCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAppRoleCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCertCredential) DeepCopyInto(out *VaultCertCredential) {
+	*out = *in
+	out.Cert = in.Cert
+	out.Key = in.Key
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCertCredential.
+func (in *VaultCertCredential) DeepCopy() *VaultCertCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCertCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialValue) DeepCopyInto(out *VaultCredentialValue) {
+	*out = *in
+	out.ValueFrom = in.ValueFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialValue.
+func (in *VaultCredentialValue) DeepCopy() *VaultCredentialValue {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultJWTCredential) DeepCopyInto(out *VaultJWTCredential) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultJWTCredential.
+func (in *VaultJWTCredential) DeepCopy() *VaultJWTCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultJWTCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultPkiData) DeepCopyInto(out *VaultPkiData) {
 	*out = *in
@@ -1335,3 +1869,28 @@ func (in *WithTriggersSpec) DeepCopy() *WithTriggersSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZeroScalingStaging) DeepCopyInto(out *ZeroScalingStaging) {
+	*out = *in
+	if in.ActivationReplicaCount != nil {
+		in, out := &in.ActivationReplicaCount, &out.ActivationReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDownSteps != nil {
+		in, out := &in.ScaleDownSteps, &out.ScaleDownSteps
+		*out = make([]ScaleDownStep, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZeroScalingStaging.
+func (in *ZeroScalingStaging) DeepCopy() *ZeroScalingStaging {
+	if in == nil {
+		return nil
+	}
+	out := new(ZeroScalingStaging)
+	in.DeepCopyInto(out)
+	return out
+}
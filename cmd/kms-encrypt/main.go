@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kms-encrypt encrypts a value read from stdin into an envelope that can be set inline in a KEDA resource,
+// e.g. the Vault token of a TriggerAuthentication:
+//
+//	kms-encrypt --keyfile keys.txt --namespace default --field hashiCorpVault.credential.token < token.txt
+//
+// or the literal value of the environment variable TOKEN read by a TriggerAuthentication env:
+//
+//	kms-encrypt --keyfile keys.txt --namespace default --field env.TOKEN < token.txt
+//
+// The value is bound to the namespace and the field, values of a ClusterTriggerAuthentication use an empty namespace.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kedacore/keda/v2/pkg/kms"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+func main() {
+	var keyfile, keyID, namespace, field string
+	flag.StringVar(&keyfile, "keyfile", kedautil.GetKMSAESKeyfile(), "Path of the aes-keyfile kms provider keyfile, defaults to "+kedautil.KMSAESKeyfileEnvVar+" environment variable")
+	flag.StringVar(&keyID, "key-id", "", "ID of the key wrapping the data encryption key, defaults to the first key of the keyfile")
+	flag.StringVar(&namespace, "namespace", "", "Namespace of the resource holding the value, empty for a ClusterTriggerAuthentication")
+	flag.StringVar(&field, "field", "", "Field path holding the value: "+kms.HashiCorpVaultTokenFieldPath+" or env.<NAME> for the literal value of an environment variable")
+	flag.Parse()
+
+	if err := run(context.Background(), os.Stdin, os.Stdout, keyfile, keyID, kms.Binding{Namespace: namespace, FieldPath: field}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, in io.Reader, out io.Writer, keyfile, keyID string, binding kms.Binding) error {
+	if keyfile == "" {
+		return fmt.Errorf("--keyfile is required")
+	}
+	if binding.FieldPath == "" {
+		return fmt.Errorf("--field is required")
+	}
+	provider, err := kms.NewAESKeyfileProvider(keyfile)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("error reading value from stdin: %w", err)
+	}
+	// values piped from a file or echo usually end with a newline that isn't part of the secret
+	value := strings.TrimRight(string(plaintext), "\r\n")
+	if value == "" {
+		return fmt.Errorf("value read from stdin is empty")
+	}
+
+	encrypted, err := kms.Encrypt(ctx, provider, keyID, binding, []byte(value))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, encrypted)
+	return err
+}
@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -45,8 +46,11 @@ import (
 	"github.com/kedacore/keda/v2/pkg/certificates"
 	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/k8s"
+	"github.com/kedacore/keda/v2/pkg/kms"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scalers/otlp"
 	"github.com/kedacore/keda/v2/pkg/scaling"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
@@ -71,6 +75,9 @@ func main() {
 	var metricsAddr string
 	var probeAddr string
 	var metricsServiceAddr string
+	var otlpReceiverGrpcAddr string
+	var otlpReceiverHTTPAddr string
+	var otlpReceiverTokensFile string
 	var profilingAddr string
 	var enableLeaderElection bool
 	var adapterClientRequestQPS float32
@@ -90,6 +97,9 @@ func main() {
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the prometheus metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	pflag.StringVar(&metricsServiceAddr, "metrics-service-bind-address", ":9666", "The address the gRPRC Metrics Service endpoint binds to.")
+	pflag.StringVar(&otlpReceiverGrpcAddr, "otlp-receiver-grpc-bind-address", "", "The address the OTLP/gRPC receiver of the otlp scaler binds to. Disabled when empty.")
+	pflag.StringVar(&otlpReceiverHTTPAddr, "otlp-receiver-http-bind-address", "", "The address the OTLP/HTTP receiver of the otlp scaler binds to. Disabled when empty.")
+	pflag.StringVar(&otlpReceiverTokensFile, "otlp-receiver-tokens-file", "", "The file with the namespace:token lines of the bearer tokens accepted by the OTLP receiver. Required when the receiver is enabled.")
 	pflag.StringVar(&profilingAddr, "profiling-bind-address", "", "The address the profiling would be exposed on.")
 	pflag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
@@ -191,6 +201,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if keyfile := kedautil.GetKMSAESKeyfile(); keyfile != "" {
+		kmsProvider, err := kms.NewAESKeyfileProvider(keyfile)
+		if err != nil {
+			setupLog.Error(err, "invalid "+kedautil.KMSAESKeyfileEnvVar)
+			os.Exit(1)
+		}
+		kms.RegisterProvider(kmsProvider)
+	}
+
 	globalHTTPTimeout := time.Duration(globalHTTPTimeoutMS) * time.Millisecond
 	eventRecorder := mgr.GetEventRecorderFor("keda-operator")
 	eventEmitter := eventemitter.NewEventEmitter(mgr.GetClient(), eventRecorder, k8sClusterName)
@@ -216,6 +235,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the kubernetes-resource scalers read the allowed kinds from the informers of the manager cache
+	scalers.KubernetesResourceReader = scalers.NewKubernetesResourceAllowedReader(mgr.GetCache())
 	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
@@ -258,6 +279,22 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterTriggerAuthentication")
 		os.Exit(1)
 	}
+	triggerAuthSecretReconciler := &kedacontrollers.TriggerAuthenticationSecretReconciler{
+		ScaleHandler: scaledHandler,
+	}
+	if strings.ToLower(kedautil.GetRestrictSecretAccess()) == "true" {
+		triggerAuthSecretReconciler.SecretsInformer = secretInformer.Informer()
+	}
+	if err = triggerAuthSecretReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TriggerAuthenticationSecret")
+		os.Exit(1)
+	}
+	if err = (&kedacontrollers.TriggerAuthenticationGrantReconciler{
+		ScaleHandler: scaledHandler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TriggerAuthenticationGrant")
+		os.Exit(1)
+	}
 	if err = (eventingcontrollers.NewCloudEventSourceReconciler(
 		mgr.GetClient(),
 		eventEmitter,
@@ -306,6 +343,29 @@ func main() {
 		os.Exit(1)
 	}
 
+	if otlpReceiverGrpcAddr != "" || otlpReceiverHTTPAddr != "" {
+		if otlpReceiverTokensFile == "" {
+			setupLog.Error(nil, "--otlp-receiver-tokens-file is required when the OTLP receiver is enabled")
+			os.Exit(1)
+		}
+		tokens, err := otlp.LoadTokens(otlpReceiverTokensFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load OTLP receiver tokens")
+			os.Exit(1)
+		}
+		receiver := otlp.NewReceiver(otlp.DefaultStore, otlp.ReceiverConfig{
+			GRPCAddress: otlpReceiverGrpcAddr,
+			HTTPAddress: otlpReceiverHTTPAddr,
+			Tokens:      tokens,
+			CertDir:     certDir,
+			CertsReady:  certReady,
+		})
+		if err := mgr.Add(receiver); err != nil {
+			setupLog.Error(err, "unable to set up OTLP receiver")
+			os.Exit(1)
+		}
+	}
+
 	kedautil.PrintWelcome(setupLog, kubeVersion, "manager")
 
 	kubeInformerFactory.Start(ctx.Done())
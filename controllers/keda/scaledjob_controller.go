@@ -376,6 +376,7 @@ func (r *ScaledJobReconciler) updatePromMetricsOnDelete(namespacedName string) {
 func (r *ScaledJobReconciler) updateTriggerAuthenticationStatus(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) (string, error) {
 	return kedastatus.UpdateTriggerAuthenticationStatusFromTriggers(ctx, logger, r.Client, scaledJob.GetNamespace(), scaledJob.Spec.Triggers, func(triggerAuthenticationStatus *kedav1alpha1.TriggerAuthenticationStatus) *kedav1alpha1.TriggerAuthenticationStatus {
 		triggerAuthenticationStatus.ScaledJobNamesStr = kedacontrollerutil.AppendIntoString(triggerAuthenticationStatus.ScaledJobNamesStr, scaledJob.GetName(), ",")
+		triggerAuthenticationStatus.AddDependent(kedav1alpha1.AuthenticationDependent{Kind: "ScaledJob", Namespace: scaledJob.GetNamespace(), Name: scaledJob.GetName()})
 		return triggerAuthenticationStatus
 	})
 }
@@ -383,6 +384,7 @@ func (r *ScaledJobReconciler) updateTriggerAuthenticationStatus(ctx context.Cont
 func (r *ScaledJobReconciler) updateTriggerAuthenticationStatusOnDelete(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) (string, error) {
 	return kedastatus.UpdateTriggerAuthenticationStatusFromTriggers(ctx, logger, r.Client, scaledJob.GetNamespace(), scaledJob.Spec.Triggers, func(triggerAuthenticationStatus *kedav1alpha1.TriggerAuthenticationStatus) *kedav1alpha1.TriggerAuthenticationStatus {
 		triggerAuthenticationStatus.ScaledJobNamesStr = kedacontrollerutil.RemoveFromString(triggerAuthenticationStatus.ScaledJobNamesStr, scaledJob.GetName(), ",")
+		triggerAuthenticationStatus.RemoveDependent(kedav1alpha1.AuthenticationDependent{Kind: "ScaledJob", Namespace: scaledJob.GetNamespace(), Name: scaledJob.GetName()})
 		return triggerAuthenticationStatus
 	})
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
@@ -59,6 +60,7 @@ import (
 // +kubebuilder:rbac:groups="",resources=pods;services;services;secrets;external,verbs=get;list;watch
 // +kubebuilder:rbac:groups="*",resources="*/scale",verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources="serviceaccounts",verbs=list;watch
+// +kubebuilder:rbac:groups="",resources="serviceaccounts/token",verbs=create
 // +kubebuilder:rbac:groups="*",resources="*",verbs=get
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",namespace=keda,resources=leases,verbs="*"
@@ -212,6 +214,11 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		reqLogger.Error(err, "Failed to update TriggerAuthentication Status after removing a finalizer")
 	}
 
+	// Requeue when the next replica count schedule starts or ends, so the HPA gets the overridden min/max replicas
+	if nextTransition := scaledObject.GetNextReplicaCountScheduleTransition(time.Now()); err == nil && !nextTransition.IsZero() {
+		return ctrl.Result{RequeueAfter: time.Until(nextTransition)}, nil
+	}
+
 	return ctrl.Result{}, err
 }
 
@@ -276,10 +283,21 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct triggers specification", err
 	}
 
-	// Create a new HPA or update existing one according to ScaledObject
-	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
-	if err != nil {
-		return "failed to ensure HPA is correctly created for ScaledObject", err
+	newHPACreated := false
+	if scaledObject.IsUsingDirectScaling() {
+		// In direct scaling mode KEDA scales the target itself, so there mustn't be any HPA competing with it
+		if gvkr.Group != "apps" || (gvkr.Kind != "Deployment" && gvkr.Kind != "StatefulSet") {
+			return "ScaledObject doesn't have correct scaleTargetRef specification", fmt.Errorf("direct scaling is supported only for Deployments and StatefulSets, got %s", gvkr.GVKString())
+		}
+		if _, err := r.ensureHPAForScaledObjectIsDeleted(ctx, logger, scaledObject); err != nil {
+			return "failed to ensure HPA is deleted for ScaledObject in direct scaling mode", err
+		}
+	} else {
+		// Create a new HPA or update existing one according to ScaledObject
+		newHPACreated, err = r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+		if err != nil {
+			return "failed to ensure HPA is correctly created for ScaledObject", err
+		}
 	}
 	scaleObjectSpecChanged := false
 	if !newHPACreated {
@@ -604,6 +622,7 @@ func (r *ScaledObjectReconciler) updateTriggerAuthenticationStatus(ctx context.C
 	return kedastatus.UpdateTriggerAuthenticationStatusFromTriggers(ctx, logger, r.Client, scaledObject.GetNamespace(), scaledObject.Spec.Triggers,
 		func(triggerAuthenticationStatus *kedav1alpha1.TriggerAuthenticationStatus) *kedav1alpha1.TriggerAuthenticationStatus {
 			triggerAuthenticationStatus.ScaledObjectNamesStr = kedacontrollerutil.AppendIntoString(triggerAuthenticationStatus.ScaledObjectNamesStr, scaledObject.GetName(), ",")
+			triggerAuthenticationStatus.AddDependent(kedav1alpha1.AuthenticationDependent{Kind: "ScaledObject", Namespace: scaledObject.GetNamespace(), Name: scaledObject.GetName()})
 			return triggerAuthenticationStatus
 		})
 }
@@ -612,6 +631,7 @@ func (r *ScaledObjectReconciler) updateTriggerAuthenticationStatusOnDelete(ctx c
 	return kedastatus.UpdateTriggerAuthenticationStatusFromTriggers(ctx, logger, r.Client, scaledObject.GetNamespace(), scaledObject.Spec.Triggers,
 		func(triggerAuthenticationStatus *kedav1alpha1.TriggerAuthenticationStatus) *kedav1alpha1.TriggerAuthenticationStatus {
 			triggerAuthenticationStatus.ScaledObjectNamesStr = kedacontrollerutil.RemoveFromString(triggerAuthenticationStatus.ScaledObjectNamesStr, scaledObject.GetName(), ",")
+			triggerAuthenticationStatus.RemoveDependent(kedav1alpha1.AuthenticationDependent{Kind: "ScaledObject", Namespace: scaledObject.GetNamespace(), Name: scaledObject.GetName()})
 			return triggerAuthenticationStatus
 		})
 }
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+// TriggerAuthenticationSecretReconciler watches Secrets referenced by cached authentication parameters
+// and invalidates the parameters and the scalers using them when the Secrets change
+type TriggerAuthenticationSecretReconciler struct {
+	ScaleHandler scaling.ScaleHandler
+	// SecretsInformer is used instead of the manager cache when secret access is restricted to KEDA namespace
+	SecretsInformer ctrlcache.Informer
+}
+
+// Reconcile invalidates the authentication parameters resolved from the identified Secret
+func (r *TriggerAuthenticationSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	authenticationKeys := resolver.InvalidateAuthCacheForSecret(req.Namespace, req.Name)
+	if len(authenticationKeys) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	log.FromContext(ctx).V(1).Info("Referenced Secret has changed, invalidating authentication parameters", "authentications", authenticationKeys)
+	r.ScaleHandler.ClearScalersCachesForAuthentications(ctx, authenticationKeys)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TriggerAuthenticationSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	referencedSecret := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return resolver.IsSecretReferencedByAuthCache(object.GetNamespace(), object.GetName())
+	})
+
+	b := ctrl.NewControllerManagedBy(mgr).Named("triggerauthentication_secret")
+	if r.SecretsInformer != nil {
+		b = b.WatchesRawSource(&source.Informer{Informer: r.SecretsInformer}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(referencedSecret))
+	} else {
+		b = b.Watches(&corev1.Secret{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(referencedSecret))
+	}
+	return b.Complete(r)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+// +kubebuilder:rbac:groups=keda.sh,resources=triggerauthenticationgrants,verbs=get;list;watch
+
+// TriggerAuthenticationGrantReconciler invalidates the authentication parameters resolved from Secrets
+// in the namespace of a TriggerAuthenticationGrant when the grant changes, so revoked references stop being used
+type TriggerAuthenticationGrantReconciler struct {
+	ScaleHandler scaling.ScaleHandler
+}
+
+// Reconcile invalidates the authentication parameters resolved from Secrets in the namespace of the grant
+func (r *TriggerAuthenticationGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	authenticationKeys := resolver.InvalidateAuthCacheForNamespace(req.Namespace)
+	if len(authenticationKeys) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	log.FromContext(ctx).V(1).Info("TriggerAuthenticationGrant has changed, invalidating authentication parameters", "authentications", authenticationKeys)
+	r.ScaleHandler.ClearScalersCachesForAuthentications(ctx, authenticationKeys)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TriggerAuthenticationGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.TriggerAuthenticationGrant{}).
+		Complete(r)
+}
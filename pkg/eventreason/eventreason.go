@@ -65,9 +65,24 @@ const (
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetScaleDownStep is for event when the scale target of ScaledObject was scaled to a scale down step
+	KEDAScaleTargetScaleDownStep = "KEDAScaleTargetScaleDownStep"
+
+	// KEDAScaleTargetScaled is for event when the scale target of ScaledObject was scaled in direct scaling mode
+	KEDAScaleTargetScaled = "KEDAScaleTargetScaled"
+
+	// KEDAScaleTargetScalingFailed is for event when scaling of the scale target of ScaledObject fails in direct scaling mode
+	KEDAScaleTargetScalingFailed = "KEDAScaleTargetScalingFailed"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
+	// KEDAJobsCreationBackoff is for event when jobs creation for ScaledJob is paused because of failing jobs
+	KEDAJobsCreationBackoff = "KEDAJobsCreationBackoff"
+
+	// KEDAJobsCreationResumed is for event when jobs creation for ScaledJob is resumed after a successful job
+	KEDAJobsCreationResumed = "KEDAJobsCreationResumed"
+
 	// TriggerAuthenticationDeleted is for event when a TriggerAuthentication is deleted
 	TriggerAuthenticationDeleted = "TriggerAuthenticationDeleted"
 
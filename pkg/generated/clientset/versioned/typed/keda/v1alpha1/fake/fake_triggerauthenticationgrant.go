@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTriggerAuthenticationGrants implements TriggerAuthenticationGrantInterface
+type FakeTriggerAuthenticationGrants struct {
+	Fake *FakeKedaV1alpha1
+	ns   string
+}
+
+var triggerauthenticationgrantsResource = v1alpha1.SchemeGroupVersion.WithResource("triggerauthenticationgrants")
+
+var triggerauthenticationgrantsKind = v1alpha1.SchemeGroupVersion.WithKind("TriggerAuthenticationGrant")
+
+// Get takes name of the triggerAuthenticationGrant, and returns the corresponding triggerAuthenticationGrant object, and an error if there is any.
+func (c *FakeTriggerAuthenticationGrants) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(triggerauthenticationgrantsResource, c.ns, name), &v1alpha1.TriggerAuthenticationGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TriggerAuthenticationGrant), err
+}
+
+// List takes label and field selectors, and returns the list of TriggerAuthenticationGrants that match those selectors.
+func (c *FakeTriggerAuthenticationGrants) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TriggerAuthenticationGrantList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(triggerauthenticationgrantsResource, triggerauthenticationgrantsKind, c.ns, opts), &v1alpha1.TriggerAuthenticationGrantList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.TriggerAuthenticationGrantList{ListMeta: obj.(*v1alpha1.TriggerAuthenticationGrantList).ListMeta}
+	for _, item := range obj.(*v1alpha1.TriggerAuthenticationGrantList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested triggerAuthenticationGrants.
+func (c *FakeTriggerAuthenticationGrants) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(triggerauthenticationgrantsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a triggerAuthenticationGrant and creates it.  Returns the server's representation of the triggerAuthenticationGrant, and an error, if there is any.
+func (c *FakeTriggerAuthenticationGrants) Create(ctx context.Context, triggerAuthenticationGrant *v1alpha1.TriggerAuthenticationGrant, opts v1.CreateOptions) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(triggerauthenticationgrantsResource, c.ns, triggerAuthenticationGrant), &v1alpha1.TriggerAuthenticationGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TriggerAuthenticationGrant), err
+}
+
+// Update takes the representation of a triggerAuthenticationGrant and updates it. Returns the server's representation of the triggerAuthenticationGrant, and an error, if there is any.
+func (c *FakeTriggerAuthenticationGrants) Update(ctx context.Context, triggerAuthenticationGrant *v1alpha1.TriggerAuthenticationGrant, opts v1.UpdateOptions) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(triggerauthenticationgrantsResource, c.ns, triggerAuthenticationGrant), &v1alpha1.TriggerAuthenticationGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TriggerAuthenticationGrant), err
+}
+
+// Delete takes name of the triggerAuthenticationGrant and deletes it. Returns an error if one occurs.
+func (c *FakeTriggerAuthenticationGrants) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(triggerauthenticationgrantsResource, c.ns, name, opts), &v1alpha1.TriggerAuthenticationGrant{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTriggerAuthenticationGrants) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(triggerauthenticationgrantsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.TriggerAuthenticationGrantList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched triggerAuthenticationGrant.
+func (c *FakeTriggerAuthenticationGrants) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(triggerauthenticationgrantsResource, c.ns, name, pt, data, subresources...), &v1alpha1.TriggerAuthenticationGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TriggerAuthenticationGrant), err
+}
@@ -25,3 +25,5 @@ type ScaledJobExpansion interface{}
 type ScaledObjectExpansion interface{}
 
 type TriggerAuthenticationExpansion interface{}
+
+type TriggerAuthenticationGrantExpansion interface{}
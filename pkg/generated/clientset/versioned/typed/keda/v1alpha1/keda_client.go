@@ -32,6 +32,7 @@ type KedaV1alpha1Interface interface {
 	ScaledJobsGetter
 	ScaledObjectsGetter
 	TriggerAuthenticationsGetter
+	TriggerAuthenticationGrantsGetter
 }
 
 // KedaV1alpha1Client is used to interact with features provided by the keda group.
@@ -55,6 +56,10 @@ func (c *KedaV1alpha1Client) TriggerAuthentications(namespace string) TriggerAut
 	return newTriggerAuthentications(c, namespace)
 }
 
+func (c *KedaV1alpha1Client) TriggerAuthenticationGrants(namespace string) TriggerAuthenticationGrantInterface {
+	return newTriggerAuthenticationGrants(c, namespace)
+}
+
 // NewForConfig creates a new KedaV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	scheme "github.com/kedacore/keda/v2/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// TriggerAuthenticationGrantsGetter has a method to return a TriggerAuthenticationGrantInterface.
+// A group's client should implement this interface.
+type TriggerAuthenticationGrantsGetter interface {
+	TriggerAuthenticationGrants(namespace string) TriggerAuthenticationGrantInterface
+}
+
+// TriggerAuthenticationGrantInterface has methods to work with TriggerAuthenticationGrant resources.
+type TriggerAuthenticationGrantInterface interface {
+	Create(ctx context.Context, triggerAuthenticationGrant *v1alpha1.TriggerAuthenticationGrant, opts v1.CreateOptions) (*v1alpha1.TriggerAuthenticationGrant, error)
+	Update(ctx context.Context, triggerAuthenticationGrant *v1alpha1.TriggerAuthenticationGrant, opts v1.UpdateOptions) (*v1alpha1.TriggerAuthenticationGrant, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.TriggerAuthenticationGrant, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.TriggerAuthenticationGrantList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TriggerAuthenticationGrant, err error)
+	TriggerAuthenticationGrantExpansion
+}
+
+// triggerAuthenticationGrants implements TriggerAuthenticationGrantInterface
+type triggerAuthenticationGrants struct {
+	client rest.Interface
+	ns     string
+}
+
+// newTriggerAuthenticationGrants returns a TriggerAuthenticationGrants
+func newTriggerAuthenticationGrants(c *KedaV1alpha1Client, namespace string) *triggerAuthenticationGrants {
+	return &triggerAuthenticationGrants{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the triggerAuthenticationGrant, and returns the corresponding triggerAuthenticationGrant object, and an error if there is any.
+func (c *triggerAuthenticationGrants) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	result = &v1alpha1.TriggerAuthenticationGrant{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of TriggerAuthenticationGrants that match those selectors.
+func (c *triggerAuthenticationGrants) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TriggerAuthenticationGrantList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.TriggerAuthenticationGrantList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested triggerAuthenticationGrants.
+func (c *triggerAuthenticationGrants) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a triggerAuthenticationGrant and creates it.  Returns the server's representation of the triggerAuthenticationGrant, and an error, if there is any.
+func (c *triggerAuthenticationGrants) Create(ctx context.Context, triggerAuthenticationGrant *v1alpha1.TriggerAuthenticationGrant, opts v1.CreateOptions) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	result = &v1alpha1.TriggerAuthenticationGrant{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(triggerAuthenticationGrant).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a triggerAuthenticationGrant and updates it. Returns the server's representation of the triggerAuthenticationGrant, and an error, if there is any.
+func (c *triggerAuthenticationGrants) Update(ctx context.Context, triggerAuthenticationGrant *v1alpha1.TriggerAuthenticationGrant, opts v1.UpdateOptions) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	result = &v1alpha1.TriggerAuthenticationGrant{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		Name(triggerAuthenticationGrant.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(triggerAuthenticationGrant).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the triggerAuthenticationGrant and deletes it. Returns an error if one occurs.
+func (c *triggerAuthenticationGrants) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *triggerAuthenticationGrants) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched triggerAuthenticationGrant.
+func (c *triggerAuthenticationGrants) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TriggerAuthenticationGrant, err error) {
+	result = &v1alpha1.TriggerAuthenticationGrant{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("triggerauthenticationgrants").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
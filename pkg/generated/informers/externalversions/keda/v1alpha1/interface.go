@@ -32,6 +32,8 @@ type Interface interface {
 	ScaledObjects() ScaledObjectInformer
 	// TriggerAuthentications returns a TriggerAuthenticationInformer.
 	TriggerAuthentications() TriggerAuthenticationInformer
+	// TriggerAuthenticationGrants returns a TriggerAuthenticationGrantInformer.
+	TriggerAuthenticationGrants() TriggerAuthenticationGrantInformer
 }
 
 type version struct {
@@ -64,3 +66,8 @@ func (v *version) ScaledObjects() ScaledObjectInformer {
 func (v *version) TriggerAuthentications() TriggerAuthenticationInformer {
 	return &triggerAuthenticationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TriggerAuthenticationGrants returns a TriggerAuthenticationGrantInformer.
+func (v *version) TriggerAuthenticationGrants() TriggerAuthenticationGrantInformer {
+	return &triggerAuthenticationGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
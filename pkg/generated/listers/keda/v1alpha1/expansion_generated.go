@@ -45,3 +45,11 @@ type TriggerAuthenticationListerExpansion interface{}
 // TriggerAuthenticationNamespaceListerExpansion allows custom methods to be added to
 // TriggerAuthenticationNamespaceLister.
 type TriggerAuthenticationNamespaceListerExpansion interface{}
+
+// TriggerAuthenticationGrantListerExpansion allows custom methods to be added to
+// TriggerAuthenticationGrantLister.
+type TriggerAuthenticationGrantListerExpansion interface{}
+
+// TriggerAuthenticationGrantNamespaceListerExpansion allows custom methods to be added to
+// TriggerAuthenticationGrantNamespaceLister.
+type TriggerAuthenticationGrantNamespaceListerExpansion interface{}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TriggerAuthenticationGrantLister helps list TriggerAuthenticationGrants.
+// All objects returned here must be treated as read-only.
+type TriggerAuthenticationGrantLister interface {
+	// List lists all TriggerAuthenticationGrants in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.TriggerAuthenticationGrant, err error)
+	// TriggerAuthenticationGrants returns an object that can list and get TriggerAuthenticationGrants.
+	TriggerAuthenticationGrants(namespace string) TriggerAuthenticationGrantNamespaceLister
+	TriggerAuthenticationGrantListerExpansion
+}
+
+// triggerAuthenticationGrantLister implements the TriggerAuthenticationGrantLister interface.
+type triggerAuthenticationGrantLister struct {
+	indexer cache.Indexer
+}
+
+// NewTriggerAuthenticationGrantLister returns a new TriggerAuthenticationGrantLister.
+func NewTriggerAuthenticationGrantLister(indexer cache.Indexer) TriggerAuthenticationGrantLister {
+	return &triggerAuthenticationGrantLister{indexer: indexer}
+}
+
+// List lists all TriggerAuthenticationGrants in the indexer.
+func (s *triggerAuthenticationGrantLister) List(selector labels.Selector) (ret []*v1alpha1.TriggerAuthenticationGrant, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.TriggerAuthenticationGrant))
+	})
+	return ret, err
+}
+
+// TriggerAuthenticationGrants returns an object that can list and get TriggerAuthenticationGrants.
+func (s *triggerAuthenticationGrantLister) TriggerAuthenticationGrants(namespace string) TriggerAuthenticationGrantNamespaceLister {
+	return triggerAuthenticationGrantNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// TriggerAuthenticationGrantNamespaceLister helps list and get TriggerAuthenticationGrants.
+// All objects returned here must be treated as read-only.
+type TriggerAuthenticationGrantNamespaceLister interface {
+	// List lists all TriggerAuthenticationGrants in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.TriggerAuthenticationGrant, err error)
+	// Get retrieves the TriggerAuthenticationGrant from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.TriggerAuthenticationGrant, error)
+	TriggerAuthenticationGrantNamespaceListerExpansion
+}
+
+// triggerAuthenticationGrantNamespaceLister implements the TriggerAuthenticationGrantNamespaceLister
+// interface.
+type triggerAuthenticationGrantNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all TriggerAuthenticationGrants in the indexer for a given namespace.
+func (s triggerAuthenticationGrantNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.TriggerAuthenticationGrant, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.TriggerAuthenticationGrant))
+	})
+	return ret, err
+}
+
+// Get retrieves the TriggerAuthenticationGrant from the indexer for a given namespace and name.
+func (s triggerAuthenticationGrantNamespaceLister) Get(name string) (*v1alpha1.TriggerAuthenticationGrant, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("triggerauthenticationgrant"), name)
+	}
+	return obj.(*v1alpha1.TriggerAuthenticationGrant), nil
+}
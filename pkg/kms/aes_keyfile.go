@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// AESKeyfileProviderName is the name of the provider wrapping keys with AES keys read from a local file
+const AESKeyfileProviderName = "aes-keyfile"
+
+// AESKeyfileProvider wraps data encryption keys with AES-256 keys read from a local file.
+// Each line of the file is "<keyID>:<base64 encoded 32 bytes key>", the first key is used to wrap new keys
+// and the others are kept to unwrap keys wrapped before a rotation. Empty lines and lines starting with # are ignored.
+type AESKeyfileProvider struct {
+	primaryKeyID string
+	keys         map[string][]byte
+}
+
+// NewAESKeyfileProvider reads the keys of the provider from the file
+func NewAESKeyfileProvider(path string) (*AESKeyfileProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading kms keyfile: %w", err)
+	}
+	return parseAESKeyfile(data)
+}
+
+func parseAESKeyfile(data []byte) (*AESKeyfileProvider, error) {
+	provider := &AESKeyfileProvider{keys: map[string][]byte{}}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		keyID, encodedKey, found := strings.Cut(text, ":")
+		if !found || keyID == "" {
+			return nil, fmt.Errorf("kms keyfile line %d should have <keyID>:<base64 key> format", line)
+		}
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("kms keyfile key %s isn't base64 encoded: %w", keyID, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("kms keyfile key %s should be %d bytes long, got %d", keyID, dataKeySize, len(key))
+		}
+		if _, ok := provider.keys[keyID]; ok {
+			return nil, fmt.Errorf("kms keyfile key %s is defined more than once", keyID)
+		}
+		if provider.primaryKeyID == "" {
+			provider.primaryKeyID = keyID
+		}
+		provider.keys[keyID] = key
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading kms keyfile: %w", err)
+	}
+	if provider.primaryKeyID == "" {
+		return nil, fmt.Errorf("kms keyfile doesn't contain any key")
+	}
+	return provider, nil
+}
+
+// Name implements Provider
+func (p *AESKeyfileProvider) Name() string {
+	return AESKeyfileProviderName
+}
+
+// WrapKey implements Provider
+func (p *AESKeyfileProvider) WrapKey(_ context.Context, keyID string, dataKey []byte) (string, []byte, error) {
+	if keyID == "" {
+		keyID = p.primaryKeyID
+	}
+	key, ok := p.keys[keyID]
+	if !ok {
+		return "", nil, fmt.Errorf("key %s isn't defined in kms keyfile", keyID)
+	}
+	wrappedKey, err := seal(key, dataKey, []byte(keyID))
+	if err != nil {
+		return "", nil, err
+	}
+	return keyID, wrappedKey, nil
+}
+
+// UnwrapKey implements Provider
+func (p *AESKeyfileProvider) UnwrapKey(_ context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %s isn't defined in kms keyfile", keyID)
+	}
+	return open(key, wrappedKey, []byte(keyID))
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kms implements envelope encryption of values set inline in KEDA resources.
+// A value is encrypted with a random data encryption key (DEK) using AES-GCM and the DEK
+// is wrapped by a KMS Provider, so only the wrapped DEK ever leaves the process.
+// The namespace and the field path holding the value are bound to the ciphertext as AES-GCM additional
+// authenticated data, so an envelope copied to another namespace or field fails to decrypt.
+package kms
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// EnvelopePrefix identifies values encrypted by this package
+const EnvelopePrefix = "kedakms:v1:"
+
+const dataKeySize = 32
+
+// HashiCorpVaultTokenFieldPath is the field path bound to an encrypted TriggerAuthentication Vault token
+const HashiCorpVaultTokenFieldPath = "hashiCorpVault.credential.token"
+
+// EnvFieldPath returns the field path bound to an encrypted literal value of the container environment variable
+func EnvFieldPath(name string) string {
+	return "env." + name
+}
+
+// Binding identifies where an encrypted value is set
+type Binding struct {
+	// Namespace of the resource holding the value, empty for cluster scoped resources
+	Namespace string
+	// FieldPath of the value in the resource, e.g. hashiCorpVault.credential.token
+	FieldPath string
+}
+
+// additionalData returns the AES-GCM additional authenticated data of the binding,
+// namespaces can't contain NUL characters so the namespace and the field path can't be confused
+func (b Binding) additionalData() []byte {
+	return []byte(EnvelopePrefix + b.Namespace + "\x00" + b.FieldPath)
+}
+
+// Provider wraps and unwraps data encryption keys with a key encryption key managed by a KMS
+type Provider interface {
+	// Name identifies the provider in the envelopes it wraps keys for
+	Name() string
+	// WrapKey encrypts the data encryption key with the key identified by keyID, or with the provider's
+	// primary key when keyID is empty, and returns the ID of the key that was used
+	WrapKey(ctx context.Context, keyID string, dataKey []byte) (string, []byte, error)
+	// UnwrapKey decrypts the data encryption key wrapped by the key identified by keyID
+	UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// envelope is the serialized form of an encrypted value
+type envelope struct {
+	Provider   string `json:"provider"`
+	KeyID      string `json:"keyId"`
+	WrappedKey []byte `json:"wrappedKey"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+var (
+	providers     = map[string]Provider{}
+	providersLock sync.RWMutex
+)
+
+// RegisterProvider makes the provider available to decrypt envelopes, replacing any provider with the same name
+func RegisterProvider(provider Provider) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	providers[provider.Name()] = provider
+}
+
+// GetProvider returns the registered provider with the name
+func GetProvider(name string) (Provider, bool) {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	provider, ok := providers[name]
+	return provider, ok
+}
+
+// IsEnvelope returns whether the value is encrypted by this package
+func IsEnvelope(value string) bool {
+	return strings.HasPrefix(value, EnvelopePrefix)
+}
+
+// Encrypt encrypts the plaintext bound to the namespace and field path with a new data encryption key wrapped by the provider
+func Encrypt(ctx context.Context, provider Provider, keyID string, binding Binding, plaintext []byte) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("error generating data encryption key: %w", err)
+	}
+	ciphertext, err := seal(dataKey, plaintext, binding.additionalData())
+	if err != nil {
+		return "", err
+	}
+	usedKeyID, wrappedKey, err := provider.WrapKey(ctx, keyID, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("error wrapping data encryption key with %s provider: %w", provider.Name(), err)
+	}
+
+	data, err := json.Marshal(envelope{Provider: provider.Name(), KeyID: usedKeyID, WrappedKey: wrappedKey, Ciphertext: ciphertext})
+	if err != nil {
+		return "", err
+	}
+	return EnvelopePrefix + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decrypt decrypts an envelope using the registered provider it was encrypted with,
+// the binding has to be the one the value was encrypted with
+func Decrypt(ctx context.Context, binding Binding, value string) (string, error) {
+	if !IsEnvelope(value) {
+		return "", fmt.Errorf("value isn't encrypted, expected %s prefix", EnvelopePrefix)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, EnvelopePrefix))
+	if err != nil {
+		return "", fmt.Errorf("error decoding encrypted value: %w", err)
+	}
+	var e envelope
+	if err := json.Unmarshal(data, &e); err != nil {
+		return "", fmt.Errorf("error decoding encrypted value: %w", err)
+	}
+
+	provider, ok := GetProvider(e.Provider)
+	if !ok {
+		return "", fmt.Errorf("kms provider %q used to encrypt the value isn't configured", e.Provider)
+	}
+	dataKey, err := provider.UnwrapKey(ctx, e.KeyID, e.WrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("error unwrapping data encryption key with %s provider: %w", e.Provider, err)
+	}
+	plaintext, err := open(dataKey, e.Ciphertext, binding.additionalData())
+	if err != nil {
+		return "", fmt.Errorf("%w, the value may be encrypted for another namespace or field than %q %s", err, binding.Namespace, binding.FieldPath)
+	}
+	return string(plaintext), nil
+}
+
+// DecryptValue decrypts the value when it's an envelope, other values are returned unchanged
+func DecryptValue(ctx context.Context, binding Binding, value string) (string, error) {
+	if !IsEnvelope(value) {
+		return value, nil
+	}
+	return Decrypt(ctx, binding, value)
+}
+
+// seal encrypts the plaintext with AES-GCM authenticating the additional data and returns the nonce followed by the ciphertext
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts data produced by seal with the same additional data
+func open(key, data, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting data: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
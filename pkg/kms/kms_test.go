@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testPrimaryKey = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("p", 32)))
+	testRotatedKey = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("r", 32)))
+)
+
+type parseAESKeyfileTestData struct {
+	name         string
+	keyfile      string
+	primaryKeyID string
+	isError      bool
+}
+
+var testParseAESKeyfileData = []parseAESKeyfileTestData{
+	{"single key", "primary:" + testPrimaryKey, "primary", false},
+	{"rotated keys with comments", "# current key\nprimary:" + testPrimaryKey + "\n\nrotated:" + testRotatedKey + "\n", "primary", false},
+	{"empty file", "# no keys\n", "", true},
+	{"missing key id", ":" + testPrimaryKey, "", true},
+	{"invalid base64", "primary:not-base64!", "", true},
+	{"short key", "primary:" + base64.StdEncoding.EncodeToString([]byte("short")), "", true},
+	{"duplicated key id", "primary:" + testPrimaryKey + "\nprimary:" + testRotatedKey, "", true},
+}
+
+func TestParseAESKeyfile(t *testing.T) {
+	for _, testData := range testParseAESKeyfileData {
+		t.Run(testData.name, func(t *testing.T) {
+			provider, err := parseAESKeyfile([]byte(testData.keyfile))
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.primaryKeyID, provider.primaryKeyID)
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	provider, err := parseAESKeyfile([]byte("primary:" + testPrimaryKey + "\nrotated:" + testRotatedKey))
+	assert.NoError(t, err)
+	RegisterProvider(provider)
+	ctx := context.Background()
+	binding := Binding{Namespace: "default", FieldPath: HashiCorpVaultTokenFieldPath}
+
+	encrypted, err := Encrypt(ctx, provider, "", binding, []byte("my-token"))
+	assert.NoError(t, err)
+	assert.True(t, IsEnvelope(encrypted))
+	assert.NotContains(t, encrypted, "my-token")
+
+	decrypted, err := Decrypt(ctx, binding, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-token", decrypted)
+
+	// values bound to another namespace or field are rejected
+	_, err = Decrypt(ctx, Binding{Namespace: "other", FieldPath: HashiCorpVaultTokenFieldPath}, encrypted)
+	assert.Error(t, err)
+	_, err = Decrypt(ctx, Binding{Namespace: "default", FieldPath: EnvFieldPath("TOKEN")}, encrypted)
+	assert.Error(t, err)
+	_, err = Decrypt(ctx, Binding{FieldPath: HashiCorpVaultTokenFieldPath}, encrypted)
+	assert.Error(t, err)
+
+	// values wrapped with a rotated key can still be decrypted
+	encrypted, err = Encrypt(ctx, provider, "rotated", binding, []byte("old-token"))
+	assert.NoError(t, err)
+	decrypted, err = DecryptValue(ctx, binding, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "old-token", decrypted)
+
+	// plaintext values are returned unchanged
+	decrypted, err = DecryptValue(ctx, binding, "plain-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain-token", decrypted)
+
+	// tampered ciphertext is rejected
+	_, err = Decrypt(ctx, binding, encrypted[:len(encrypted)-4]+"AAAA")
+	assert.Error(t, err)
+
+	// values can't be decrypted with another key
+	otherProvider, err := parseAESKeyfile([]byte("rotated:" + testPrimaryKey))
+	assert.NoError(t, err)
+	RegisterProvider(otherProvider)
+	_, err = Decrypt(ctx, binding, encrypted)
+	assert.Error(t, err)
+}
+
+func TestDecryptUnknownProvider(t *testing.T) {
+	provider := &fakeProvider{}
+	binding := Binding{Namespace: "default", FieldPath: HashiCorpVaultTokenFieldPath}
+	encrypted, err := Encrypt(context.Background(), provider, "", binding, []byte("my-token"))
+	assert.NoError(t, err)
+
+	_, err = Decrypt(context.Background(), binding, encrypted)
+	assert.ErrorContains(t, err, "isn't configured")
+
+	RegisterProvider(provider)
+	decrypted, err := Decrypt(context.Background(), binding, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-token", decrypted)
+}
+
+// fakeProvider stores the data encryption key as is, like a cloud KMS plugin would store a reference to it
+type fakeProvider struct{}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) WrapKey(_ context.Context, _ string, dataKey []byte) (string, []byte, error) {
+	return "fake-key", dataKey, nil
+}
+
+func (p *fakeProvider) UnwrapKey(_ context.Context, _ string, wrappedKey []byte) ([]byte, error) {
+	return wrappedKey, nil
+}
@@ -70,6 +70,12 @@ type MetricsCollector interface {
 
 	// RecordCloudEventQueueStatus record the number of cloudevents that are waiting for emitting
 	RecordCloudEventQueueStatus(namespace string, value int)
+
+	// RecordSecretProviderRequest counts the number of secret resolutions per secret provider and result (success, error or cached)
+	RecordSecretProviderRequest(namespace string, provider string, result string)
+
+	// RecordSecretProviderLatency create a measurement of the latency of reading secrets from a secret provider
+	RecordSecretProviderLatency(namespace string, provider string, value float64)
 }
 
 func NewMetricsCollectors(enablePrometheusMetrics bool, enableOpenTelemetryMetrics bool) {
@@ -184,3 +190,17 @@ func RecordCloudEventQueueStatus(namespace string, value int) {
 		element.RecordCloudEventQueueStatus(namespace, value)
 	}
 }
+
+// RecordSecretProviderRequest counts the number of secret resolutions per secret provider and result (success, error or cached)
+func RecordSecretProviderRequest(namespace string, provider string, result string) {
+	for _, element := range collectors {
+		element.RecordSecretProviderRequest(namespace, provider, result)
+	}
+}
+
+// RecordSecretProviderLatency create a measurement of the latency of reading secrets from a secret provider
+func RecordSecretProviderLatency(namespace string, provider string, value float64) {
+	for _, element := range collectors {
+		element.RecordSecretProviderLatency(namespace, provider, value)
+	}
+}
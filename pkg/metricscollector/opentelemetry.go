@@ -39,6 +39,9 @@ var (
 	otCloudEventQueueStatusVal OtelMetricFloat64Val
 
 	otelScalerActiveVal OtelMetricFloat64Val
+
+	otSecretProviderRequestsCounter api.Int64Counter
+	otSecretProviderLatencyVal      OtelMetricFloat64Val
 )
 
 type OtelMetrics struct {
@@ -163,6 +166,20 @@ func initMeters() {
 	if err != nil {
 		otLog.Error(err, msg)
 	}
+
+	otSecretProviderRequestsCounter, err = meter.Int64Counter("keda.secret.provider.requests", api.WithDescription("Total number of secret resolutions per secret provider. 'result': success, error or cached"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	_, err = meter.Float64ObservableGauge(
+		"keda.secret.provider.latency",
+		api.WithDescription("Latency of reading secrets from a secret provider"),
+		api.WithFloat64Callback(SecretProviderLatencyCallback),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
 }
 
 func BuildInfoCallback(_ context.Context, obsrv api.Int64Observer) error {
@@ -407,3 +424,32 @@ func (o *OtelMetrics) RecordCloudEventQueueStatus(namespace string, value int) {
 	otCloudEventQueueStatusVal.val = float64(value)
 	otCloudEventQueueStatusVal.measurementOption = opt
 }
+
+// RecordSecretProviderRequest counts the number of secret resolutions per secret provider and result (success, error or cached)
+func (o *OtelMetrics) RecordSecretProviderRequest(namespace string, provider string, result string) {
+	opt := api.WithAttributes(
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("provider").String(provider),
+		attribute.Key("result").String(result),
+	)
+	otSecretProviderRequestsCounter.Add(context.Background(), 1, opt)
+}
+
+func SecretProviderLatencyCallback(_ context.Context, obsrv api.Float64Observer) error {
+	if otSecretProviderLatencyVal.measurementOption != nil {
+		obsrv.Observe(otSecretProviderLatencyVal.val, otSecretProviderLatencyVal.measurementOption)
+	}
+	otSecretProviderLatencyVal = OtelMetricFloat64Val{}
+	return nil
+}
+
+// RecordSecretProviderLatency create a measurement of the latency of reading secrets from a secret provider
+func (o *OtelMetrics) RecordSecretProviderLatency(namespace string, provider string, value float64) {
+	opt := api.WithAttributes(
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("provider").String(provider),
+	)
+
+	otSecretProviderLatencyVal.val = value
+	otSecretProviderLatencyVal.measurementOption = opt
+}
@@ -160,6 +160,26 @@ var (
 		},
 		[]string{"namespace"},
 	)
+
+	secretProviderRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "secret_provider",
+			Name:      "requests_total",
+			Help:      "Total number of secret resolutions per secret provider. 'result': success, error or cached",
+		},
+		[]string{"namespace", "provider", "result"},
+	)
+
+	secretProviderLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "secret_provider",
+			Name:      "latency_seconds",
+			Help:      "Latency of reading secrets from a secret provider",
+		},
+		[]string{"namespace", "provider"},
+	)
 )
 
 type PromMetrics struct {
@@ -183,6 +203,9 @@ func NewPromMetrics() *PromMetrics {
 	metrics.Registry.MustRegister(cloudeventEmitted)
 	metrics.Registry.MustRegister(cloudeventQueueStatus)
 
+	metrics.Registry.MustRegister(secretProviderRequests)
+	metrics.Registry.MustRegister(secretProviderLatency)
+
 	RecordBuildInfo()
 	return &PromMetrics{}
 }
@@ -329,3 +352,13 @@ func (p *PromMetrics) RecordCloudEventEmittedError(namespace string, cloudevents
 func (p *PromMetrics) RecordCloudEventQueueStatus(namespace string, value int) {
 	cloudeventQueueStatus.With(prometheus.Labels{"namespace": namespace}).Set(float64(value))
 }
+
+// RecordSecretProviderRequest counts the number of secret resolutions per secret provider and result (success, error or cached)
+func (p *PromMetrics) RecordSecretProviderRequest(namespace string, provider string, result string) {
+	secretProviderRequests.With(prometheus.Labels{"namespace": namespace, "provider": provider, "result": result}).Inc()
+}
+
+// RecordSecretProviderLatency create a measurement of the latency of reading secrets from a secret provider
+func (p *PromMetrics) RecordSecretProviderLatency(namespace string, provider string, value float64) {
+	secretProviderLatency.With(prometheus.Labels{"namespace": namespace, "provider": provider}).Set(value)
+}
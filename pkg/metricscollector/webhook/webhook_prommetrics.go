@@ -44,11 +44,31 @@ var (
 		},
 		[]string{"namespace", "action", "reason"},
 	)
+	scaledJobValidatingTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "webhook",
+			Name:      "scaled_job_validation_total",
+			Help:      "Total number of scaled job validations",
+		},
+		[]string{"namespace", "action"},
+	)
+	scaledJobValidatingErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "webhook",
+			Name:      "scaled_job_validation_errors",
+			Help:      "Total number of scaled job validating errors",
+		},
+		[]string{"namespace", "action", "reason"},
+	)
 )
 
 func init() {
 	metrics.Registry.MustRegister(scaledObjectValidatingTotal)
 	metrics.Registry.MustRegister(scaledObjectValidatingErrors)
+	metrics.Registry.MustRegister(scaledJobValidatingTotal)
+	metrics.Registry.MustRegister(scaledJobValidatingErrors)
 }
 
 // RecordScaledObjectValidatingTotal counts the number of ScaledObject validations
@@ -62,3 +82,15 @@ func RecordScaledObjectValidatingErrors(namespace, action, reason string) {
 	labels := prometheus.Labels{"namespace": namespace, "action": action, "reason": reason}
 	scaledObjectValidatingErrors.With(labels).Inc()
 }
+
+// RecordScaledJobValidatingTotal counts the number of ScaledJob validations
+func RecordScaledJobValidatingTotal(namespace, action string) {
+	labels := prometheus.Labels{"namespace": namespace, "action": action}
+	scaledJobValidatingTotal.With(labels).Inc()
+}
+
+// RecordScaledJobValidatingErrors counts the number of ScaledJob validating errors
+func RecordScaledJobValidatingErrors(namespace, action, reason string) {
+	labels := prometheus.Labels{"namespace": namespace, "action": action, "reason": reason}
+	scaledJobValidatingErrors.With(labels).Inc()
+}
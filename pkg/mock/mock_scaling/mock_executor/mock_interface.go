@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	executor "github.com/kedacore/keda/v2/pkg/scaling/executor"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +41,18 @@ func (m *MockScaleExecutor) EXPECT() *MockScaleExecutorMockRecorder {
 	return m.recorder
 }
 
+// RequestDirectScale mocks base method.
+func (m *MockScaleExecutor) RequestDirectScale(ctx context.Context, scaledObject *v1alpha1.ScaledObject, isActive, isError bool, metrics []executor.DirectScalingMetric) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestDirectScale", ctx, scaledObject, isActive, isError, metrics)
+}
+
+// RequestDirectScale indicates an expected call of RequestDirectScale.
+func (mr *MockScaleExecutorMockRecorder) RequestDirectScale(ctx, scaledObject, isActive, isError, metrics any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDirectScale", reflect.TypeOf((*MockScaleExecutor)(nil).RequestDirectScale), ctx, scaledObject, isActive, isError, metrics)
+}
+
 // RequestJobScale mocks base method.
 func (m *MockScaleExecutor) RequestJobScale(ctx context.Context, scaledJob *v1alpha1.ScaledJob, isActive bool, scaleTo, maxScale int64) {
 	m.ctrl.T.Helper()
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	cache "github.com/kedacore/keda/v2/pkg/scaling/cache"
+	resolver "github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	gomock "go.uber.org/mock/gomock"
 	external_metrics "k8s.io/metrics/pkg/apis/external_metrics"
 )
@@ -55,6 +56,18 @@ func (mr *MockScaleHandlerMockRecorder) ClearScalersCache(ctx, scalableObject an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearScalersCache", reflect.TypeOf((*MockScaleHandler)(nil).ClearScalersCache), ctx, scalableObject)
 }
 
+// ClearScalersCachesForAuthentications mocks base method.
+func (m *MockScaleHandler) ClearScalersCachesForAuthentications(ctx context.Context, authenticationKeys []resolver.AuthenticationKey) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearScalersCachesForAuthentications", ctx, authenticationKeys)
+}
+
+// ClearScalersCachesForAuthentications indicates an expected call of ClearScalersCachesForAuthentications.
+func (mr *MockScaleHandlerMockRecorder) ClearScalersCachesForAuthentications(ctx, authenticationKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearScalersCachesForAuthentications", reflect.TypeOf((*MockScaleHandler)(nil).ClearScalersCachesForAuthentications), ctx, authenticationKeys)
+}
+
 // DeleteScalableObject mocks base method.
 func (m *MockScaleHandler) DeleteScalableObject(ctx context.Context, scalableObject any) error {
 	m.ctrl.T.Helper()
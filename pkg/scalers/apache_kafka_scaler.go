@@ -23,8 +23,12 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/segmentio/kafka-go"
@@ -52,12 +56,15 @@ type apacheKafkaMetadata struct {
 	bootstrapServers       []string
 	group                  string
 	topic                  []string
+	topicPattern           *regexp.Regexp
 	partitionLimitation    []int32
 	lagThreshold           int64
 	activationLagThreshold int64
 	offsetResetPolicy      offsetResetPolicy
 	allowIdleConsumers     bool
 	excludePersistentLag   bool
+	lagMode                kafkaLagMode
+	partitionLagCap        int64
 
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
@@ -253,16 +260,26 @@ func parseApacheKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Lo
 		meta.topic = strings.Split(config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]], ",")
 	case config.TriggerMetadata["topic"] != "":
 		meta.topic = strings.Split(config.TriggerMetadata["topic"], ",")
+	case config.TriggerMetadata["topicPattern"] != "":
+		meta.topic = []string{}
+		pattern, err := parseKafkaTopicPattern(config.TriggerMetadata["topicPattern"])
+		if err != nil {
+			return meta, err
+		}
+		meta.topicPattern = pattern
 	default:
 		meta.topic = []string{}
 		logger.V(1).Info(fmt.Sprintf("consumer group %q has no topics specified, "+
 			"will use all topics subscribed by the consumer group for scaling", meta.group))
 	}
+	if len(meta.topic) > 0 && config.TriggerMetadata["topicPattern"] != "" {
+		return meta, errors.New("topic and topicPattern cannot be set simultaneously")
+	}
 
 	meta.partitionLimitation = nil
 	partitionLimitationMetadata := strings.TrimSpace(config.TriggerMetadata["partitionLimitation"])
 	if partitionLimitationMetadata != "" {
-		if meta.topic == nil || len(meta.topic) == 0 {
+		if len(meta.topic) == 0 && meta.topicPattern == nil {
 			logger.V(1).Info("no specific topics set, ignoring partitionLimitation setting")
 		} else {
 			pattern := config.TriggerMetadata["partitionLimitation"]
@@ -353,11 +370,23 @@ func parseApacheKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Lo
 		if meta.allowIdleConsumers && meta.limitToPartitionsWithLag {
 			return meta, fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
 		}
-		if len(meta.topic) == 0 && meta.limitToPartitionsWithLag {
-			return meta, fmt.Errorf("topic must be specified when using limitToPartitionsWithLag")
+		if len(meta.topic) == 0 && meta.topicPattern == nil && meta.limitToPartitionsWithLag {
+			return meta, fmt.Errorf("topic or topicPattern must be specified when using limitToPartitionsWithLag")
 		}
 	}
 
+	lagMode, err := parseKafkaLagMode(config)
+	if err != nil {
+		return meta, err
+	}
+	meta.lagMode = lagMode
+
+	partitionLagCap, err := parseKafkaPartitionLagCap(config)
+	if err != nil {
+		return meta, err
+	}
+	meta.partitionLagCap = partitionLagCap
+
 	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
@@ -430,46 +459,43 @@ func (s *apacheKafkaScaler) getTopicPartitions(ctx context.Context) (map[string]
 	}
 	s.logger.V(1).Info(fmt.Sprintf("Listed topics %v", metadata.Topics))
 
-	if len(s.metadata.topic) == 0 {
-		// in case of empty topic name, we will get all topics that the consumer group is subscribed to
-		describeGrpReq := &kafka.DescribeGroupsRequest{
-			Addr: s.client.Addr,
-			GroupIDs: []string{
-				s.metadata.group,
-			},
+	var topics []string
+	switch {
+	case len(s.metadata.topic) > 0:
+		topics = s.metadata.topic
+	case s.metadata.topicPattern != nil:
+		names := make([]string, 0, len(metadata.Topics))
+		for _, topic := range metadata.Topics {
+			if !topic.Internal {
+				names = append(names, topic.Name)
+			}
 		}
-		describeGrp, err := s.client.DescribeGroups(ctx, describeGrpReq)
-		if err != nil {
-			return nil, fmt.Errorf("error describing group: %w", err)
+		topics = matchKafkaTopics(s.metadata.topicPattern, names)
+		if len(topics) == 0 {
+			return nil, fmt.Errorf("no topic matches topicPattern %s", s.metadata.topicPattern)
 		}
-		if len(describeGrp.Groups[0].Members) == 0 {
-			return nil, fmt.Errorf("no active members in group %s, group-state is %s", s.metadata.group, describeGrp.Groups[0].GroupState)
+	default:
+		// in case of empty topic name, we will get all topics that the consumer group is subscribed to
+		if err := s.checkConsumerGroupMembers(ctx); err != nil {
+			return nil, err
 		}
-		s.logger.V(4).Info(fmt.Sprintf("Described group %s with response %v", s.metadata.group, describeGrp))
-
-		result := make(map[string][]int)
+		topics = make([]string, 0, len(metadata.Topics))
 		for _, topic := range metadata.Topics {
-			partitions := make([]int, 0)
-			for _, partition := range topic.Partitions {
-				// if no partitions limitatitions are specified, all partitions are considered
-				if (len(s.metadata.partitionLimitation) == 0) ||
-					(len(s.metadata.partitionLimitation) > 0 && kedautil.Contains(s.metadata.partitionLimitation, int32(partition.ID))) {
-					partitions = append(partitions, partition.ID)
-				}
-			}
-			result[topic.Name] = partitions
+			topics = append(topics, topic.Name)
 		}
-		return result, nil
 	}
+
 	result := make(map[string][]int)
 	for _, topic := range metadata.Topics {
+		if !slices.Contains(topics, topic.Name) {
+			continue
+		}
 		partitions := make([]int, 0)
-		if kedautil.Contains(s.metadata.topic, topic.Name) {
-			for _, partition := range topic.Partitions {
-				if (len(s.metadata.partitionLimitation) == 0) ||
-					(len(s.metadata.partitionLimitation) > 0 && kedautil.Contains(s.metadata.partitionLimitation, int32(partition.ID))) {
-					partitions = append(partitions, partition.ID)
-				}
+		for _, partition := range topic.Partitions {
+			// if no partitions limitatitions are specified, all partitions are considered
+			if (len(s.metadata.partitionLimitation) == 0) ||
+				(len(s.metadata.partitionLimitation) > 0 && kedautil.Contains(s.metadata.partitionLimitation, int32(partition.ID))) {
+				partitions = append(partitions, partition.ID)
 			}
 		}
 		result[topic.Name] = partitions
@@ -477,6 +503,25 @@ func (s *apacheKafkaScaler) getTopicPartitions(ctx context.Context) (map[string]
 	return result, nil
 }
 
+// checkConsumerGroupMembers returns an error when the consumer group has no active member
+func (s *apacheKafkaScaler) checkConsumerGroupMembers(ctx context.Context) error {
+	describeGrpReq := &kafka.DescribeGroupsRequest{
+		Addr: s.client.Addr,
+		GroupIDs: []string{
+			s.metadata.group,
+		},
+	}
+	describeGrp, err := s.client.DescribeGroups(ctx, describeGrpReq)
+	if err != nil {
+		return fmt.Errorf("error describing group: %w", err)
+	}
+	if len(describeGrp.Groups[0].Members) == 0 {
+		return fmt.Errorf("no active members in group %s, group-state is %s", s.metadata.group, describeGrp.Groups[0].GroupState)
+	}
+	s.logger.V(4).Info(fmt.Sprintf("Described group %s with response %v", s.metadata.group, describeGrp))
+	return nil
+}
+
 func (s *apacheKafkaScaler) getConsumerOffsets(ctx context.Context, topicPartitions map[string][]int) (map[string]map[int]int64, error) {
 	response, err := s.client.OffsetFetch(
 		ctx,
@@ -655,6 +700,14 @@ func (s *apacheKafkaScaler) getTotalLag(ctx context.Context) (int64, int64, erro
 		return 0, 0, err
 	}
 
+	var timeLags map[string]map[int]int64
+	if s.metadata.lagMode == kafkaLagModeTime {
+		timeLags, err = s.getTimeLags(ctx, consumerOffsets, producerOffsets)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
 	totalLag := int64(0)
 	totalLagWithPersistent := int64(0)
 	totalTopicPartitions := int64(0)
@@ -666,6 +719,14 @@ func (s *apacheKafkaScaler) getTotalLag(ctx context.Context) (int64, int64, erro
 			if err != nil {
 				return 0, 0, err
 			}
+			if s.metadata.lagMode == kafkaLagModeTime {
+				timeLag, found := timeLags[topic][partition]
+				lag, lagWithPersistent = applyKafkaTimeLag(lag, lagWithPersistent, timeLag, found)
+			}
+			if s.metadata.partitionLagCap > 0 {
+				lag = min(lag, s.metadata.partitionLagCap)
+				lagWithPersistent = min(lagWithPersistent, s.metadata.partitionLagCap)
+			}
 			totalLag += lag
 			totalLagWithPersistent += lagWithPersistent
 
@@ -724,3 +785,84 @@ func (s *apacheKafkaScaler) getProducerOffsets(ctx context.Context, topicPartiti
 
 	return producerOffsets, nil
 }
+
+// getTimeLags returns the time lag in seconds of the partitions with unconsumed messages. The next message to consume is at
+// the committed offset, or at the first offset when nothing is committed yet and offsetResetPolicy is earliest.
+func (s *apacheKafkaScaler) getTimeLags(ctx context.Context, consumerOffsets map[string]map[int]int64, producerOffsets map[string]map[int]int64) (map[string]map[int]int64, error) {
+	timeLags := make(map[string]map[int]int64)
+	for topic, partitionsOffsets := range producerOffsets {
+		for partitionID, producerOffset := range partitionsOffsets {
+			consumerOffset, found := consumerOffsets[topic][partitionID]
+			if !found {
+				continue
+			}
+			if consumerOffset == invalidOffset {
+				if s.metadata.offsetResetPolicy != earliest {
+					continue
+				}
+				consumerOffset = kafka.FirstOffset
+			} else if consumerOffset >= producerOffset {
+				continue
+			}
+
+			nextTimestamp, err := s.getMessageTimestamp(ctx, topic, partitionID, consumerOffset)
+			if err != nil {
+				return nil, err
+			}
+			if nextTimestamp.IsZero() {
+				// the partition is empty or its messages can't be fetched, it keeps its offset lag
+				continue
+			}
+			lastTimestamp, err := s.getMessageTimestamp(ctx, topic, partitionID, producerOffset-1)
+			if err != nil {
+				return nil, err
+			}
+			if lastTimestamp.IsZero() {
+				continue
+			}
+			if timeLags[topic] == nil {
+				timeLags[topic] = make(map[int]int64)
+			}
+			timeLags[topic][partitionID] = kafkaTimeLag(nextTimestamp, lastTimestamp)
+		}
+	}
+	return timeLags, nil
+}
+
+// getMessageTimestamp returns the timestamp of the first message at or after the offset, or a zero time when there is none
+// or the partition returned an error, e.g. because the message was deleted by the retention. Fetches return the whole batch
+// containing the offset, so the messages before the offset are skipped.
+func (s *apacheKafkaScaler) getMessageTimestamp(ctx context.Context, topic string, partitionID int, offset int64) (time.Time, error) {
+	response, err := s.client.Fetch(ctx, &kafka.FetchRequest{
+		Topic:     topic,
+		Partition: partitionID,
+		Offset:    offset,
+		MinBytes:  1,
+		MaxBytes:  kafkaTimeLagFetchMaxBytes,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error fetching message at offset %d for topic %s and partition %d: %w", offset, topic, partitionID, err)
+	}
+	if response.Error != nil {
+		s.logger.V(1).Info(fmt.Sprintf("error fetching message at offset %d for topic %s and partition %d, using the offset lag: %s", offset, topic, partitionID, response.Error))
+		return time.Time{}, nil
+	}
+	if response.Topic != topic || response.Partition != partitionID {
+		return time.Time{}, fmt.Errorf("error fetching message at offset %d for topic %s and partition %d: got topic %s and partition %d", offset, topic, partitionID, response.Topic, response.Partition)
+	}
+	if response.Records == nil {
+		return time.Time{}, nil
+	}
+	for {
+		record, err := response.Records.ReadRecord()
+		if errors.Is(err, io.EOF) {
+			return time.Time{}, nil
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error reading message at offset %d for topic %s and partition %d: %w", offset, topic, partitionID, err)
+		}
+		if offset < 0 || record.Offset >= offset {
+			return record.Time, nil
+		}
+	}
+}
@@ -382,3 +382,54 @@ func parseExpectedLagThreshold(metadata map[string]string) (int64, error) {
 	}
 	return strconv.ParseInt(val, 10, 64)
 }
+
+func TestApacheKafkaGetTotalLagWithMockBroker(t *testing.T) {
+	broker := newKafkaMockBroker(t)
+	defer broker.Close()
+
+	testData := []struct {
+		name     string
+		metadata map[string]string
+		lag      int64
+		isError  bool
+	}{
+		{"topic", map[string]string{"topic": "orders"}, 7, false},
+		{"topicPattern skipping internal topics", map[string]string{"topicPattern": ".*"}, 7 + 100 + 1 + 3, false},
+		{"topicPattern", map[string]string{"topicPattern": "orders|pay.*"}, 107, false},
+		{"topicPattern without match", map[string]string{"topicPattern": "returns"}, 0, true},
+		{"consumer group without members", map[string]string{}, 0, true},
+		{"partitionLagCap", map[string]string{"topicPattern": "orders|payments", "partitionLagCap": "4"}, 4 + 2 + 4, false},
+		{"time lagMode", map[string]string{"topic": "orders", "lagMode": "time"}, 30 + 20, false},
+		{"time lagMode with topicPattern", map[string]string{"topicPattern": ".*", "lagMode": "time"}, 30 + 20 + 3 + 1 + 3, false},
+		{"time lagMode with partitionLagCap", map[string]string{"topicPattern": "orders|payments", "lagMode": "time", "partitionLagCap": "25"}, 25 + 20 + 3, false},
+		{"time lagMode with earliest offsetResetPolicy", map[string]string{"topic": "invoices", "lagMode": "time", "offsetResetPolicy": "earliest"}, 5, false},
+		{"time lagMode falling back to the offset lag", map[string]string{"topic": "refunds", "lagMode": "time"}, 3, false},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.metadata["bootstrapServers"] = broker.Addr()
+			tt.metadata["consumerGroup"] = "my-group"
+			tt.metadata["allowIdleConsumers"] = "true"
+			scaler, err := NewApacheKafkaScaler(context.Background(), &scalersconfig.ScalerConfig{TriggerMetadata: tt.metadata})
+			if err != nil {
+				t.Fatal("Could not create scaler:", err)
+			}
+			defer scaler.Close(context.Background())
+
+			lag, _, err := scaler.(*apacheKafkaScaler).getTotalLag(context.Background())
+			if tt.isError {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if lag != tt.lag {
+				t.Errorf("Expected lag %d but got %d", tt.lag, lag)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 
 const (
 	AuthModesKey = "authModes"
+	// OIDCAccessTokenKey is the auth param containing the access token exchanged by the oidc pod identity provider
+	OIDCAccessTokenKey = "oidcAccessToken"
 )
 
 func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMeta, err error) {
@@ -45,6 +47,9 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			if out.EnableOAuth {
 				return nil, errors.New("both bearer and OAuth can not be set")
 			}
+			if out.EnableServiceAccountTokenAuth {
+				return nil, errors.New("both bearer and serviceAccountToken authentication can not be set")
+			}
 			out.BearerToken = strings.TrimSuffix(authParams["bearerToken"], "\n")
 			out.EnableBearerAuth = true
 		case BasicAuthType:
@@ -57,6 +62,9 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			if out.EnableOAuth {
 				return nil, errors.New("both bearer and OAuth can not be set")
 			}
+			if out.EnableServiceAccountTokenAuth {
+				return nil, errors.New("both basic and serviceAccountToken authentication can not be set")
+			}
 
 			out.Username = authParams["username"]
 			// password is optional. For convenience, many application implement basic auth with
@@ -93,6 +101,9 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			if out.EnableBearerAuth {
 				return nil, errors.New("both oauth and bearer authentication can not be set")
 			}
+			if out.EnableServiceAccountTokenAuth {
+				return nil, errors.New("both oauth and serviceAccountToken authentication can not be set")
+			}
 			out.EnableOAuth = true
 			out.OauthTokenURI = authParams["oauthTokenURI"]
 			out.Scopes = ParseScope(authParams["scope"])
@@ -104,6 +115,21 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 				return nil, fmt.Errorf("incorrect value for endpointParams is given: %s", authParams["endpointParams"])
 			}
 			out.EndpointParams = v
+		case ServiceAccountTokenAuthType:
+			if len(authParams["serviceAccountToken"]) == 0 {
+				return nil, errors.New("no service account token provided, serviceAccountToken has to be configured in the TriggerAuthentication")
+			}
+			if out.EnableBearerAuth {
+				return nil, errors.New("both bearer and serviceAccountToken authentication can not be set")
+			}
+			if out.EnableBasicAuth {
+				return nil, errors.New("both basic and serviceAccountToken authentication can not be set")
+			}
+			if out.EnableOAuth {
+				return nil, errors.New("both oauth and serviceAccountToken authentication can not be set")
+			}
+			out.ServiceAccountToken = authParams["serviceAccountToken"]
+			out.EnableServiceAccountTokenAuth = true
 		default:
 			return nil, fmt.Errorf("incorrect value for authMode is given: %s", t)
 		}
@@ -149,10 +175,26 @@ func ParseEndpointParams(inputStr string) (url.Values, error) {
 	return v, nil
 }
 
+// GetBearerToken returns the value of the Authorization header for bearer or serviceAccountToken authentication
 func GetBearerToken(auth *AuthMeta) string {
+	if auth.EnableServiceAccountTokenAuth {
+		return fmt.Sprintf("Bearer %s", auth.ServiceAccountToken)
+	}
 	return fmt.Sprintf("Bearer %s", auth.BearerToken)
 }
 
+// NewOIDCRoundTripper returns a http.RoundTripper adding the access token exchanged by the oidc pod identity provider to the requests
+func NewOIDCRoundTripper(authParams map[string]string, rt http.RoundTripper) (http.RoundTripper, error) {
+	token := authParams[OIDCAccessTokenKey]
+	if len(token) == 0 {
+		return nil, errors.New("no access token resolved for oidc pod identity")
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return pConfig.NewAuthorizationCredentialsRoundTripper("Bearer", pConfig.Secret(token), rt), nil
+}
+
 func NewTLSConfig(auth *AuthMeta, unsafeSsl bool) (*tls.Config, error) {
 	return kedautil.NewTLSConfig(
 		auth.Cert,
@@ -175,7 +217,7 @@ func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf
 	switch roundTripperType {
 	case NetHTTP:
 		// from official github.com/prometheus/client_golang/api package
-		return &http.Transport{
+		rt = &http.Transport{
 			Proxy: http.ProxyFromEnvironment,
 			DialContext: (&net.Dialer{
 				Timeout:   30 * time.Second,
@@ -183,7 +225,15 @@ func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf
 			}).DialContext,
 			TLSHandshakeTimeout: 10 * time.Second,
 			TLSClientConfig:     tlsConfig,
-		}, nil
+		}
+		if auth != nil && auth.EnableServiceAccountTokenAuth {
+			rt = pConfig.NewAuthorizationCredentialsRoundTripper(
+				"Bearer",
+				pConfig.Secret(auth.ServiceAccountToken),
+				rt,
+			)
+		}
+		return rt, nil
 	case FastHTTP:
 		// default configs
 		httpConf := &libs.HTTPTransport{
@@ -224,6 +274,14 @@ func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf
 					roundTripper,
 				)
 			}
+
+			if auth.EnableServiceAccountTokenAuth {
+				rt = pConfig.NewAuthorizationCredentialsRoundTripper(
+					"Bearer",
+					pConfig.Secret(auth.ServiceAccountToken),
+					roundTripper,
+				)
+			}
 		} else {
 			rt = roundTripper
 		}
@@ -21,6 +21,8 @@ const (
 	CustomAuthType Type = "custom"
 	// OAuthType is an auth type using a oAuth2
 	OAuthType Type = "oauth"
+	// ServiceAccountTokenAuthType is an auth type using a short-lived ServiceAccount token as bearer token
+	ServiceAccountTokenAuthType Type = "serviceAccountToken"
 )
 
 // TransportType is type of http transport
@@ -59,6 +61,10 @@ type AuthMeta struct {
 	EnableCustomAuth bool
 	CustomAuthHeader string
 	CustomAuthValue  string
+
+	// ServiceAccount token, requested by KEDA through the TokenRequest API
+	EnableServiceAccountTokenAuth bool
+	ServiceAccountToken           string
 }
 
 type HTTPTransport struct {
@@ -0,0 +1,375 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	clickHouseDefaultDatabase = "default"
+	clickHouseDefaultUsername = "default"
+
+	clickHouseProtocolHTTP   = "http"
+	clickHouseProtocolNative = "native"
+
+	// clickHouseDefaultPort and clickHouseDefaultTLSPort are the default ports of the HTTP interface
+	clickHouseDefaultPort    = "8123"
+	clickHouseDefaultTLSPort = "8443"
+
+	// clickHouseDefaultNativePort and clickHouseDefaultNativeTLSPort are the default ports of the native interface
+	clickHouseDefaultNativePort    = "9000"
+	clickHouseDefaultNativeTLSPort = "9440"
+
+	// clickHouseNull is how the TabSeparated format writes NULL
+	clickHouseNull = `\N`
+)
+
+type clickHouseScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *clickHouseMetadata
+	httpClient *http.Client
+	connection *sql.DB
+	logger     logr.Logger
+}
+
+type clickHouseMetadata struct {
+	protocol                   string
+	host                       string
+	port                       string
+	database                   string
+	username                   string
+	password                   string
+	query                      string
+	targetQueryValue           float64
+	activationTargetQueryValue float64
+
+	// TLS
+	enableTLS   bool
+	ca          string
+	cert        string
+	key         string
+	keyPassword string
+	unsafeSsl   bool
+
+	triggerIndex int
+}
+
+// NewClickHouseScaler creates a new ClickHouse scaler querying the HTTP or the native interface of the server
+func NewClickHouseScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "clickhouse_scaler")
+
+	meta, err := parseClickHouseMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ClickHouse metadata: %w", err)
+	}
+
+	scaler := &clickHouseScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     logger,
+	}
+
+	var tlsConfig *tls.Config
+	if meta.enableTLS {
+		tlsConfig, err = kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca, meta.unsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if meta.protocol == clickHouseProtocolNative {
+		scaler.connection = clickhouse.OpenDB(getClickHouseNativeOptions(meta, tlsConfig, config.GlobalHTTPTimeout))
+		return scaler, nil
+	}
+
+	scaler.httpClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+	if tlsConfig != nil {
+		scaler.httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+	return scaler, nil
+}
+
+// getClickHouseNativeOptions returns the options of the clickhouse-go connection to the native interface,
+// the queries run in readonly mode like the GET requests sent to the HTTP interface
+func getClickHouseNativeOptions(meta *clickHouseMetadata, tlsConfig *tls.Config, timeout time.Duration) *clickhouse.Options {
+	return &clickhouse.Options{
+		Protocol: clickhouse.Native,
+		Addr:     []string{net.JoinHostPort(meta.host, meta.port)},
+		Auth: clickhouse.Auth{
+			Database: meta.database,
+			Username: meta.username,
+			Password: meta.password,
+		},
+		TLS:         tlsConfig,
+		Settings:    clickhouse.Settings{"readonly": 1},
+		DialTimeout: timeout,
+	}
+}
+
+func parseClickHouseMetadata(config *scalersconfig.ScalerConfig) (*clickHouseMetadata, error) {
+	meta := clickHouseMetadata{}
+
+	meta.protocol = clickHouseProtocolHTTP
+	if val, ok := config.TriggerMetadata["protocol"]; ok && val != "" {
+		switch val {
+		case clickHouseProtocolHTTP, clickHouseProtocolNative:
+			meta.protocol = val
+		default:
+			return nil, fmt.Errorf("protocol must be either %s or %s, got %s", clickHouseProtocolHTTP, clickHouseProtocolNative, val)
+		}
+	}
+
+	if val, ok := config.TriggerMetadata["query"]; ok && val != "" {
+		meta.query = val
+	} else {
+		return nil, fmt.Errorf("no query given")
+	}
+
+	if val, ok := config.TriggerMetadata["targetQueryValue"]; ok {
+		targetQueryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("targetQueryValue parsing error %w", err)
+		}
+		meta.targetQueryValue = targetQueryValue
+	} else {
+		if config.AsMetricSource {
+			meta.targetQueryValue = 0
+		} else {
+			return nil, fmt.Errorf("no targetQueryValue given")
+		}
+	}
+
+	meta.activationTargetQueryValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetQueryValue"]; ok {
+		activationTargetQueryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("activationTargetQueryValue parsing error %w", err)
+		}
+		meta.activationTargetQueryValue = activationTargetQueryValue
+	}
+
+	host, err := GetFromAuthOrMeta(config, "host")
+	if err != nil {
+		return nil, err
+	}
+	meta.host = host
+
+	meta.database = clickHouseDefaultDatabase
+	if val, ok := config.TriggerMetadata["database"]; ok && val != "" {
+		meta.database = val
+	}
+
+	meta.username = clickHouseDefaultUsername
+	if val, err := GetFromAuthOrMeta(config, "username"); err == nil && val != "" {
+		meta.username = val
+	}
+	if config.AuthParams["password"] != "" {
+		meta.password = config.AuthParams["password"]
+	} else if config.TriggerMetadata["passwordFromEnv"] != "" {
+		meta.password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
+	}
+
+	if err := parseClickHouseTLS(config, &meta); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case meta.protocol == clickHouseProtocolNative && meta.enableTLS:
+		meta.port = clickHouseDefaultNativeTLSPort
+	case meta.protocol == clickHouseProtocolNative:
+		meta.port = clickHouseDefaultNativePort
+	case meta.enableTLS:
+		meta.port = clickHouseDefaultTLSPort
+	default:
+		meta.port = clickHouseDefaultPort
+	}
+	if val, err := GetFromAuthOrMeta(config, "port"); err == nil && val != "" {
+		if _, err := strconv.ParseUint(val, 10, 16); err != nil {
+			return nil, fmt.Errorf("port parsing error %w", err)
+		}
+		meta.port = val
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return &meta, nil
+}
+
+// parseClickHouseTLS enables TLS with the enableTLS parameter or the tls auth param, like the redis scaler does
+func parseClickHouseTLS(config *scalersconfig.ScalerConfig, meta *clickHouseMetadata) error {
+	meta.enableTLS = false
+	if val, ok := config.TriggerMetadata["enableTLS"]; ok {
+		enableTLS, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("enableTLS parsing error %w", err)
+		}
+		meta.enableTLS = enableTLS
+	}
+
+	meta.unsafeSsl = false
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
+		unsafeSsl, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("error parsing unsafeSsl: %w", err)
+		}
+		meta.unsafeSsl = unsafeSsl
+	}
+
+	if val, ok := config.AuthParams["tls"]; ok {
+		val = strings.TrimSpace(val)
+		if meta.enableTLS {
+			return errors.New("unable to set `tls` in both ScaledObject and TriggerAuthentication together")
+		}
+		switch val {
+		case stringEnable:
+			meta.enableTLS = true
+		case stringDisable:
+			meta.enableTLS = false
+		default:
+			return fmt.Errorf("error incorrect TLS value given, got %s", val)
+		}
+	}
+
+	if !meta.enableTLS {
+		return nil
+	}
+	certGiven := config.AuthParams["cert"] != ""
+	keyGiven := config.AuthParams["key"] != ""
+	if certGiven && !keyGiven {
+		return errors.New("key must be provided with cert")
+	}
+	if keyGiven && !certGiven {
+		return errors.New("cert must be provided with key")
+	}
+	meta.ca = config.AuthParams["ca"]
+	meta.cert = config.AuthParams["cert"]
+	meta.key = config.AuthParams["key"]
+	meta.keyPassword = config.AuthParams["keyPassword"]
+	return nil
+}
+
+// Close releases the connections of the scaler
+func (s *clickHouseScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	if s.connection != nil {
+		if err := s.connection.Close(); err != nil {
+			s.logger.Error(err, "Error closing ClickHouse connection")
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *clickHouseScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("clickhouse-%s", s.metadata.database))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueryValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *clickHouseScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var num float64
+	var err error
+	if s.connection != nil {
+		num, err = querySQLValue(ctx, s.connection, s.metadata.query, sqlAggregationFirst)
+	} else {
+		num, err = s.queryHTTP(ctx)
+	}
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("could not query ClickHouse: %s", err))
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting ClickHouse: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, num)
+
+	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationTargetQueryValue, nil
+}
+
+// queryHTTP runs the query over the HTTP interface and returns the first column of the first row, 0 is returned when
+// the query doesn't return any row or a NULL value. The query is sent with a GET request, which ClickHouse runs in
+// readonly mode, so the query can't write data or run DDL.
+func (s *clickHouseScaler) queryHTTP(ctx context.Context) (float64, error) {
+	scheme := "http"
+	if s.metadata.enableTLS {
+		scheme = "https"
+	}
+	params := url.Values{}
+	params.Set("database", s.metadata.database)
+	params.Set("default_format", "TabSeparated")
+	params.Set("query", s.metadata.query)
+	queryURL := url.URL{
+		Scheme:   scheme,
+		Host:     net.JoinHostPort(s.metadata.host, s.metadata.port),
+		Path:     "/",
+		RawQuery: params.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("X-ClickHouse-User", s.metadata.username)
+	req.Header.Set("X-ClickHouse-Key", s.metadata.password)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("clickhouse returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	// the exception code header reports an exception raised with a 200 status
+	if code := resp.Header.Get("X-ClickHouse-Exception-Code"); code != "" {
+		return 0, fmt.Errorf("clickhouse returned the exception code %s", code)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+	value, _, _ := strings.Cut(strings.TrimRight(line, "\r\n"), "\t")
+	if value == "" || value == clickHouseNull {
+		return 0, nil
+	}
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("query returned %q, not a number", value)
+	}
+	return num, nil
+}
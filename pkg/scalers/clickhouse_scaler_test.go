@@ -0,0 +1,211 @@
+package scalers
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseClickHouseMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testClickHouseMetadata = []parseClickHouseMetadataTestData{
+	{name: "nothing passed", metadata: map[string]string{}, isError: true},
+	{name: "properly formed", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events", "targetQueryValue": "100"}},
+	{name: "all parameters", metadata: map[string]string{"host": "clickhouse", "port": "8124", "database": "analytics", "query": "SELECT count() FROM events", "targetQueryValue": "100", "activationTargetQueryValue": "10", "enableTLS": "true", "unsafeSsl": "true"}, authParams: map[string]string{"username": "keda", "password": "secret", "ca": "ca", "cert": "cert", "key": "key"}},
+	{name: "host from auth params", metadata: map[string]string{"query": "SELECT count() FROM events", "targetQueryValue": "100"}, authParams: map[string]string{"host": "clickhouse", "tls": "enable"}},
+	{name: "missing host", metadata: map[string]string{"query": "SELECT count() FROM events", "targetQueryValue": "100"}, isError: true},
+	{name: "missing query", metadata: map[string]string{"host": "clickhouse", "targetQueryValue": "100"}, isError: true},
+	{name: "missing targetQueryValue", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events"}, isError: true},
+	{name: "invalid targetQueryValue", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events", "targetQueryValue": "a"}, isError: true},
+	{name: "invalid activationTargetQueryValue", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events", "targetQueryValue": "100", "activationTargetQueryValue": "a"}, isError: true},
+	{name: "invalid port", metadata: map[string]string{"host": "clickhouse", "port": "a", "query": "SELECT count() FROM events", "targetQueryValue": "100"}, isError: true},
+	{name: "invalid enableTLS", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events", "targetQueryValue": "100", "enableTLS": "a"}, isError: true},
+	{name: "invalid tls", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events", "targetQueryValue": "100"}, authParams: map[string]string{"tls": "yes"}, isError: true},
+	{name: "tls and enableTLS", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events", "targetQueryValue": "100", "enableTLS": "true"}, authParams: map[string]string{"tls": "enable"}, isError: true},
+	{name: "native protocol", metadata: map[string]string{"host": "clickhouse", "protocol": "native", "query": "SELECT count() FROM events", "targetQueryValue": "100"}},
+	{name: "invalid protocol", metadata: map[string]string{"host": "clickhouse", "protocol": "grpc", "query": "SELECT count() FROM events", "targetQueryValue": "100"}, isError: true},
+	{name: "cert without key", metadata: map[string]string{"host": "clickhouse", "query": "SELECT count() FROM events", "targetQueryValue": "100", "enableTLS": "true"}, authParams: map[string]string{"cert": "cert"}, isError: true},
+}
+
+func TestParseClickHouseMetadata(t *testing.T) {
+	for _, testData := range testClickHouseMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	meta, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, "8123", meta.port)
+	assert.Equal(t, clickHouseDefaultDatabase, meta.database)
+	assert.Equal(t, clickHouseDefaultUsername, meta.username)
+
+	meta, err = parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[3].metadata, AuthParams: testClickHouseMetadata[3].authParams})
+	assert.NoError(t, err)
+	assert.Equal(t, "8443", meta.port)
+	assert.True(t, meta.enableTLS)
+
+	meta, err = parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[13].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, clickHouseProtocolNative, meta.protocol)
+	assert.Equal(t, "9000", meta.port)
+
+	meta, err = parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[13].metadata, AuthParams: map[string]string{"tls": "enable"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "9440", meta.port)
+}
+
+func TestClickHouseNativeOptions(t *testing.T) {
+	meta, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"host": "clickhouse", "protocol": "native", "database": "analytics", "query": "SELECT count() FROM events", "targetQueryValue": "100"},
+		AuthParams:      map[string]string{"username": "keda", "password": "secret"},
+	})
+	assert.NoError(t, err)
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	options := getClickHouseNativeOptions(meta, tlsConfig, 3*time.Second)
+	assert.Equal(t, clickhouse.Native, options.Protocol)
+	assert.Equal(t, []string{"clickhouse:9000"}, options.Addr)
+	assert.Equal(t, clickhouse.Auth{Database: "analytics", Username: "keda", Password: "secret"}, options.Auth)
+	assert.Same(t, tlsConfig, options.TLS)
+	assert.Equal(t, 1, options.Settings["readonly"], "the queries must not write data")
+	assert.Equal(t, 3*time.Second, options.DialTimeout)
+}
+
+func TestClickHouseNativeGetMetricsUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NoError(t, err)
+	listener.Close()
+
+	scaler, err := NewClickHouseScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"host": host, "port": port, "protocol": "native", "query": "SELECT count() FROM events", "targetQueryValue": "10"},
+		GlobalHTTPTimeout: time.Second,
+	})
+	assert.NoError(t, err)
+	defer scaler.Close(context.Background())
+
+	_, _, err = scaler.GetMetricsAndActivity(context.Background(), "clickhouse")
+	assert.Error(t, err)
+}
+
+func TestClickHouseGetMetricSpecForScaling(t *testing.T) {
+	scaler, err := NewClickHouseScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[2].metadata, AuthParams: map[string]string{}, TriggerIndex: 1})
+	assert.NoError(t, err)
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-clickhouse-analytics", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, int64(100), metricSpec[0].External.Target.AverageValue.Value())
+}
+
+// newClickHouseHTTPHandler emulates the HTTP interface of ClickHouse, answering the query of the tests with the given response
+func newClickHouseHTTPHandler(t *testing.T, status int, response string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "ClickHouse only runs GET requests in readonly mode")
+		assert.Equal(t, "analytics", r.URL.Query().Get("database"))
+		assert.Equal(t, "TabSeparated", r.URL.Query().Get("default_format"))
+		if r.Header.Get("X-ClickHouse-User") != "keda" || r.Header.Get("X-ClickHouse-Key") != "secret" {
+			http.Error(w, "Code: 516. DB::Exception: keda: Authentication failed. (AUTHENTICATION_FAILED)", http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "SELECT count() FROM events WHERE processed = 0", r.URL.Query().Get("query"))
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}
+}
+
+func TestClickHouseGetMetricsAndActivity(t *testing.T) {
+	testData := []struct {
+		name       string
+		status     int
+		response   string
+		authParams map[string]string
+		value      float64
+		isActive   bool
+		isError    bool
+	}{
+		{name: "count of the rows", status: http.StatusOK, response: "42\n", value: 42, isActive: true},
+		{name: "first column of the first row", status: http.StatusOK, response: "12.5\tevents\n7\tlogs\n", value: 12.5, isActive: true},
+		{name: "below the activation", status: http.StatusOK, response: "5\n", value: 5},
+		{name: "no row", status: http.StatusOK, response: "", value: 0},
+		{name: "NULL value", status: http.StatusOK, response: "\\N\n", value: 0},
+		{name: "not a number", status: http.StatusOK, response: "events\n", isError: true},
+		{name: "exception", status: http.StatusNotFound, response: "Code: 60. DB::Exception: Table analytics.events does not exist. (UNKNOWN_TABLE)", isError: true},
+		{name: "wrong password", status: http.StatusOK, response: "42\n", authParams: map[string]string{"username": "keda", "password": "wrong"}, isError: true},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(newClickHouseHTTPHandler(t, tt.status, tt.response))
+			defer server.Close()
+			host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+			assert.NoError(t, err)
+
+			authParams := map[string]string{"username": "keda", "password": "secret"}
+			if tt.authParams != nil {
+				authParams = tt.authParams
+			}
+			scaler, err := NewClickHouseScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"host": host, "port": port, "database": "analytics", "query": "SELECT count() FROM events WHERE processed = 0", "targetQueryValue": "10", "activationTargetQueryValue": "5"},
+				AuthParams:      authParams,
+			})
+			assert.NoError(t, err)
+			defer scaler.Close(context.Background())
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "clickhouse")
+			if tt.isError {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			assert.Equal(t, int64(tt.value*1000), metrics[0].Value.MilliValue())
+			assert.Equal(t, tt.isActive, active)
+		})
+	}
+}
+
+func TestClickHouseGetMetricsWithTLS(t *testing.T) {
+	server := httptest.NewTLSServer(newClickHouseHTTPHandler(t, http.StatusOK, "42\n"))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	scaler, err := NewClickHouseScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"host": host, "port": port, "database": "analytics", "query": "SELECT count() FROM events WHERE processed = 0", "targetQueryValue": "10"},
+		AuthParams:      map[string]string{"username": "keda", "password": "secret", "tls": "enable", "ca": string(ca)},
+	})
+	assert.NoError(t, err)
+	defer scaler.Close(context.Background())
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "clickhouse")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	assert.Equal(t, int64(42), metrics[0].Value.Value())
+	assert.True(t, active)
+}
@@ -23,10 +23,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
@@ -56,12 +60,15 @@ type kafkaMetadata struct {
 	bootstrapServers       []string
 	group                  string
 	topic                  string
+	topicPattern           *regexp.Regexp
 	partitionLimitation    []int32
 	lagThreshold           int64
 	activationLagThreshold int64
 	offsetResetPolicy      offsetResetPolicy
 	allowIdleConsumers     bool
 	excludePersistentLag   bool
+	lagMode                kafkaLagMode
+	partitionLagCap        int64
 	version                sarama.KafkaVersion
 
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
@@ -103,6 +110,17 @@ const (
 	earliest offsetResetPolicy = "earliest"
 )
 
+// kafkaLagMode is the unit the lag of a partition is measured in
+type kafkaLagMode string
+
+const (
+	// kafkaLagModeOffset measures the lag as the number of messages between the committed offset and the log end
+	kafkaLagModeOffset kafkaLagMode = "offset"
+	// kafkaLagModeTime measures the lag as the seconds between the timestamps of the message at the committed offset
+	// and of the last message of the partition
+	kafkaLagModeTime kafkaLagMode = "time"
+)
+
 type kafkaSaslType string
 
 // supported SASL types
@@ -123,6 +141,8 @@ const (
 	defaultKafkaActivationLagThreshold = 0
 	defaultOffsetResetPolicy           = latest
 	invalidOffset                      = -1
+	// kafkaTimeLagFetchMaxBytes is enough to read the first record batch of a fetch, which brokers return even when it's larger
+	kafkaTimeLagFetchMaxBytes = 64 * 1024
 )
 
 // NewKafkaScaler creates a new kafkaScaler
@@ -387,16 +407,25 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		meta.topic = config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]]
 	case config.TriggerMetadata["topic"] != "":
 		meta.topic = config.TriggerMetadata["topic"]
+	case config.TriggerMetadata["topicPattern"] != "":
+		pattern, err := parseKafkaTopicPattern(config.TriggerMetadata["topicPattern"])
+		if err != nil {
+			return meta, err
+		}
+		meta.topicPattern = pattern
 	default:
 		meta.topic = ""
 		logger.V(1).Info(fmt.Sprintf("consumer group %q has no topic specified, "+
 			"will use all topics subscribed by the consumer group for scaling", meta.group))
 	}
+	if meta.topic != "" && config.TriggerMetadata["topicPattern"] != "" {
+		return meta, errors.New("topic and topicPattern cannot be set simultaneously")
+	}
 
 	meta.partitionLimitation = nil
 	partitionLimitationMetadata := strings.TrimSpace(config.TriggerMetadata["partitionLimitation"])
 	if partitionLimitationMetadata != "" {
-		if meta.topic == "" {
+		if meta.topic == "" && meta.topicPattern == nil {
 			logger.V(1).Info("no specific topic set, ignoring partitionLimitation setting")
 		} else {
 			pattern := config.TriggerMetadata["partitionLimitation"]
@@ -487,11 +516,23 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		if meta.allowIdleConsumers && meta.limitToPartitionsWithLag {
 			return meta, fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
 		}
-		if len(meta.topic) == 0 && meta.limitToPartitionsWithLag {
-			return meta, fmt.Errorf("topic must be specified when using limitToPartitionsWithLag")
+		if len(meta.topic) == 0 && meta.topicPattern == nil && meta.limitToPartitionsWithLag {
+			return meta, fmt.Errorf("topic or topicPattern must be specified when using limitToPartitionsWithLag")
 		}
 	}
 
+	lagMode, err := parseKafkaLagMode(config)
+	if err != nil {
+		return meta, err
+	}
+	meta.lagMode = lagMode
+
+	partitionLagCap, err := parseKafkaPartitionLagCap(config)
+	if err != nil {
+		return meta, err
+	}
+	meta.partitionLagCap = partitionLagCap
+
 	meta.version = sarama.V1_0_0_0
 	if val, ok := config.TriggerMetadata["version"]; ok {
 		val = strings.TrimSpace(val)
@@ -501,10 +542,82 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		}
 		meta.version = version
 	}
+	if meta.lagMode == kafkaLagModeTime && !meta.version.IsAtLeast(sarama.V0_11_0_0) {
+		return meta, fmt.Errorf("lagMode %s requires kafka version 0.11.0.0 or later", kafkaLagModeTime)
+	}
 	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
 
+// parseKafkaTopicPattern compiles the topicPattern, which must match the whole topic name like the pattern subscriptions of Kafka consumers
+func parseKafkaTopicPattern(pattern string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing topicPattern: %w", err)
+	}
+	return compiled, nil
+}
+
+// matchKafkaTopics returns the sorted topics matching the pattern, internal topics such as __consumer_offsets are never matched
+func matchKafkaTopics(pattern *regexp.Regexp, topics []string) []string {
+	var matched []string
+	for _, topic := range topics {
+		if !strings.HasPrefix(topic, "__") && pattern.MatchString(topic) {
+			matched = append(matched, topic)
+		}
+	}
+	slices.Sort(matched)
+	return matched
+}
+
+func parseKafkaLagMode(config *scalersconfig.ScalerConfig) (kafkaLagMode, error) {
+	lagMode := kafkaLagModeOffset
+	if val, ok := config.TriggerMetadata["lagMode"]; ok && val != "" {
+		lagMode = kafkaLagMode(strings.ToLower(strings.TrimSpace(val)))
+		if lagMode != kafkaLagModeOffset && lagMode != kafkaLagModeTime {
+			return "", fmt.Errorf("lagMode must be %s or %s, got %q", kafkaLagModeOffset, kafkaLagModeTime, val)
+		}
+	}
+	return lagMode, nil
+}
+
+func parseKafkaPartitionLagCap(config *scalersconfig.ScalerConfig) (int64, error) {
+	val, ok := config.TriggerMetadata["partitionLagCap"]
+	if !ok || val == "" {
+		return 0, nil
+	}
+	partitionLagCap, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing partitionLagCap: %w", err)
+	}
+	if partitionLagCap <= 0 {
+		return 0, errors.New("partitionLagCap must be positive number")
+	}
+	return partitionLagCap, nil
+}
+
+// kafkaTimeLag returns the seconds between the timestamps of the next message to consume and of the last message, rounded up.
+// A partition with unconsumed messages has a lag of at least one second, so it activates the target even when the messages
+// were produced within the same second.
+func kafkaTimeLag(next, last time.Time) int64 {
+	return max(1, int64(math.Ceil(last.Sub(next).Seconds())))
+}
+
+// applyKafkaTimeLag replaces the offset lags of a partition by its time lag, partitions without time lag keep
+// the lags returned for invalid offsets
+func applyKafkaTimeLag(lag, lagWithPersistent int64, timeLag int64, found bool) (int64, int64) {
+	if !found {
+		return lag, lagWithPersistent
+	}
+	if lag > 0 {
+		lag = timeLag
+	}
+	if lagWithPersistent > 0 {
+		lagWithPersistent = timeLag
+	}
+	return lag, lagWithPersistent
+}
+
 func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
 	config := sarama.NewConfig()
 	config.Version = metadata.version
@@ -582,8 +695,21 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 	var topicsToDescribe = make([]string, 0)
 
+	switch {
+	case s.metadata.topicPattern != nil:
+		if err := s.client.RefreshMetadata(); err != nil {
+			return nil, fmt.Errorf("error refreshing metadata: %w", err)
+		}
+		topics, err := s.client.Topics()
+		if err != nil {
+			return nil, fmt.Errorf("error listing topics: %w", err)
+		}
+		topicsToDescribe = matchKafkaTopics(s.metadata.topicPattern, topics)
+		if len(topicsToDescribe) == 0 {
+			return nil, fmt.Errorf("no topic matches topicPattern %s", s.metadata.topicPattern)
+		}
 	// when no topic is specified, query to cg group to fetch all subscribed topics
-	if s.metadata.topic == "" {
+	case s.metadata.topic == "":
 		listCGOffsetResponse, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error listing cg offset: %w", err)
@@ -597,7 +723,7 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 		for topicName := range listCGOffsetResponse.Blocks {
 			topicsToDescribe = append(topicsToDescribe, topicName)
 		}
-	} else {
+	default:
 		topicsToDescribe = []string{s.metadata.topic}
 	}
 
@@ -746,6 +872,7 @@ func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	if s.metadata.topic != "" {
 		metricName = fmt.Sprintf("kafka-%s", s.metadata.topic)
 	} else {
+		// patterns aren't valid metric names, so the topics matching a pattern are named like the topics of the group
 		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.group)
 	}
 
@@ -820,6 +947,14 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 		return 0, 0, err
 	}
 
+	var timeLags map[string]map[int32]int64
+	if s.metadata.lagMode == kafkaLagModeTime {
+		timeLags, err = s.getTimeLags(consumerOffsets, producerOffsets)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
 	totalLag := int64(0)
 	totalLagWithPersistent := int64(0)
 	totalTopicPartitions := int64(0)
@@ -831,6 +966,14 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 			if err != nil {
 				return 0, 0, err
 			}
+			if s.metadata.lagMode == kafkaLagModeTime {
+				timeLag, found := timeLags[topic][partition]
+				lag, lagWithPersistent = applyKafkaTimeLag(lag, lagWithPersistent, timeLag, found)
+			}
+			if s.metadata.partitionLagCap > 0 {
+				lag = min(lag, s.metadata.partitionLagCap)
+				lagWithPersistent = min(lagWithPersistent, s.metadata.partitionLagCap)
+			}
 			totalLag += lag
 			totalLagWithPersistent += lagWithPersistent
 
@@ -921,3 +1064,145 @@ func (s *kafkaScaler) getProducerOffsets(topicPartitions map[string][]int32) (ma
 
 	return topicPartitionsOffsets, nil
 }
+
+// getTimeLags returns the time lag in seconds of the partitions with unconsumed messages. The next message to consume is at
+// the committed offset, or at the oldest offset when nothing is committed yet and offsetResetPolicy is earliest. Partitions
+// whose messages can't be fetched are left out, so they keep their offset lag.
+func (s *kafkaScaler) getTimeLags(consumerOffsets *sarama.OffsetFetchResponse, producerOffsets map[string]map[int32]int64) (map[string]map[int32]int64, error) {
+	nextOffsets := make(map[string]map[int32]int64)
+	lastOffsets := make(map[string]map[int32]int64)
+	for topic, partitionsOffsets := range producerOffsets {
+		for partitionID, latestOffset := range partitionsOffsets {
+			block := consumerOffsets.GetBlock(topic, partitionID)
+			if block == nil {
+				continue
+			}
+			nextOffset := block.Offset
+			if nextOffset == invalidOffset {
+				if s.metadata.offsetResetPolicy != earliest {
+					continue
+				}
+				oldestOffset, err := s.client.GetOffset(topic, partitionID, sarama.OffsetOldest)
+				if err != nil {
+					return nil, fmt.Errorf("error getting oldest offset for topic %s and partition %d: %w", topic, partitionID, err)
+				}
+				nextOffset = oldestOffset
+			}
+			if nextOffset >= latestOffset {
+				continue
+			}
+			if nextOffsets[topic] == nil {
+				nextOffsets[topic] = make(map[int32]int64)
+				lastOffsets[topic] = make(map[int32]int64)
+			}
+			nextOffsets[topic][partitionID] = nextOffset
+			lastOffsets[topic][partitionID] = latestOffset - 1
+		}
+	}
+
+	nextTimestamps, err := s.getMessageTimestamps(nextOffsets)
+	if err != nil {
+		return nil, err
+	}
+	lastTimestamps, err := s.getMessageTimestamps(lastOffsets)
+	if err != nil {
+		return nil, err
+	}
+
+	timeLags := make(map[string]map[int32]int64, len(nextTimestamps))
+	for topic, partitionsTimestamps := range nextTimestamps {
+		timeLags[topic] = make(map[int32]int64, len(partitionsTimestamps))
+		for partitionID, nextTimestamp := range partitionsTimestamps {
+			lastTimestamp, found := lastTimestamps[topic][partitionID]
+			if !found {
+				continue
+			}
+			timeLags[topic][partitionID] = kafkaTimeLag(nextTimestamp, lastTimestamp)
+		}
+	}
+	return timeLags, nil
+}
+
+// getMessageTimestamps returns the timestamps of the messages at the offsets, fetching them with one request per broker.
+// Partitions whose message can't be fetched, e.g. because it was deleted by the retention or the fetch of the partition
+// failed, are left out of the timestamps.
+func (s *kafkaScaler) getMessageTimestamps(offsets map[string]map[int32]int64) (map[string]map[int32]time.Time, error) {
+	requests := make(map[*sarama.Broker]*sarama.FetchRequest)
+	requestedOffsets := make(map[*sarama.Broker]map[string]map[int32]int64)
+	for topic, partitionsOffsets := range offsets {
+		for partitionID, offset := range partitionsOffsets {
+			broker, err := s.client.Leader(topic, partitionID)
+			if err != nil {
+				return nil, err
+			}
+			request, ok := requests[broker]
+			if !ok {
+				request = &sarama.FetchRequest{Version: 4, MinBytes: 1, MaxBytes: kafkaTimeLagFetchMaxBytes, Isolation: sarama.ReadUncommitted}
+				requests[broker] = request
+				requestedOffsets[broker] = make(map[string]map[int32]int64)
+			}
+			request.AddBlock(topic, partitionID, offset, kafkaTimeLagFetchMaxBytes, -1)
+			if requestedOffsets[broker][topic] == nil {
+				requestedOffsets[broker][topic] = make(map[int32]int64)
+			}
+			requestedOffsets[broker][topic][partitionID] = offset
+		}
+	}
+
+	timestamps := make(map[string]map[int32]time.Time)
+	for broker, request := range requests {
+		response, err := broker.Fetch(request)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching messages: %w", err)
+		}
+		for topic, partitionsOffsets := range requestedOffsets[broker] {
+			for partitionID, offset := range partitionsOffsets {
+				block := response.GetBlock(topic, partitionID)
+				if block == nil {
+					s.logger.V(1).Info(fmt.Sprintf("no fetch response for topic %s and partition %d, using the offset lag", topic, partitionID))
+					continue
+				}
+				if block.Err != sarama.ErrNoError {
+					s.logger.V(1).Info(fmt.Sprintf("error fetching message at offset %d for topic %s and partition %d, using the offset lag: %s", offset, topic, partitionID, block.Err))
+					continue
+				}
+				timestamp, found := kafkaRecordTimestamp(block.RecordsSet, offset)
+				if !found {
+					s.logger.V(1).Info(fmt.Sprintf("no message found at offset %d for topic %s and partition %d, using the offset lag", offset, topic, partitionID))
+					continue
+				}
+				if timestamps[topic] == nil {
+					timestamps[topic] = make(map[int32]time.Time)
+				}
+				timestamps[topic][partitionID] = timestamp
+			}
+		}
+	}
+	return timestamps, nil
+}
+
+// kafkaRecordTimestamp returns the timestamp of the first record at or after the offset, fetches return the whole batch
+// containing the offset
+func kafkaRecordTimestamp(recordsSet []*sarama.Records, offset int64) (time.Time, bool) {
+	for _, records := range recordsSet {
+		if batch := records.RecordBatch; batch != nil {
+			for _, record := range batch.Records {
+				if batch.FirstOffset+record.OffsetDelta < offset {
+					continue
+				}
+				if batch.LogAppendTime {
+					return batch.MaxTimestamp, true
+				}
+				return batch.FirstTimestamp.Add(record.TimestampDelta), true
+			}
+		}
+		if records.MsgSet != nil {
+			for _, message := range records.MsgSet.Messages {
+				if message.Offset >= offset && message.Msg != nil {
+					return message.Msg.Timestamp, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
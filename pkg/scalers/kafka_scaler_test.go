@@ -3,11 +3,14 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
@@ -555,6 +558,203 @@ func TestGetTopicPartitions(t *testing.T) {
 	}
 }
 
+func TestKafkaLagOptionsMetadata(t *testing.T) {
+	testData := []struct {
+		name            string
+		metadata        map[string]string
+		isError         bool
+		topicPattern    string
+		lagMode         kafkaLagMode
+		partitionLagCap int64
+	}{
+		{"defaults", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, "", kafkaLagModeOffset, 0},
+		{"topicPattern", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicPattern": "orders-.*"}, false, "^(?:orders-.*)$", kafkaLagModeOffset, 0},
+		{"topicPattern with limitToPartitionsWithLag", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicPattern": "orders-.*", "limitToPartitionsWithLag": "true"}, false, "^(?:orders-.*)$", kafkaLagModeOffset, 0},
+		{"topic and topicPattern", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "topicPattern": "orders-.*"}, true, "", "", 0},
+		{"invalid topicPattern", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicPattern": "orders-("}, true, "", "", 0},
+		{"time lagMode", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "Time"}, false, "", kafkaLagModeTime, 0},
+		{"invalid lagMode", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "bytes"}, true, "", "", 0},
+		{"time lagMode with old kafka version", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "time", "version": "0.10.2.0"}, true, "", "", 0},
+		{"partitionLagCap", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLagCap": "100"}, false, "", kafkaLagModeOffset, 100},
+		{"invalid partitionLagCap", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLagCap": "a"}, true, "", "", 0},
+		{"zero partitionLagCap", map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "partitionLagCap": "0"}, true, "", "", 0},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tt.metadata}, logr.Discard())
+			if tt.isError {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			topicPattern := ""
+			if meta.topicPattern != nil {
+				topicPattern = meta.topicPattern.String()
+			}
+			if topicPattern != tt.topicPattern {
+				t.Errorf("Expected topicPattern %q but got %q", tt.topicPattern, topicPattern)
+			}
+			if meta.lagMode != tt.lagMode {
+				t.Errorf("Expected lagMode %s but got %s", tt.lagMode, meta.lagMode)
+			}
+			if meta.partitionLagCap != tt.partitionLagCap {
+				t.Errorf("Expected partitionLagCap %d but got %d", tt.partitionLagCap, meta.partitionLagCap)
+			}
+		})
+	}
+}
+
+// newKafkaMockBroker returns the bootstrap broker of a cluster with the orders, payments, invoices and refunds topics, the consumer
+// group my-group has committed offsets on all of them. Each partition has its own leader, whose fetch response only contains the
+// records of the partition like a real broker, which the time lag mode reads. The messages of refunds were deleted by the retention,
+// so its fetches fail.
+func newKafkaMockBroker(t *testing.T) *sarama.MockBroker {
+	now := time.Now().Truncate(time.Millisecond)
+	partitions := []struct {
+		topic     string
+		partition int32
+		records   map[int64]time.Time
+		fetchErr  sarama.KError
+	}{
+		{"orders", 0, map[int64]time.Time{5: now.Add(-time.Minute), 9: now.Add(-30 * time.Second)}, sarama.ErrNoError},
+		{"orders", 1, map[int64]time.Time{8: now.Add(-50 * time.Second), 9: now.Add(-30 * time.Second)}, sarama.ErrNoError},
+		{"payments", 0, map[int64]time.Time{1: now.Add(-5 * time.Second), 100: now.Add(-2500 * time.Millisecond)}, sarama.ErrNoError},
+		{"invoices", 0, map[int64]time.Time{2: now.Add(-10 * time.Second), 3: now.Add(-5 * time.Second)}, sarama.ErrNoError},
+		{"refunds", 0, nil, sarama.ErrOffsetOutOfRange},
+	}
+
+	bootstrap := sarama.NewMockBroker(t, 1)
+	metadataResponse := sarama.NewMockMetadataResponse(t).
+		SetController(bootstrap.BrokerID()).
+		SetBroker(bootstrap.Addr(), bootstrap.BrokerID()).
+		SetLeader("__consumer_offsets", 0, bootstrap.BrokerID())
+	leaders := make([]*sarama.MockBroker, len(partitions))
+	for i, p := range partitions {
+		leaders[i] = sarama.NewMockBroker(t, int32(i+2))
+		t.Cleanup(leaders[i].Close)
+		metadataResponse.SetBroker(leaders[i].Addr(), leaders[i].BrokerID()).SetLeader(p.topic, p.partition, leaders[i].BrokerID())
+	}
+
+	handlers := map[string]sarama.MockResponse{
+		"MetadataRequest": metadataResponse,
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "my-group", bootstrap),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("my-group", "orders", 0, 5, "", sarama.ErrNoError).
+			SetOffset("my-group", "orders", 1, 8, "", sarama.ErrNoError).
+			SetOffset("my-group", "payments", 0, 1, "", sarama.ErrNoError).
+			SetOffset("my-group", "invoices", 0, -1, "", sarama.ErrNoError).
+			SetOffset("my-group", "refunds", 0, 3, "", sarama.ErrNoError),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("orders", 0, sarama.OffsetNewest, 10).
+			SetOffset("orders", 0, sarama.OffsetOldest, 0).
+			SetOffset("orders", 1, sarama.OffsetNewest, 10).
+			SetOffset("orders", 1, sarama.OffsetOldest, 0).
+			SetOffset("payments", 0, sarama.OffsetNewest, 101).
+			SetOffset("payments", 0, sarama.OffsetOldest, 0).
+			SetOffset("invoices", 0, sarama.OffsetNewest, 4).
+			SetOffset("invoices", 0, sarama.OffsetOldest, 2).
+			SetOffset("refunds", 0, sarama.OffsetNewest, 6).
+			SetOffset("refunds", 0, sarama.OffsetOldest, 0),
+		// the group has no member, as when the target is scaled to zero
+		"DescribeGroupsRequest": sarama.NewMockDescribeGroupsResponse(t).
+			AddGroupDescription("my-group", &sarama.GroupDescription{GroupId: "my-group", State: "Empty"}),
+		// the versions used by the kafka-go client of the apache-kafka scaler
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t).SetApiKeys([]sarama.ApiVersionsResponseKey{
+			{ApiKey: 1, MinVersion: 4, MaxVersion: 4},  // Fetch
+			{ApiKey: 2, MinVersion: 1, MaxVersion: 1},  // ListOffsets
+			{ApiKey: 3, MinVersion: 1, MaxVersion: 1},  // Metadata
+			{ApiKey: 9, MinVersion: 2, MaxVersion: 2},  // OffsetFetch
+			{ApiKey: 10, MinVersion: 0, MaxVersion: 0}, // FindCoordinator
+			{ApiKey: 15, MinVersion: 0, MaxVersion: 0}, // DescribeGroups
+			{ApiKey: 18, MinVersion: 0, MaxVersion: 0}, // ApiVersions
+		}),
+	}
+	bootstrap.SetHandlerByMap(handlers)
+
+	for i, p := range partitions {
+		fetchResponse := &sarama.FetchResponse{Version: 4}
+		offsets := make([]int64, 0, len(p.records))
+		for offset := range p.records {
+			offsets = append(offsets, offset)
+		}
+		slices.Sort(offsets)
+		for _, offset := range offsets {
+			fetchResponse.AddRecordWithTimestamp(p.topic, p.partition, nil, sarama.StringEncoder("message"), offset, p.records[offset])
+		}
+		if p.fetchErr != sarama.ErrNoError {
+			fetchResponse.AddError(p.topic, p.partition, p.fetchErr)
+		}
+
+		leaderHandlers := maps.Clone(handlers)
+		leaderHandlers["FetchRequest"] = sarama.NewMockWrapper(fetchResponse)
+		leaders[i].SetHandlerByMap(leaderHandlers)
+	}
+	return bootstrap
+}
+
+func TestKafkaGetTotalLagWithMockBroker(t *testing.T) {
+	broker := newKafkaMockBroker(t)
+	defer broker.Close()
+
+	testData := []struct {
+		name     string
+		metadata map[string]string
+		lag      int64
+		isError  bool
+	}{
+		{"topic", map[string]string{"topic": "orders"}, 7, false},
+		{"topic capped to the partition count", map[string]string{"topic": "orders", "lagThreshold": "1"}, 2, false},
+		// invoices has no committed offset, so it adds a lag of 1 with the latest offsetResetPolicy
+		{"topicPattern skipping internal topics", map[string]string{"topicPattern": ".*"}, 7 + 100 + 1 + 3, false},
+		{"topicPattern", map[string]string{"topicPattern": "orders|pay.*"}, 107, false},
+		{"topicPattern without match", map[string]string{"topicPattern": "returns"}, 0, true},
+		{"topics of the consumer group", map[string]string{}, 111, false},
+		{"partitionLagCap", map[string]string{"topicPattern": "orders|payments", "partitionLagCap": "4"}, 4 + 2 + 4, false},
+		{"earliest offsetResetPolicy", map[string]string{"topic": "invoices", "offsetResetPolicy": "earliest"}, 4, false},
+		{"time lagMode", map[string]string{"topic": "orders", "lagMode": "time"}, 30 + 20, false},
+		// the 2.5 seconds between the payments are rounded up
+		{"time lagMode with topicPattern", map[string]string{"topicPattern": ".*", "lagMode": "time"}, 30 + 20 + 3 + 1 + 3, false},
+		{"time lagMode with partitionLagCap", map[string]string{"topicPattern": "orders|payments", "lagMode": "time", "partitionLagCap": "25"}, 25 + 20 + 3, false},
+		{"time lagMode with earliest offsetResetPolicy", map[string]string{"topic": "invoices", "lagMode": "time", "offsetResetPolicy": "earliest"}, 5, false},
+		{"time lagMode falling back to the offset lag", map[string]string{"topic": "refunds", "lagMode": "time"}, 3, false},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.metadata["bootstrapServers"] = broker.Addr()
+			tt.metadata["consumerGroup"] = "my-group"
+			if _, ok := tt.metadata["lagThreshold"]; !ok {
+				tt.metadata["allowIdleConsumers"] = "true"
+			}
+			scaler, err := NewKafkaScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tt.metadata})
+			if err != nil {
+				t.Fatal("Could not create scaler:", err)
+			}
+			defer scaler.Close(context.Background())
+
+			lag, _, err := scaler.(*kafkaScaler).getTotalLag()
+			if tt.isError {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if lag != tt.lag {
+				t.Errorf("Expected lag %d but got %d", tt.lag, lag)
+			}
+		})
+	}
+}
+
 type MockClusterAdmin struct {
 	partitionIds []int32
 }
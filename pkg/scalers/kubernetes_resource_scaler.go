@@ -0,0 +1,337 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// kubernetesResourceCELCostLimit stops the evaluation of expressions iterating over too large objects
+	kubernetesResourceCELCostLimit = 1000000
+)
+
+// KubernetesResourceReader lists the objects read by the kubernetes-resource scalers. The operator sets it to the cache of
+// its manager, so the objects are served by informers shared by all the scalers instead of being listed from the API server
+// on each polling interval. Only the kinds listed in KEDA_KUBERNETES_RESOURCE_ALLOWED_KINDS can be read, the operator
+// service account must be granted the list and watch verbs on them, e.g. with a ClusterRole bound to keda-operator.
+var KubernetesResourceReader client.Reader
+
+// kubernetesResourceAllowedReader only lets the allowed kinds through to the reader, so no informer is started for the
+// other kinds
+type kubernetesResourceAllowedReader struct {
+	reader client.Reader
+}
+
+// NewKubernetesResourceAllowedReader returns a reader of the kinds allowed by KEDA_KUBERNETES_RESOURCE_ALLOWED_KINDS
+func NewKubernetesResourceAllowedReader(reader client.Reader) client.Reader {
+	return &kubernetesResourceAllowedReader{reader: reader}
+}
+
+func (r *kubernetesResourceAllowedReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := checkKubernetesResourceKindAllowed(obj.GetObjectKind().GroupVersionKind()); err != nil {
+		return err
+	}
+	return r.reader.Get(ctx, key, obj, opts...)
+}
+
+func (r *kubernetesResourceAllowedReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gvk := list.GetObjectKind().GroupVersionKind()
+	if err := checkKubernetesResourceKindAllowed(gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List"))); err != nil {
+		return err
+	}
+	return r.reader.List(ctx, list, opts...)
+}
+
+// checkKubernetesResourceKindAllowed returns an error when the kind isn't listed in KEDA_KUBERNETES_RESOURCE_ALLOWED_KINDS
+func checkKubernetesResourceKindAllowed(gvk schema.GroupVersionKind) error {
+	allowed, err := kedautil.IsKubernetesResourceKindAllowed(gvk)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("kind %s of apiVersion %s isn't allowed, it must be listed in %s", gvk.Kind, gvk.GroupVersion(), kedautil.KubernetesResourceAllowedKindsEnvVar)
+	}
+	return nil
+}
+
+type kubernetesResourceScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *kubernetesResourceMetadata
+	reader     client.Reader
+	logger     logr.Logger
+}
+
+type kubernetesResourceMetadata struct {
+	gvk             schema.GroupVersionKind
+	namespace       string
+	labelSelector   labels.Selector
+	jsonPath        *jsonpath.JSONPath
+	celProgram      cel.Program
+	value           float64
+	activationValue float64
+	triggerIndex    int
+}
+
+// NewKubernetesResourceScaler creates a new kubernetesResourceScaler
+func NewKubernetesResourceScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseKubernetesResourceMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubernetes resource metadata: %w", err)
+	}
+
+	var reader client.Reader = kubeClient
+	if KubernetesResourceReader != nil {
+		reader = KubernetesResourceReader
+	}
+
+	return &kubernetesResourceScaler{
+		metricType: metricType,
+		metadata:   meta,
+		reader:     reader,
+		logger:     InitializeLogger(config, "kubernetes_resource_scaler"),
+	}, nil
+}
+
+func parseKubernetesResourceMetadata(config *scalersconfig.ScalerConfig) (*kubernetesResourceMetadata, error) {
+	meta := &kubernetesResourceMetadata{}
+
+	apiVersion, ok := config.TriggerMetadata["apiVersion"]
+	if !ok || apiVersion == "" {
+		return nil, errors.New("no apiVersion given")
+	}
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %s: %w", apiVersion, err)
+	}
+	kind, ok := config.TriggerMetadata["kind"]
+	if !ok || kind == "" {
+		return nil, errors.New("no kind given")
+	}
+	meta.gvk = groupVersion.WithKind(kind)
+	if err := checkKubernetesResourceKindAllowed(meta.gvk); err != nil {
+		return nil, err
+	}
+
+	// the objects are only read from the namespace of the scaled object, like the kubernetes-workload scaler
+	meta.namespace = config.ScalableObjectNamespace
+
+	meta.labelSelector = labels.Everything()
+	if val, ok := config.TriggerMetadata["labelSelector"]; ok && val != "" {
+		labelSelector, err := labels.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		meta.labelSelector = labelSelector
+	}
+
+	jsonPathExpression := config.TriggerMetadata["jsonPath"]
+	celExpression := config.TriggerMetadata["celExpression"]
+	if jsonPathExpression != "" && celExpression != "" {
+		return nil, errors.New("jsonPath and celExpression cannot be set simultaneously")
+	}
+	if jsonPathExpression != "" {
+		meta.jsonPath = jsonpath.New("value").AllowMissingKeys(true)
+		if err := meta.jsonPath.Parse(jsonPathExpression); err != nil {
+			return nil, fmt.Errorf("invalid jsonPath: %w", err)
+		}
+	}
+	if celExpression != "" {
+		program, err := compileKubernetesResourceCELExpression(celExpression)
+		if err != nil {
+			return nil, err
+		}
+		meta.celProgram = program
+	}
+
+	if val, ok := config.TriggerMetadata["value"]; ok && val != "" {
+		value, err := strconv.ParseFloat(val, 64)
+		if err != nil || value <= 0 {
+			return nil, errors.New("value must be a float greater than 0")
+		}
+		meta.value = value
+	} else {
+		if config.AsMetricSource {
+			meta.value = 0
+		} else {
+			return nil, errors.New("no value given")
+		}
+	}
+
+	meta.activationValue = 0
+	if val, ok := config.TriggerMetadata["activationValue"]; ok && val != "" {
+		activationValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("activationValue parsing error %w", err)
+		}
+		meta.activationValue = activationValue
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// compileKubernetesResourceCELExpression compiles an expression evaluated on each object, available as the object variable,
+// which returns a bool or a number
+func compileKubernetesResourceCELExpression(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid celExpression: %w", issues.Err())
+	}
+	switch ast.OutputType() {
+	case cel.BoolType, cel.IntType, cel.UintType, cel.DoubleType, cel.DynType:
+	default:
+		return nil, fmt.Errorf("celExpression must return a bool or a number, got %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(kubernetesResourceCELCostLimit))
+}
+
+// Close no need for kubernetes resource scaler
+func (s *kubernetesResourceScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *kubernetesResourceScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("resource-%s", strings.ToLower(s.metadata.gvk.Kind)))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the sum of the values of the objects
+func (s *kubernetesResourceScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting kubernetes resource %s: %w", s.metadata.gvk, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.activationValue, nil
+}
+
+// getMetricValue sums the value of each listed object, which is 1 without jsonPath and celExpression so the objects are counted
+func (s *kubernetesResourceScaler) getMetricValue(ctx context.Context) (float64, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(s.metadata.gvk.GroupVersion().WithKind(s.metadata.gvk.Kind + "List"))
+	err := s.reader.List(ctx, list, client.InNamespace(s.metadata.namespace), client.MatchingLabelsSelector{Selector: s.metadata.labelSelector})
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for i := range list.Items {
+		value, err := s.getObjectValue(&list.Items[i])
+		if err != nil {
+			return 0, fmt.Errorf("error getting value of %s: %w", list.Items[i].GetName(), err)
+		}
+		total += value
+	}
+	return total, nil
+}
+
+func (s *kubernetesResourceScaler) getObjectValue(object *unstructured.Unstructured) (float64, error) {
+	switch {
+	case s.metadata.celProgram != nil:
+		result, _, err := s.metadata.celProgram.Eval(map[string]interface{}{"object": object.Object})
+		if err != nil {
+			return 0, err
+		}
+		switch v := result.(type) {
+		case types.Bool:
+			if v {
+				return 1, nil
+			}
+			return 0, nil
+		case types.Int:
+			return float64(v), nil
+		case types.Uint:
+			return float64(v), nil
+		case types.Double:
+			return float64(v), nil
+		default:
+			return 0, fmt.Errorf("celExpression returned %s, not a bool or a number", result.Type().TypeName())
+		}
+	case s.metadata.jsonPath != nil:
+		results, err := s.metadata.jsonPath.FindResults(object.Object)
+		if err != nil {
+			return 0, err
+		}
+		// the results of a path selecting several fields, like a list, are summed
+		var total float64
+		for _, result := range results {
+			for _, r := range result {
+				value, err := kubernetesResourceValueToFloat(r)
+				if err != nil {
+					return 0, err
+				}
+				total += value
+			}
+		}
+		return total, nil
+	default:
+		return 1, nil
+	}
+}
+
+// kubernetesResourceValueToFloat converts a field selected by jsonPath, a bool counts as 1 when true
+func kubernetesResourceValueToFloat(value reflect.Value) (float64, error) {
+	if value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return 0, nil
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(value.String(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("jsonPath selected %q, not a number", value.String())
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("jsonPath selected a %s, not a bool or a number", value.Kind())
+	}
+}
@@ -0,0 +1,189 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const testKubernetesResourceAllowedKinds = "v1/Pod,example.com/v1/Queue,tekton.dev/v1/PipelineRun"
+
+type parseKubernetesResourceMetadataTestData struct {
+	name     string
+	metadata map[string]string
+	isError  bool
+}
+
+var testKubernetesResourceMetadata = []parseKubernetesResourceMetadataTestData{
+	{name: "nothing passed", metadata: map[string]string{}, isError: true},
+	{name: "count", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "value": "5"}},
+	{name: "jsonPath", metadata: map[string]string{"apiVersion": "example.com/v1", "kind": "Queue", "jsonPath": "{.status.queueDepth}", "value": "100", "activationValue": "10"}},
+	{name: "celExpression", metadata: map[string]string{"apiVersion": "tekton.dev/v1", "kind": "PipelineRun", "labelSelector": "app in (build, test)", "celExpression": "object.spec.status == 'PipelineRunPending'", "value": "2"}},
+	{name: "missing apiVersion", metadata: map[string]string{"kind": "Pod", "value": "5"}, isError: true},
+	{name: "invalid apiVersion", metadata: map[string]string{"apiVersion": "tekton.dev/v1/beta", "kind": "Pod", "value": "5"}, isError: true},
+	{name: "missing kind", metadata: map[string]string{"apiVersion": "v1", "value": "5"}, isError: true},
+	{name: "kind not allowed", metadata: map[string]string{"apiVersion": "v1", "kind": "Secret", "value": "5"}, isError: true},
+	{name: "kind allowed in another apiVersion", metadata: map[string]string{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun", "value": "5"}, isError: true},
+	{name: "invalid labelSelector", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "labelSelector": "app in build", "value": "5"}, isError: true},
+	{name: "invalid jsonPath", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "jsonPath": "{.status", "value": "5"}, isError: true},
+	{name: "invalid celExpression", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "celExpression": "object.status.", "value": "5"}, isError: true},
+	{name: "celExpression returning a string", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "celExpression": "'pending'", "value": "5"}, isError: true},
+	{name: "jsonPath and celExpression", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "jsonPath": "{.status.phase}", "celExpression": "true", "value": "5"}, isError: true},
+	{name: "missing value", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod"}, isError: true},
+	{name: "zero value", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "value": "0"}, isError: true},
+	{name: "invalid activationValue", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "value": "5", "activationValue": "a"}, isError: true},
+}
+
+func TestParseKubernetesResourceMetadata(t *testing.T) {
+	t.Setenv(kedautil.KubernetesResourceAllowedKindsEnvVar, testKubernetesResourceAllowedKinds)
+	for _, testData := range testKubernetesResourceMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseKubernetesResourceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "test"})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseKubernetesResourceMetadataWithoutAllowedKinds(t *testing.T) {
+	_, err := parseKubernetesResourceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testKubernetesResourceMetadata[1].metadata, ScalableObjectNamespace: "test"})
+	assert.ErrorContains(t, err, kedautil.KubernetesResourceAllowedKindsEnvVar, "no kind is allowed by default")
+}
+
+func TestKubernetesResourceGetMetricSpecForScaling(t *testing.T) {
+	t.Setenv(kedautil.KubernetesResourceAllowedKindsEnvVar, testKubernetesResourceAllowedKinds)
+	scaler, err := NewKubernetesResourceScaler(fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{TriggerMetadata: testKubernetesResourceMetadata[3].metadata, ScalableObjectNamespace: "test", TriggerIndex: 2})
+	assert.NoError(t, err)
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s2-resource-pipelinerun", metricSpec[0].External.Metric.Name)
+}
+
+func TestKubernetesResourceReader(t *testing.T) {
+	t.Setenv(kedautil.KubernetesResourceAllowedKindsEnvVar, testKubernetesResourceAllowedKinds)
+	cache := fake.NewClientBuilder().WithObjects(
+		newKubernetesResourcePod("test", "worker-1", nil, true),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "credentials"}},
+	).Build()
+	KubernetesResourceReader = NewKubernetesResourceAllowedReader(cache)
+	defer func() { KubernetesResourceReader = nil }()
+
+	// the objects are read from the cache, not with the client of the scale handler
+	scaler, err := NewKubernetesResourceScaler(fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{TriggerMetadata: testKubernetesResourceMetadata[1].metadata, ScalableObjectNamespace: "test"})
+	assert.NoError(t, err)
+	metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), "resource")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), metrics[0].Value.Value())
+
+	secrets := &unstructured.UnstructuredList{}
+	secrets.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "SecretList"})
+	assert.ErrorContains(t, KubernetesResourceReader.List(context.Background(), secrets, client.InNamespace("test")), kedautil.KubernetesResourceAllowedKindsEnvVar)
+	secret := &unstructured.Unstructured{}
+	secret.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Secret"})
+	assert.ErrorContains(t, KubernetesResourceReader.Get(context.Background(), client.ObjectKey{Namespace: "test", Name: "credentials"}, secret), kedautil.KubernetesResourceAllowedKindsEnvVar)
+}
+
+func newKubernetesResourceObject(apiVersion, kind, namespace, name string, labels map[string]string, fields map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: fields}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	object.SetLabels(labels)
+	return object
+}
+
+func newKubernetesResourcePod(namespace, name string, labels map[string]string, unschedulable bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if unschedulable {
+		pod.Status.Phase = corev1.PodPending
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}}
+	}
+	return pod
+}
+
+func TestKubernetesResourceGetMetricsAndActivity(t *testing.T) {
+	t.Setenv(kedautil.KubernetesResourceAllowedKindsEnvVar, testKubernetesResourceAllowedKinds)
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	for _, gvk := range []schema.GroupVersionKind{
+		{Group: "tekton.dev", Version: "v1", Kind: "PipelineRun"},
+		{Group: "example.com", Version: "v1", Kind: "Queue"},
+	} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+
+	objects := []client.Object{
+		newKubernetesResourcePod("test", "worker-1", map[string]string{"app": "worker"}, true),
+		newKubernetesResourcePod("test", "worker-2", map[string]string{"app": "worker"}, true),
+		newKubernetesResourcePod("test", "worker-3", map[string]string{"app": "worker"}, false),
+		newKubernetesResourcePod("test", "api-1", map[string]string{"app": "api"}, true),
+		newKubernetesResourcePod("other", "worker-1", map[string]string{"app": "worker"}, true),
+		newKubernetesResourceObject("tekton.dev/v1", "PipelineRun", "test", "build-1", nil, map[string]interface{}{"spec": map[string]interface{}{"status": "PipelineRunPending"}}),
+		newKubernetesResourceObject("tekton.dev/v1", "PipelineRun", "test", "build-2", nil, map[string]interface{}{"spec": map[string]interface{}{"status": "PipelineRunPending"}}),
+		newKubernetesResourceObject("tekton.dev/v1", "PipelineRun", "test", "build-3", nil, map[string]interface{}{"spec": map[string]interface{}{}}),
+		newKubernetesResourceObject("example.com/v1", "Queue", "test", "orders", nil, map[string]interface{}{"status": map[string]interface{}{"queueDepth": int64(40), "partitions": []interface{}{int64(10), int64(30)}}}),
+		newKubernetesResourceObject("example.com/v1", "Queue", "test", "payments", nil, map[string]interface{}{"status": map[string]interface{}{"queueDepth": 2.5}}),
+		newKubernetesResourceObject("example.com/v1", "Queue", "test", "refunds", nil, map[string]interface{}{}),
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	testData := []struct {
+		name     string
+		metadata map[string]string
+		value    float64
+		isActive bool
+		isError  bool
+	}{
+		{name: "count of the pods", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod"}, value: 4, isActive: true},
+		{name: "count of the pods with a label", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "labelSelector": "app=worker"}, value: 3, isActive: true},
+		{name: "unschedulable pods with a label", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "labelSelector": "app=worker", "celExpression": "has(object.status.conditions) && object.status.conditions.exists(c, c.type == 'PodScheduled' && c.reason == 'Unschedulable')"}, value: 2, isActive: true},
+		{name: "pending PipelineRuns", metadata: map[string]string{"apiVersion": "tekton.dev/v1", "kind": "PipelineRun", "celExpression": "has(object.spec.status) && object.spec.status == 'PipelineRunPending'", "activationValue": "2"}, value: 2},
+		{name: "queueDepth with jsonPath", metadata: map[string]string{"apiVersion": "example.com/v1", "kind": "Queue", "jsonPath": "{.status.queueDepth}"}, value: 42.5, isActive: true},
+		{name: "list selected with jsonPath", metadata: map[string]string{"apiVersion": "example.com/v1", "kind": "Queue", "jsonPath": "{.status.partitions[*]}"}, value: 40, isActive: true},
+		{name: "queueDepth with celExpression", metadata: map[string]string{"apiVersion": "example.com/v1", "kind": "Queue", "celExpression": "has(object.status) && has(object.status.queueDepth) ? object.status.queueDepth : 0"}, value: 42.5, isActive: true},
+		{name: "celExpression failing on an object", metadata: map[string]string{"apiVersion": "example.com/v1", "kind": "Queue", "celExpression": "object.status.queueDepth"}, isError: true},
+		{name: "celExpression returning a string", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "celExpression": "object.metadata.name"}, isError: true},
+		{name: "jsonPath selecting a string", metadata: map[string]string{"apiVersion": "v1", "kind": "Pod", "jsonPath": "{.status.phase}"}, isError: true},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.metadata["value"] = "1"
+			meta, err := parseKubernetesResourceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tt.metadata, ScalableObjectNamespace: "test"})
+			assert.NoError(t, err)
+			scaler := kubernetesResourceScaler{metadata: meta, reader: kubeClient, logger: logr.Discard()}
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "resource")
+			if tt.isError {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			assert.Equal(t, int64(tt.value*1000), metrics[0].Value.MilliValue())
+			assert.Equal(t, tt.isActive, active)
+		})
+	}
+}
@@ -80,6 +80,16 @@ func NewLokiScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
 
+	if meta.lokiAuth != nil && meta.lokiAuth.EnableServiceAccountTokenAuth {
+		// create http.RoundTripper adding the ServiceAccount token to the requests
+		transport, err := authentication.CreateHTTPRoundTripper(authentication.NetHTTP, meta.lokiAuth)
+		if err != nil {
+			logger.V(1).Error(err, "init Loki client http transport")
+			return nil, err
+		}
+		httpClient.Transport = transport
+	}
+
 	return &lokiScaler{
 		metricType: metricType,
 		metadata:   meta,
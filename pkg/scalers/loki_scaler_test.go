@@ -53,6 +53,10 @@ var testLokiAuthMetadata = []lokiAuthMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "bearer"}, map[string]string{"bearerToken": "dummy-token"}, false},
 	// fail bearerAuth with no token
 	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "bearer"}, map[string]string{}, true},
+	// success serviceAccountToken
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "serviceAccountToken"}, map[string]string{"serviceAccountToken": "dummy-token"}, false},
+	// fail serviceAccountToken with no token
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "serviceAccountToken"}, map[string]string{}, true},
 	// success basicAuth
 	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "basic"}, map[string]string{"username": "user", "password": "pass"}, false},
 	// fail basicAuth with no username
@@ -236,6 +236,13 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 
 		meta.bearerToken = config.AuthParams["token"]
 		meta.enableBearerAuth = true
+	case authentication.ServiceAccountTokenAuthType:
+		if len(config.AuthParams["serviceAccountToken"]) == 0 {
+			return nil, errors.New("no service account token provided, serviceAccountToken has to be configured in the TriggerAuthentication")
+		}
+
+		meta.bearerToken = config.AuthParams["serviceAccountToken"]
+		meta.enableBearerAuth = true
 	default:
 		return nil, fmt.Errorf("err incorrect value for authMode is given: %s", authMode)
 	}
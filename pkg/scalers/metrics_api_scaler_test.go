@@ -69,6 +69,10 @@ var testMetricsAPIAuthMetadata = []metricAPIAuthMetadataTestData{
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer"}, map[string]string{"token": "bearerTokenValue"}, false},
 	// fail bearerAuth without token
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer"}, map[string]string{}, true},
+	// success serviceAccountToken
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "serviceAccountToken"}, map[string]string{"serviceAccountToken": "saTokenValue"}, false},
+	// fail serviceAccountToken without token
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "serviceAccountToken"}, map[string]string{}, true},
 	// success unsafeSsl true
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "unsafeSsl": "true"}, map[string]string{}, false},
 	// success unsafeSsl false
@@ -173,7 +177,7 @@ func TestMetricAPIScalerAuthParams(t *testing.T) {
 			if (meta.enableAPIKeyAuth && !(testData.metadata["authMode"] == "apiKey")) ||
 				(meta.enableBaseAuth && !(testData.metadata["authMode"] == "basic")) ||
 				(meta.enableTLS && !(testData.metadata["authMode"] == "tls")) ||
-				(meta.enableBearerAuth && !(testData.metadata["authMode"] == "bearer")) {
+				(meta.enableBearerAuth && !(testData.metadata["authMode"] == "bearer" || testData.metadata["authMode"] == "serviceAccountToken")) {
 				t.Error("wrong auth mode detected")
 			}
 		}
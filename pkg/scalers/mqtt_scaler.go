@@ -0,0 +1,375 @@
+package scalers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/go-logr/logr"
+	"github.com/tidwall/gjson"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	mqttModeSys  = "sys"
+	mqttModeHTTP = "http"
+
+	mqttDefaultQueueLength = 10
+	// mqttDefaultSysTopic is the number of messages stored by Mosquitto, including the queued messages of offline subscriptions.
+	// It's a broker-wide value that isn't specific to any topic or subscription
+	mqttDefaultSysTopic = "$SYS/broker/store/messages/count"
+)
+
+type mqttScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *mqttMetadata
+	httpClient *http.Client
+	timeout    time.Duration
+	logger     logr.Logger
+}
+
+type mqttMetadata struct {
+	mode string
+
+	// sys mode reads a value the broker publishes on a $SYS topic, which is broker-wide
+	// so it can't be scoped to a topic or a share group
+	host     string
+	sysTopic string
+
+	// http mode reads the queued messages of the topic, or of the shared subscription of the share group
+	managementURL string
+	valueLocation string
+	topic         string
+	shareGroup    string
+
+	queueLength           float64
+	activationQueueLength float64
+
+	username  string
+	password  string
+	ca        string
+	cert      string
+	key       string
+	unsafeSsl bool
+
+	triggerIndex int
+}
+
+// NewMQTTScaler creates a new mqttScaler
+func NewMQTTScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseMQTTMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing mqtt metadata: %w", err)
+	}
+
+	s := &mqttScaler{
+		metricType: metricType,
+		metadata:   meta,
+		timeout:    config.GlobalHTTPTimeout,
+		logger:     InitializeLogger(config, "mqtt_scaler"),
+	}
+	if meta.mode == mqttModeHTTP {
+		s.httpClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+		if meta.ca != "" || meta.cert != "" {
+			tlsConfig, err := kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca, meta.unsafeSsl)
+			if err != nil {
+				return nil, err
+			}
+			s.httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+		}
+	}
+	return s, nil
+}
+
+func parseMQTTMetadata(config *scalersconfig.ScalerConfig) (*mqttMetadata, error) {
+	meta := mqttMetadata{}
+
+	meta.mode = mqttModeSys
+	if val, ok := config.TriggerMetadata["mode"]; ok && val != "" {
+		meta.mode = val
+	}
+
+	switch meta.mode {
+	case mqttModeSys:
+		host, err := GetFromAuthOrMeta(config, "host")
+		if err != nil {
+			return nil, err
+		}
+		meta.host = host
+
+		meta.sysTopic = mqttDefaultSysTopic
+		if val, ok := config.TriggerMetadata["sysTopic"]; ok && val != "" {
+			meta.sysTopic = val
+		}
+	case mqttModeHTTP:
+		managementURL, err := GetFromAuthOrMeta(config, "managementURL")
+		if err != nil {
+			return nil, err
+		}
+		meta.managementURL = managementURL
+
+		if val, ok := config.TriggerMetadata["valueLocation"]; ok && val != "" {
+			meta.valueLocation = val
+		} else {
+			return nil, fmt.Errorf("no valueLocation given")
+		}
+	default:
+		return nil, fmt.Errorf("mode must be one of %s or %s, got %s", mqttModeSys, mqttModeHTTP, meta.mode)
+	}
+
+	if meta.mode == mqttModeSys {
+		// the value of the $SYS topic counts the messages of the whole broker, a topic would suggest otherwise
+		if config.TriggerMetadata["topic"] != "" || config.TriggerMetadata["shareGroup"] != "" {
+			return nil, fmt.Errorf("topic and shareGroup aren't supported in %s mode as %s is a broker-wide value, use %s mode to scale on a topic", mqttModeSys, meta.sysTopic, mqttModeHTTP)
+		}
+	} else {
+		if val, ok := config.TriggerMetadata["topic"]; ok && val != "" {
+			meta.topic = val
+		} else {
+			return nil, fmt.Errorf("no topic given")
+		}
+
+		if val, ok := config.TriggerMetadata["shareGroup"]; ok && val != "" {
+			if strings.ContainsAny(val, "/+#") {
+				return nil, fmt.Errorf("shareGroup can't contain /, + or # characters")
+			}
+			meta.shareGroup = val
+		}
+	}
+
+	meta.queueLength = mqttDefaultQueueLength
+	if val, ok := config.TriggerMetadata["queueLength"]; ok && val != "" {
+		queueLength, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing queueLength: %w", err)
+		}
+		meta.queueLength = queueLength
+	}
+
+	meta.activationQueueLength = 0
+	if val, ok := config.TriggerMetadata["activationQueueLength"]; ok && val != "" {
+		activationQueueLength, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationQueueLength: %w", err)
+		}
+		meta.activationQueueLength = activationQueueLength
+	}
+
+	meta.username = config.AuthParams["username"]
+	meta.password = config.AuthParams["password"]
+	if meta.password != "" && meta.username == "" {
+		return nil, fmt.Errorf("username is required when password is given")
+	}
+
+	meta.ca = config.AuthParams["ca"]
+	meta.cert = config.AuthParams["cert"]
+	meta.key = config.AuthParams["key"]
+	if (meta.cert == "") != (meta.key == "") {
+		return nil, fmt.Errorf("both cert and key must be provided for client authentication")
+	}
+
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
+		unsafeSslValue, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+		}
+		meta.unsafeSsl = unsafeSslValue
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return &meta, nil
+}
+
+// subscription returns the shared subscription of the share group, or the topic filter when there isn't any share group.
+// In sys mode it's the $SYS topic the value is read from
+func (m *mqttMetadata) subscription() string {
+	if m.mode == mqttModeSys {
+		return m.sysTopic
+	}
+	if m.shareGroup == "" {
+		return m.topic
+	}
+	return fmt.Sprintf("$share/%s/%s", m.shareGroup, m.topic)
+}
+
+// Close closes the idle connections to the management endpoint, broker connections only live for a single read
+func (s *mqttScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *mqttScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("mqtt-%s", s.metadata.subscription()))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.queueLength),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of queued messages and whether it's above the activation queue length
+func (s *mqttScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var queueLength float64
+	var err error
+	if s.metadata.mode == mqttModeHTTP {
+		queueLength, err = s.getQueueLengthFromManagementAPI(ctx)
+	} else {
+		queueLength, err = s.getQueueLengthFromSysTopic(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting queued messages of %s: %w", s.metadata.subscription(), err)
+	}
+
+	metric := GenerateMetricInMili(metricName, queueLength)
+	return []external_metrics.ExternalMetricValue{metric}, queueLength > s.metadata.activationQueueLength, nil
+}
+
+// getQueueLengthFromSysTopic reads the value the broker publishes on the $SYS topic,
+// $SYS topics are retained so the current value is received right after subscribing.
+// The whole read is bounded by the global HTTP timeout, like the requests of http mode
+func (s *mqttScaler) getQueueLengthFromSysTopic(ctx context.Context) (float64, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	// the connection only lives for a single read, so it is neither kept alive nor reconnected
+	options := mqtt.NewClientOptions().
+		AddBroker(s.metadata.host).
+		SetClientID(mqttClientID()).
+		SetUsername(s.metadata.username).
+		SetPassword(s.metadata.password).
+		SetCleanSession(true).
+		SetKeepAlive(0).
+		SetAutoReconnect(false)
+	if s.timeout > 0 {
+		options.SetConnectTimeout(s.timeout)
+	}
+	if s.metadata.ca != "" || s.metadata.cert != "" || s.metadata.unsafeSsl {
+		tlsConfig, err := kedautil.NewTLSConfig(s.metadata.cert, s.metadata.key, s.metadata.ca, s.metadata.unsafeSsl)
+		if err != nil {
+			return -1, err
+		}
+		options.SetTLSConfig(tlsConfig)
+	}
+
+	client := mqtt.NewClient(options)
+	if err := waitMQTTToken(ctx, client.Connect()); err != nil {
+		return -1, fmt.Errorf("error connecting to broker: %w", err)
+	}
+	defer client.Disconnect(0)
+
+	messages := make(chan mqtt.Message, 1)
+	token := client.Subscribe(s.metadata.sysTopic, 0, func(_ mqtt.Client, message mqtt.Message) {
+		select {
+		case messages <- message:
+		default:
+		}
+	})
+	if err := waitMQTTToken(ctx, token); err != nil {
+		return -1, fmt.Errorf("error subscribing to %s: %w", s.metadata.sysTopic, err)
+	}
+	if qos, ok := token.(*mqtt.SubscribeToken).Result()[s.metadata.sysTopic]; !ok || qos >= 0x80 {
+		return -1, fmt.Errorf("broker refused the subscription to %s", s.metadata.sysTopic)
+	}
+
+	var message mqtt.Message
+	select {
+	case message = <-messages:
+	case <-ctx.Done():
+		return -1, fmt.Errorf("error reading %s: %w", s.metadata.sysTopic, ctx.Err())
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(message.Payload())), 64)
+	if err != nil {
+		return -1, fmt.Errorf("error parsing %s value %q: %w", s.metadata.sysTopic, string(message.Payload()), err)
+	}
+	return value, nil
+}
+
+// waitMQTTToken waits for the operation of the token to complete or for the context to be done
+func waitMQTTToken(ctx context.Context, token mqtt.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// getQueueLengthFromManagementAPI reads the value at valueLocation in the response of the management endpoint,
+// the {topic}, {shareGroup} and {subscription} placeholders of the URL are replaced with their escaped values
+func (s *mqttScaler) getQueueLengthFromManagementAPI(ctx context.Context) (float64, error) {
+	managementURL := strings.NewReplacer(
+		"{topic}", url.QueryEscape(s.metadata.topic),
+		"{shareGroup}", url.QueryEscape(s.metadata.shareGroup),
+		"{subscription}", url.QueryEscape(s.metadata.subscription()),
+	).Replace(s.metadata.managementURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, managementURL, nil)
+	if err != nil {
+		return -1, err
+	}
+	if s.metadata.username != "" {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return -1, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("management endpoint returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	r := gjson.GetBytes(body, s.metadata.valueLocation)
+	switch r.Type {
+	case gjson.Number:
+		return r.Num, nil
+	case gjson.String:
+		value, err := strconv.ParseFloat(r.Str, 64)
+		if err != nil {
+			return -1, fmt.Errorf("value at %s isn't a number: %s", s.metadata.valueLocation, r.Str)
+		}
+		return value, nil
+	default:
+		return -1, fmt.Errorf("no number found at %s in the management endpoint response", s.metadata.valueLocation)
+	}
+}
+
+// mqttClientID returns a unique client ID, so concurrent reads don't take over each other's connection
+func mqttClientID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "keda-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,289 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseMQTTMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type mqttMetricIdentifier struct {
+	metadataTestData *parseMQTTMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testMQTTMetadata = []parseMQTTMetadataTestData{
+	{"nothing passed", map[string]string{}, map[string]string{}, true},
+	{"sys mode", map[string]string{"host": "tcp://localhost:1883"}, map[string]string{}, false},
+	{"sys mode with host in auth params", map[string]string{}, map[string]string{"host": "ssl://localhost:8883"}, false},
+	{"sys mode with custom topic", map[string]string{"host": "tcp://localhost:1883", "sysTopic": "$SYS/broker/clients/connected"}, map[string]string{}, false},
+	{"sys mode without host", map[string]string{}, map[string]string{}, true},
+	{"shared subscription", map[string]string{"mode": "http", "managementURL": "http://localhost:18083/api/v5/subscriptions?topic={subscription}", "valueLocation": "data.0.mqueue_len", "topic": "sensors/#", "shareGroup": "ingestion"}, map[string]string{}, false},
+	{"invalid share group", map[string]string{"mode": "http", "managementURL": "http://localhost:18083/api/v5/subscriptions?topic={subscription}", "valueLocation": "data.0.mqueue_len", "topic": "sensors/#", "shareGroup": "ingestion/1"}, map[string]string{}, true},
+	{"sys mode with topic", map[string]string{"host": "tcp://localhost:1883", "topic": "sensors/#"}, map[string]string{}, true},
+	{"sys mode with share group", map[string]string{"host": "tcp://localhost:1883", "shareGroup": "ingestion"}, map[string]string{}, true},
+	{"http mode", map[string]string{"mode": "http", "managementURL": "http://localhost:18083/api/v5/subscriptions?topic={topic}", "valueLocation": "data.0.mqueue_len", "topic": "sensors/#"}, map[string]string{}, false},
+	{"http mode without topic", map[string]string{"mode": "http", "managementURL": "http://localhost:18083/api/v5/subscriptions?topic={topic}", "valueLocation": "data.0.mqueue_len"}, map[string]string{}, true},
+	{"http mode without valueLocation", map[string]string{"mode": "http", "managementURL": "http://localhost:18083/api/v5/subscriptions", "topic": "sensors/#"}, map[string]string{}, true},
+	{"http mode without managementURL", map[string]string{"mode": "http", "valueLocation": "data.0.mqueue_len", "topic": "sensors/#"}, map[string]string{}, true},
+	{"invalid mode", map[string]string{"mode": "amqp", "host": "tcp://localhost:1883", "topic": "sensors/#"}, map[string]string{}, true},
+	{"queue lengths", map[string]string{"host": "tcp://localhost:1883", "queueLength": "50", "activationQueueLength": "5"}, map[string]string{}, false},
+	{"invalid queueLength", map[string]string{"host": "tcp://localhost:1883", "queueLength": "a"}, map[string]string{}, true},
+	{"invalid activationQueueLength", map[string]string{"host": "tcp://localhost:1883", "activationQueueLength": "a"}, map[string]string{}, true},
+	{"username and password", map[string]string{"host": "tcp://localhost:1883"}, map[string]string{"username": "keda", "password": "secret"}, false},
+	{"password without username", map[string]string{"host": "tcp://localhost:1883"}, map[string]string{"password": "secret"}, true},
+	{"cert without key", map[string]string{"host": "ssl://localhost:8883"}, map[string]string{"cert": "cert"}, true},
+	{"invalid unsafeSsl", map[string]string{"host": "ssl://localhost:8883", "unsafeSsl": "yes"}, map[string]string{}, true},
+}
+
+var mqttMetricIdentifiers = []mqttMetricIdentifier{
+	{&testMQTTMetadata[1], 0, "s0-mqtt-$SYS-broker-store-messages-count"},
+	{&testMQTTMetadata[5], 1, "s1-mqtt-$share-ingestion-sensors-#"},
+	{&testMQTTMetadata[9], 2, "s2-mqtt-sensors-#"},
+}
+
+func TestMQTTParseMetadata(t *testing.T) {
+	for _, testData := range testMQTTMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestMQTTGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range mqttMetricIdentifiers {
+		meta, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockMQTTScaler := mqttScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockMQTTScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName, "expected:", testData.name)
+		}
+	}
+}
+
+// testMQTTBroker is a stand-in for an MQTT broker publishing retained values on $SYS topics
+type testMQTTBroker struct {
+	listener net.Listener
+	username string
+	password string
+	retained map[string]string
+}
+
+func newTestMQTTBroker(t *testing.T, username, password string, retained map[string]string) *testMQTTBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker := &testMQTTBroker{listener: listener, username: username, password: password, retained: retained}
+	go broker.serve()
+	t.Cleanup(func() { listener.Close() })
+	return broker
+}
+
+func (b *testMQTTBroker) url() string {
+	return "tcp://" + b.listener.Addr().String()
+}
+
+func (b *testMQTTBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func (b *testMQTTBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		switch p := packet.(type) {
+		case *packets.ConnectPacket:
+			connack := packets.NewControlPacket(packets.Connack).(*packets.ConnackPacket)
+			if p.Username != b.username || string(p.Password) != b.password {
+				connack.ReturnCode = packets.ErrRefusedBadUsernameOrPassword
+			}
+			_ = connack.Write(conn)
+		case *packets.SubscribePacket:
+			suback := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+			suback.MessageID = p.MessageID
+			value, ok := b.retained[p.Topics[0]]
+			if ok {
+				suback.ReturnCodes = []byte{0}
+			} else {
+				// failure
+				suback.ReturnCodes = []byte{0x80}
+			}
+			_ = suback.Write(conn)
+			// topics without value are never published, like a broker that stopped publishing its $SYS topics
+			if ok && value != "" {
+				publish := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+				publish.TopicName = p.Topics[0]
+				publish.Retain = true
+				publish.Payload = []byte(value)
+				_ = publish.Write(conn)
+			}
+		case *packets.PingreqPacket:
+			_ = packets.NewControlPacket(packets.Pingresp).Write(conn)
+		case *packets.DisconnectPacket:
+			return
+		}
+	}
+}
+
+func TestMQTTGetMetricsFromSysTopic(t *testing.T) {
+	broker := newTestMQTTBroker(t, "keda", "secret", map[string]string{
+		mqttDefaultSysTopic:           "42",
+		"$SYS/broker/custom":          "3",
+		"$SYS/broker/invalid-payload": "many",
+		"$SYS/broker/silent":          "",
+	})
+
+	tests := []struct {
+		name       string
+		metadata   map[string]string
+		authParams map[string]string
+		expected   int64
+		active     bool
+		isError    bool
+	}{
+		{
+			name:       "default topic",
+			metadata:   map[string]string{},
+			authParams: map[string]string{"username": "keda", "password": "secret"},
+			expected:   42,
+			active:     true,
+		},
+		{
+			name:       "custom topic under activation",
+			metadata:   map[string]string{"sysTopic": "$SYS/broker/custom", "activationQueueLength": "5"},
+			authParams: map[string]string{"username": "keda", "password": "secret"},
+			expected:   3,
+			active:     false,
+		},
+		{
+			name:       "wrong credentials",
+			metadata:   map[string]string{},
+			authParams: map[string]string{"username": "keda", "password": "wrong"},
+			isError:    true,
+		},
+		{
+			name:       "unknown topic",
+			metadata:   map[string]string{"sysTopic": "$SYS/broker/unknown"},
+			authParams: map[string]string{"username": "keda", "password": "secret"},
+			isError:    true,
+		},
+		{
+			name:       "invalid payload",
+			metadata:   map[string]string{"sysTopic": "$SYS/broker/invalid-payload"},
+			authParams: map[string]string{"username": "keda", "password": "secret"},
+			isError:    true,
+		},
+		{
+			name:       "topic never published",
+			metadata:   map[string]string{"sysTopic": "$SYS/broker/silent"},
+			authParams: map[string]string{"username": "keda", "password": "secret"},
+			isError:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["host"] = broker.url()
+			scaler, err := NewMQTTScaler(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata, AuthParams: test.authParams, GlobalHTTPTimeout: time.Second})
+			assert.NoError(t, err)
+
+			// the read is bounded by the global HTTP timeout even without a context deadline
+			start := time.Now()
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "mqtt")
+			assert.Less(t, time.Since(start), 5*time.Second)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, metrics[0].Value.Value())
+			assert.Equal(t, test.active, active)
+		})
+	}
+}
+
+func TestMQTTGetMetricsFromManagementAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "key" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("topic") != "$share/ingestion/sensors/#" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"data":[{"topic":"$share/ingestion/sensors/#","mqueue_len":17}]}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		authParams map[string]string
+		expected   int64
+		isError    bool
+	}{
+		{name: "queued messages", authParams: map[string]string{"username": "key", "password": "secret"}, expected: 17},
+		{name: "unauthorized", authParams: map[string]string{"username": "key", "password": "wrong"}, isError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := map[string]string{
+				"mode":          "http",
+				"managementURL": server.URL + "/api/v5/subscriptions?topic={subscription}",
+				"valueLocation": "data.0.mqueue_len",
+				"topic":         "sensors/#",
+				"shareGroup":    "ingestion",
+			}
+			scaler, err := NewMQTTScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: test.authParams, GlobalHTTPTimeout: time.Second})
+			assert.NoError(t, err)
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "mqtt")
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, metrics[0].Value.Value())
+			assert.True(t, active)
+		})
+	}
+}
@@ -99,14 +99,14 @@ var testMssqlMetadata = []mssqlTestData{
 		metadata:      map[string]string{"targetValue": "1"},
 		resolvedEnv:   map[string]string{},
 		authParams:    map[string]string{"connectionString": "sqlserver://localhost"},
-		expectedError: ErrMsSQLNoQuery,
+		expectedError: ErrSQLNoQuery,
 	},
 	// Error: missing targetValue
 	{
 		metadata:      map[string]string{"query": "SELECT 1"},
 		resolvedEnv:   map[string]string{},
 		authParams:    map[string]string{"connectionString": "sqlserver://localhost"},
-		expectedError: ErrMsSQLNoTargetValue,
+		expectedError: ErrSQLNoTargetValue,
 	},
 	// Error: missing host
 	{
@@ -130,7 +130,7 @@ func TestMSSQLMetadataParsing(t *testing.T) {
 			AuthParams:      testData.authParams,
 		}
 
-		outputMetadata, err := parseMSSQLMetadata(&config)
+		outputMetadata, err := parseSQLMetadata("mssql", &config)
 		if err != nil {
 			if testData.expectedError == nil {
 				t.Errorf("Unexpected error parsing input metadata: %v", err)
@@ -147,13 +147,12 @@ func TestMSSQLMetadataParsing(t *testing.T) {
 		}
 
 		var expectedTargetValue float64 = 1
-		if outputMetadata.targetValue != expectedTargetValue {
-			t.Errorf("Wrong targetValue. Expected %f but got %f", expectedTargetValue, outputMetadata.targetValue)
+		if outputMetadata.targetQueryValue != expectedTargetValue {
+			t.Errorf("Wrong targetValue. Expected %f but got %f", expectedTargetValue, outputMetadata.targetQueryValue)
 		}
 
-		outputConnectionString := getMSSQLConnectionString(outputMetadata)
-		if testData.expectedConnectionString != outputConnectionString {
-			t.Errorf("Wrong connection string. Expected '%s' but got '%s'", testData.expectedConnectionString, outputConnectionString)
+		if testData.expectedConnectionString != outputMetadata.connectionString {
+			t.Errorf("Wrong connection string. Expected '%s' but got '%s'", testData.expectedConnectionString, outputMetadata.connectionString)
 		}
 	}
 }
@@ -167,12 +166,12 @@ func TestMSSQLGetMetricSpecForScaling(t *testing.T) {
 			AuthParams:      testData.metadataTestData.authParams,
 			TriggerIndex:    testData.triggerIndex,
 		}
-		meta, err := parseMSSQLMetadata(&config)
+		meta, err := parseSQLMetadata("mssql", &config)
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
 
-		mockMssqlScaler := mssqlScaler{
+		mockMssqlScaler := sqlScaler{
 			metadata: meta,
 		}
 		metricSpec := mockMssqlScaler.GetMetricSpecForScaling(ctx)
@@ -1,100 +1,30 @@
 package scalers
 
 import (
-	"context"
-	"database/sql"
 	"fmt"
 	"net"
-	"strconv"
 	"strings"
 
-	"github.com/go-logr/logr"
 	"github.com/go-sql-driver/mysql"
-	v2 "k8s.io/api/autoscaling/v2"
-	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
-	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-type mySQLScaler struct {
-	metricType v2.MetricTargetType
-	metadata   *mySQLMetadata
-	connection *sql.DB
-	logger     logr.Logger
-}
+// The mysql trigger is an alias of the sql trigger with the mysql driver, see sql_scaler.go
 
+// mySQLMetadata holds the parameters the MySQL connection string is built from
 type mySQLMetadata struct {
-	connectionString     string // Database connection string
-	username             string
-	password             string
-	host                 string
-	port                 string
-	dbName               string
-	query                string
-	queryValue           float64
-	activationQueryValue float64
-	metricName           string
-}
-
-// NewMySQLScaler creates a new MySQL scaler
-func NewMySQLScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
-	metricType, err := GetMetricTargetType(config)
-	if err != nil {
-		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
-	}
-
-	logger := InitializeLogger(config, "mysql_scaler")
-
-	meta, err := parseMySQLMetadata(config)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing MySQL metadata: %w", err)
-	}
-
-	conn, err := newMySQLConnection(meta, logger)
-	if err != nil {
-		return nil, fmt.Errorf("error establishing MySQL connection: %w", err)
-	}
-	return &mySQLScaler{
-		metricType: metricType,
-		metadata:   meta,
-		connection: conn,
-		logger:     logger,
-	}, nil
+	connectionString string // Database connection string
+	username         string
+	password         string
+	host             string
+	port             string
+	dbName           string
 }
 
-func parseMySQLMetadata(config *scalersconfig.ScalerConfig) (*mySQLMetadata, error) {
-	meta := mySQLMetadata{}
-
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no query given")
-	}
-
-	if val, ok := config.TriggerMetadata["queryValue"]; ok {
-		queryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("queryValue parsing error %w", err)
-		}
-		meta.queryValue = queryValue
-	} else {
-		if config.AsMetricSource {
-			meta.queryValue = 0
-		} else {
-			return nil, fmt.Errorf("no queryValue given")
-		}
-	}
-
-	meta.activationQueryValue = 0
-	if val, ok := config.TriggerMetadata["activationQueryValue"]; ok {
-		activationQueryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("activationQueryValue parsing error %w", err)
-		}
-		meta.activationQueryValue = activationQueryValue
-	}
-
+// parseMySQLConnection sets the connection string given in the auth params or the env,
+// or the host, port, username, dbName and password parameters to build it from
+func parseMySQLConnection(config *scalersconfig.ScalerConfig, meta *mySQLMetadata) error {
 	switch {
 	case config.AuthParams["connectionString"] != "":
 		meta.connectionString = config.AuthParams["connectionString"]
@@ -105,25 +35,25 @@ func parseMySQLMetadata(config *scalersconfig.ScalerConfig) (*mySQLMetadata, err
 		var err error
 		host, err := GetFromAuthOrMeta(config, "host")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		meta.host = host
 
 		port, err := GetFromAuthOrMeta(config, "port")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		meta.port = port
 
 		username, err := GetFromAuthOrMeta(config, "username")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		meta.username = username
 
 		dbName, err := GetFromAuthOrMeta(config, "dbName")
 		if err != nil {
-			return nil, err
+			return err
 		}
 		meta.dbName = dbName
 
@@ -134,16 +64,10 @@ func parseMySQLMetadata(config *scalersconfig.ScalerConfig) (*mySQLMetadata, err
 		}
 
 		if len(meta.password) == 0 {
-			return nil, fmt.Errorf("no password given")
+			return fmt.Errorf("no password given")
 		}
 	}
-
-	if meta.connectionString != "" {
-		meta.dbName = parseMySQLDbNameFromConnectionStr(meta.connectionString)
-	}
-	meta.metricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("mysql-%s", meta.dbName)))
-
-	return &meta, nil
+	return nil
 }
 
 // metadataToConnectionStr builds new MySQL connection string
@@ -165,22 +89,6 @@ func metadataToConnectionStr(meta *mySQLMetadata) string {
 	return connStr
 }
 
-// newMySQLConnection creates MySQL db connection
-func newMySQLConnection(meta *mySQLMetadata, logger logr.Logger) (*sql.DB, error) {
-	connStr := metadataToConnectionStr(meta)
-	db, err := sql.Open("mysql", connStr)
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Found error when opening connection: %s", err))
-		return nil, err
-	}
-	err = db.Ping()
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Found error when pinging database: %s", err))
-		return nil, err
-	}
-	return db, nil
-}
-
 // parseMySQLDbNameFromConnectionStr returns dbname from connection string
 // in it is not able to parse it, it returns "dbname" string
 func parseMySQLDbNameFromConnectionStr(connectionString string) string {
@@ -191,50 +99,3 @@ func parseMySQLDbNameFromConnectionStr(connectionString string) string {
 	}
 	return "dbname"
 }
-
-// Close disposes of MySQL connections
-func (s *mySQLScaler) Close(context.Context) error {
-	err := s.connection.Close()
-	if err != nil {
-		s.logger.Error(err, "Error closing MySQL connection")
-		return err
-	}
-	return nil
-}
-
-// getQueryResult returns result of the scaler query
-func (s *mySQLScaler) getQueryResult(ctx context.Context) (float64, error) {
-	var value float64
-	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&value)
-	if err != nil {
-		s.logger.Error(err, fmt.Sprintf("Could not query MySQL database: %s", err))
-		return 0, err
-	}
-	return value, nil
-}
-
-// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *mySQLScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	externalMetric := &v2.ExternalMetricSource{
-		Metric: v2.MetricIdentifier{
-			Name: s.metadata.metricName,
-		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.queryValue),
-	}
-	metricSpec := v2.MetricSpec{
-		External: externalMetric, Type: externalMetricType,
-	}
-	return []v2.MetricSpec{metricSpec}
-}
-
-// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
-func (s *mySQLScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	num, err := s.getQueryResult(ctx)
-	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting MySQL: %w", err)
-	}
-
-	metric := GenerateMetricInMili(metricName, num)
-
-	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationQueryValue, nil
-}
@@ -69,7 +69,7 @@ var mySQLMetricIdentifiers = []mySQLMetricIdentifier{
 
 func TestParseMySQLMetadata(t *testing.T) {
 	for _, testData := range testMySQLMetadata {
-		_, err := parseMySQLMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		_, err := parseSQLMetadata("mysql", &scalersconfig.ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
 		if err != nil && !testData.raisesError {
 			t.Error("Expected success but got error", err)
 		}
@@ -82,9 +82,8 @@ func TestParseMySQLMetadata(t *testing.T) {
 func TestMetadataToConnectionStrUseConnStr(t *testing.T) {
 	// Use existing ConnStr
 	testMeta := map[string]string{"query": "query", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"}
-	meta, _ := parseMySQLMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testMeta, AuthParams: map[string]string{}})
-	connStr := metadataToConnectionStr(meta)
-	if connStr != testMySQLResolvedEnv["MYSQL_CONN_STR"] {
+	meta, _ := parseSQLMetadata("mysql", &scalersconfig.ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testMeta, AuthParams: map[string]string{}})
+	if meta.connectionString != testMySQLResolvedEnv["MYSQL_CONN_STR"] {
 		t.Error("Expected success")
 	}
 }
@@ -93,16 +92,15 @@ func TestMetadataToConnectionStrBuildNew(t *testing.T) {
 	// Build new ConnStr
 	expected := "test_username:pass@tcp(test_host:test_port)/test_dbname"
 	testMeta := map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "test_port", "username": "test_username", "passwordFromEnv": "MYSQL_PASSWORD", "dbName": "test_dbname"}
-	meta, _ := parseMySQLMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testMeta, AuthParams: map[string]string{}})
-	connStr := metadataToConnectionStr(meta)
-	if connStr != expected {
-		t.Errorf("%s != %s", expected, connStr)
+	meta, _ := parseSQLMetadata("mysql", &scalersconfig.ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testMeta, AuthParams: map[string]string{}})
+	if meta.connectionString != expected {
+		t.Errorf("%s != %s", expected, meta.connectionString)
 	}
 }
 
 func TestMySQLGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range mySQLMetricIdentifiers {
-		meta, err := parseMySQLMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: testData.metadataTestData.resolvedEnv, TriggerMetadata: testData.metadataTestData.metadata, AuthParams: nil, TriggerIndex: testData.triggerIndex})
+		meta, err := parseSQLMetadata("mysql", &scalersconfig.ScalerConfig{ResolvedEnv: testData.metadataTestData.resolvedEnv, TriggerMetadata: testData.metadataTestData.metadata, AuthParams: nil, TriggerIndex: testData.triggerIndex})
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
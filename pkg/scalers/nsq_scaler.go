@@ -0,0 +1,362 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	nsqDefaultDepthThreshold = 10
+	// nsqMaxNameLength is the maximum length of the topic and channel names accepted by nsqd
+	nsqMaxNameLength = 64
+)
+
+// nsqNamePattern matches the valid topic and channel names of nsqd
+var nsqNamePattern = regexp.MustCompile(`^[.a-zA-Z0-9_-]+(#ephemeral)?$`)
+
+type nsqScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *nsqMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type nsqMetadata struct {
+	nsqLookupdHTTPAddresses  []string
+	topic                    string
+	channel                  string
+	depthThreshold           int64
+	activationDepthThreshold int64
+	includeInFlight          bool
+	skipPausedChannels       bool
+	useHTTPS                 bool
+	unsafeSsl                bool
+	triggerIndex             int
+}
+
+type nsqLookupResponse struct {
+	Producers []nsqProducer `json:"producers"`
+}
+
+type nsqProducer struct {
+	BroadcastAddress string `json:"broadcast_address"`
+	HTTPPort         int    `json:"http_port"`
+}
+
+type nsqStatsResponse struct {
+	Topics []nsqTopicStats `json:"topics"`
+}
+
+type nsqTopicStats struct {
+	TopicName string            `json:"topic_name"`
+	Depth     int64             `json:"depth"`
+	Channels  []nsqChannelStats `json:"channels"`
+}
+
+type nsqChannelStats struct {
+	ChannelName   string `json:"channel_name"`
+	Depth         int64  `json:"depth"`
+	InFlightCount int64  `json:"in_flight_count"`
+	Paused        bool   `json:"paused"`
+}
+
+// NewNSQScaler creates a new nsqScaler
+func NewNSQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseNSQMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing nsq metadata: %w", err)
+	}
+
+	return &nsqScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "nsq_scaler"),
+	}, nil
+}
+
+func parseNSQMetadata(config *scalersconfig.ScalerConfig) (*nsqMetadata, error) {
+	meta := nsqMetadata{}
+
+	if val, ok := config.TriggerMetadata["nsqLookupdHTTPAddresses"]; ok && val != "" {
+		meta.nsqLookupdHTTPAddresses = splitAndTrim(val)
+	} else {
+		return nil, errors.New("no nsqLookupdHTTPAddresses given")
+	}
+
+	if val, ok := config.TriggerMetadata["topic"]; ok && val != "" {
+		if !isValidNSQName(val) {
+			return nil, fmt.Errorf("invalid topic name %s", val)
+		}
+		meta.topic = val
+	} else {
+		return nil, errors.New("no topic given")
+	}
+
+	if val, ok := config.TriggerMetadata["channel"]; ok && val != "" {
+		if !isValidNSQName(val) {
+			return nil, fmt.Errorf("invalid channel name %s", val)
+		}
+		meta.channel = val
+	} else {
+		return nil, errors.New("no channel given")
+	}
+
+	meta.depthThreshold = nsqDefaultDepthThreshold
+	if val, ok := config.TriggerMetadata["depthThreshold"]; ok && val != "" {
+		depthThreshold, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing depthThreshold: %w", err)
+		}
+		if depthThreshold <= 0 {
+			return nil, errors.New("depthThreshold must be greater than 0")
+		}
+		meta.depthThreshold = depthThreshold
+	}
+
+	meta.activationDepthThreshold = 0
+	if val, ok := config.TriggerMetadata["activationDepthThreshold"]; ok && val != "" {
+		activationDepthThreshold, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationDepthThreshold: %w", err)
+		}
+		if activationDepthThreshold < 0 {
+			return nil, errors.New("activationDepthThreshold must be greater than or equal to 0")
+		}
+		meta.activationDepthThreshold = activationDepthThreshold
+	}
+
+	meta.includeInFlight = true
+	if val, ok := config.TriggerMetadata["includeInFlight"]; ok && val != "" {
+		includeInFlight, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing includeInFlight: %w", err)
+		}
+		meta.includeInFlight = includeInFlight
+	}
+
+	if val, ok := config.TriggerMetadata["skipPausedChannels"]; ok && val != "" {
+		skipPausedChannels, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing skipPausedChannels: %w", err)
+		}
+		meta.skipPausedChannels = skipPausedChannels
+	}
+
+	if val, ok := config.TriggerMetadata["useHttps"]; ok && val != "" {
+		useHTTPS, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing useHttps: %w", err)
+		}
+		meta.useHTTPS = useHTTPS
+	}
+
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
+		unsafeSsl, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+		}
+		meta.unsafeSsl = unsafeSsl
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return &meta, nil
+}
+
+func isValidNSQName(name string) bool {
+	return len(name) <= nsqMaxNameLength && nsqNamePattern.MatchString(name)
+}
+
+// Close closes the idle connections to nsqlookupd and nsqd
+func (s *nsqScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *nsqScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("nsq-%s-%s", s.metadata.topic, s.metadata.channel))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.depthThreshold),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the depth of the channel across the nsqd nodes of the topic
+func (s *nsqScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	depth, err := s.getTopicChannelDepth(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting depth of channel %s of topic %s: %w", s.metadata.channel, s.metadata.topic, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(depth))
+	return []external_metrics.ExternalMetricValue{metric}, depth > s.metadata.activationDepthThreshold, nil
+}
+
+// getTopicChannelDepth sums the depth of the channel, and its in-flight messages when includeInFlight is set, on all the nsqd
+// nodes producing the topic
+func (s *nsqScaler) getTopicChannelDepth(ctx context.Context) (int64, error) {
+	nodes, err := s.getTopicProducers(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	var total int64
+	for _, node := range nodes {
+		depth, err := s.getNodeChannelDepth(ctx, node)
+		if err != nil {
+			return -1, err
+		}
+		total += depth
+	}
+	return total, nil
+}
+
+// getTopicProducers returns the HTTP addresses of the nsqd nodes producing the topic, as known by any of the nsqlookupd.
+// The nsqlookupd are redundant, so an error is only returned when none of them can be queried.
+func (s *nsqScaler) getTopicProducers(ctx context.Context) ([]string, error) {
+	var nodes []string
+	seen := map[string]bool{}
+	var errs []error
+	for _, address := range s.metadata.nsqLookupdHTTPAddresses {
+		lookupURL := url.URL{
+			Scheme:   s.scheme(),
+			Host:     address,
+			Path:     "/lookup",
+			RawQuery: url.Values{"topic": []string{s.metadata.topic}}.Encode(),
+		}
+
+		var lookup nsqLookupResponse
+		found, err := s.getJSON(ctx, lookupURL.String(), &lookup)
+		if err != nil {
+			s.logger.V(1).Info("Error looking up the topic", "nsqlookupd", address, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if !found {
+			// the topic doesn't exist yet, nothing was published
+			continue
+		}
+
+		for _, producer := range lookup.Producers {
+			node := net.JoinHostPort(producer.BroadcastAddress, strconv.Itoa(producer.HTTPPort))
+			if !seen[node] {
+				seen[node] = true
+				nodes = append(nodes, node)
+			}
+		}
+	}
+
+	if len(errs) == len(s.metadata.nsqLookupdHTTPAddresses) {
+		return nil, fmt.Errorf("error looking up the topic on nsqlookupd: %w", errors.Join(errs...))
+	}
+	return nodes, nil
+}
+
+// getNodeChannelDepth returns the depth of the channel on the nsqd node, which counts the messages in memory and on disk.
+// When the channel doesn't exist yet, the messages wait in the topic until it's created, so the topic depth is returned.
+func (s *nsqScaler) getNodeChannelDepth(ctx context.Context, node string) (int64, error) {
+	statsURL := url.URL{
+		Scheme: s.scheme(),
+		Host:   node,
+		Path:   "/stats",
+		RawQuery: url.Values{
+			"format":          []string{"json"},
+			"topic":           []string{s.metadata.topic},
+			"channel":         []string{s.metadata.channel},
+			"include_clients": []string{"false"},
+		}.Encode(),
+	}
+
+	var stats nsqStatsResponse
+	found, err := s.getJSON(ctx, statsURL.String(), &stats)
+	if err != nil {
+		return -1, fmt.Errorf("error getting stats of nsqd %s: %w", node, err)
+	}
+	if !found {
+		return 0, nil
+	}
+
+	for _, topic := range stats.Topics {
+		if topic.TopicName != s.metadata.topic {
+			continue
+		}
+		for _, channel := range topic.Channels {
+			if channel.ChannelName != s.metadata.channel {
+				continue
+			}
+			if channel.Paused && s.metadata.skipPausedChannels {
+				s.logger.V(1).Info("Skipping paused channel", "nsqd", node, "channel", s.metadata.channel)
+				return 0, nil
+			}
+			depth := channel.Depth
+			if s.metadata.includeInFlight {
+				depth += channel.InFlightCount
+			}
+			return depth, nil
+		}
+		return topic.Depth, nil
+	}
+	return 0, nil
+}
+
+// getJSON decodes the response of the endpoint into v, it returns false when the resource isn't found
+func (s *nsqScaler) getJSON(ctx context.Context, endpoint string, v interface{}) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/vnd.nsq; version=1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("%s returned status %d: %s", endpoint, resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("error decoding the response of %s: %w", endpoint, err)
+	}
+	return true, nil
+}
+
+func (s *nsqScaler) scheme() string {
+	if s.metadata.useHTTPS {
+		return "https"
+	}
+	return "http"
+}
@@ -0,0 +1,182 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseNSQMetadataTestData struct {
+	name     string
+	metadata map[string]string
+	isError  bool
+}
+
+var testNSQMetadata = []parseNSQMetadataTestData{
+	{name: "nothing passed", metadata: map[string]string{}, isError: true},
+	{name: "properly formed", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing"}},
+	{name: "all parameters", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161, nsqlookupd-1:4161", "topic": "orders#ephemeral", "channel": "billing", "depthThreshold": "50", "activationDepthThreshold": "5", "includeInFlight": "false", "skipPausedChannels": "true", "useHttps": "true", "unsafeSsl": "true"}},
+	{name: "missing nsqLookupdHTTPAddresses", metadata: map[string]string{"topic": "orders", "channel": "billing"}, isError: true},
+	{name: "missing topic", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "channel": "billing"}, isError: true},
+	{name: "invalid topic", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders/eu", "channel": "billing"}, isError: true},
+	{name: "missing channel", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders"}, isError: true},
+	{name: "channel name too long", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing-billing-billing-billing-billing-billing-billing-billing-billing"}, isError: true},
+	{name: "invalid depthThreshold", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing", "depthThreshold": "a"}, isError: true},
+	{name: "zero depthThreshold", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing", "depthThreshold": "0"}, isError: true},
+	{name: "invalid activationDepthThreshold", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing", "activationDepthThreshold": "a"}, isError: true},
+	{name: "negative activationDepthThreshold", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing", "activationDepthThreshold": "-1"}, isError: true},
+	{name: "invalid includeInFlight", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing", "includeInFlight": "a"}, isError: true},
+	{name: "invalid skipPausedChannels", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing", "skipPausedChannels": "a"}, isError: true},
+	{name: "invalid useHttps", metadata: map[string]string{"nsqLookupdHTTPAddresses": "nsqlookupd-0:4161", "topic": "orders", "channel": "billing", "useHttps": "a"}, isError: true},
+}
+
+func TestParseNSQMetadata(t *testing.T) {
+	for _, testData := range testNSQMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseNSQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	meta, err := parseNSQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testNSQMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(nsqDefaultDepthThreshold), meta.depthThreshold)
+	assert.True(t, meta.includeInFlight)
+	assert.False(t, meta.skipPausedChannels)
+
+	meta, err = parseNSQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testNSQMetadata[2].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nsqlookupd-0:4161", "nsqlookupd-1:4161"}, meta.nsqLookupdHTTPAddresses)
+	assert.Equal(t, int64(50), meta.depthThreshold)
+	assert.Equal(t, int64(5), meta.activationDepthThreshold)
+	assert.False(t, meta.includeInFlight)
+	assert.True(t, meta.skipPausedChannels)
+	assert.True(t, meta.useHTTPS)
+}
+
+func TestNSQGetMetricSpecForScaling(t *testing.T) {
+	scaler, err := NewNSQScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testNSQMetadata[1].metadata, TriggerIndex: 1})
+	assert.NoError(t, err)
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-nsq-orders-billing", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, int64(nsqDefaultDepthThreshold), metricSpec[0].External.Target.AverageValue.Value())
+}
+
+// newNSQDStub starts an nsqd stand-in serving the stats of the orders topic, and returns the producer nsqlookupd returns for it
+func newNSQDStub(t *testing.T, stats string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stats", r.URL.Path)
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		assert.Equal(t, "orders", r.URL.Query().Get("topic"))
+		assert.Equal(t, "billing", r.URL.Query().Get("channel"))
+		if stats == "" {
+			http.Error(w, "nsqd is unavailable", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(stats))
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	host, port, err := net.SplitHostPort(serverURL.Host)
+	assert.NoError(t, err)
+	return fmt.Sprintf(`{"broadcast_address": %q, "hostname": "nsqd", "tcp_port": 4150, "http_port": %s, "version": "1.3.0"}`, host, port)
+}
+
+func TestNSQGetTopicChannelDepth(t *testing.T) {
+	node0 := newNSQDStub(t, `{"version": "1.3.0", "health": "OK", "topics": [{"topic_name": "orders", "depth": 0, "channels": [{"channel_name": "billing", "depth": 12, "backend_depth": 10, "in_flight_count": 3, "paused": false}]}]}`)
+	node1 := newNSQDStub(t, `{"version": "1.3.0", "health": "OK", "topics": [{"topic_name": "orders", "depth": 0, "channels": [{"channel_name": "billing", "depth": 20, "backend_depth": 0, "in_flight_count": 5, "paused": true}]}]}`)
+	nodeWithoutChannel := newNSQDStub(t, `{"version": "1.3.0", "health": "OK", "topics": [{"topic_name": "orders", "depth": 7, "channels": []}]}`)
+	nodeWithoutTopic := newNSQDStub(t, `{"version": "1.3.0", "health": "OK", "topics": []}`)
+	unavailableNode := newNSQDStub(t, "")
+
+	testData := []struct {
+		name      string
+		producers []string
+		metadata  map[string]string
+		depth     int64
+		isActive  bool
+		isError   bool
+	}{
+		{name: "sum of the nodes", producers: []string{node0, node1}, depth: 12 + 3 + 20 + 5, isActive: true},
+		{name: "without in-flight messages", producers: []string{node0, node1}, metadata: map[string]string{"includeInFlight": "false"}, depth: 12 + 20, isActive: true},
+		{name: "skipping paused channels", producers: []string{node0, node1}, metadata: map[string]string{"skipPausedChannels": "true"}, depth: 12 + 3, isActive: true},
+		{name: "channel not created yet", producers: []string{node0, nodeWithoutChannel}, depth: 12 + 3 + 7, isActive: true},
+		{name: "topic not on the node anymore", producers: []string{nodeWithoutTopic}, depth: 0},
+		{name: "below the activation", producers: []string{node0}, metadata: map[string]string{"activationDepthThreshold": "15"}, depth: 15},
+		{name: "producer returned twice", producers: []string{node0, node0}, depth: 12 + 3, isActive: true},
+		{name: "topic not found", producers: nil, depth: 0},
+		{name: "unavailable nsqd", producers: []string{node0, unavailableNode}, isError: true},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			lookupd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/lookup", r.URL.Path)
+				assert.Equal(t, "orders", r.URL.Query().Get("topic"))
+				if tt.producers == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "TOPIC_NOT_FOUND"}`))
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"channels": ["billing"], "producers": [%s]}`, strings.Join(tt.producers, ","))
+			}))
+			defer lookupd.Close()
+			unavailableLookupd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "nsqlookupd is unavailable", http.StatusInternalServerError)
+			}))
+			defer unavailableLookupd.Close()
+
+			metadata := map[string]string{
+				"nsqLookupdHTTPAddresses": fmt.Sprintf("%s,%s", unavailableLookupd.Listener.Addr(), lookupd.Listener.Addr()),
+				"topic":                   "orders",
+				"channel":                 "billing",
+			}
+			for k, v := range tt.metadata {
+				metadata[k] = v
+			}
+			meta, err := parseNSQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata})
+			assert.NoError(t, err)
+			scaler := nsqScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "nsq")
+			if tt.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.depth, metrics[0].Value.Value())
+			assert.Equal(t, tt.isActive, active)
+		})
+	}
+}
+
+func TestNSQAllLookupdUnavailable(t *testing.T) {
+	lookupd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nsqlookupd is unavailable", http.StatusInternalServerError)
+	}))
+	defer lookupd.Close()
+
+	meta, err := parseNSQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"nsqLookupdHTTPAddresses": lookupd.Listener.Addr().String(), "topic": "orders", "channel": "billing"}})
+	assert.NoError(t, err)
+	scaler := nsqScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	_, _, err = scaler.GetMetricsAndActivity(context.Background(), "nsq")
+	assert.ErrorContains(t, err, "nsqlookupd is unavailable")
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlp
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// MetricsPath is the path of the OTLP/HTTP metrics endpoint
+	MetricsPath = "/v1/metrics"
+
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+	// maxRequestSize matches the default maximum message size of the gRPC server
+	maxRequestSize = 4 << 20
+	// sweepInterval is how often the expired data points are removed from the store
+	sweepInterval = time.Minute
+)
+
+var log = logf.Log.WithName("otlp_receiver")
+
+// ReceiverConfig configures the listeners and the authentication of a receiver
+type ReceiverConfig struct {
+	// GRPCAddress and HTTPAddress are the addresses of the protocols, an empty address disables the protocol
+	GRPCAddress string
+	HTTPAddress string
+	// Tokens maps the bearer tokens accepted from the senders to the namespace the metrics they push are bound to
+	Tokens map[string]string
+	// CertDir holds the tls.crt and tls.key of the certificate served by the receiver, plaintext is served when empty
+	CertDir string
+	// CertsReady is closed once the certificate is written to CertDir
+	CertsReady <-chan struct{}
+}
+
+// Receiver accepts the metrics pushed with OTLP/gRPC and OTLP/HTTP by authenticated senders and adds them to a store
+type Receiver struct {
+	store  *Store
+	config ReceiverConfig
+	collectormetrics.UnimplementedMetricsServiceServer
+}
+
+type namespaceContextKey struct{}
+
+// NewReceiver creates a receiver adding the received metrics to the store
+func NewReceiver(store *Store, config ReceiverConfig) *Receiver {
+	return &Receiver{
+		store:  store,
+		config: config,
+	}
+}
+
+// LoadTokens reads the bearer tokens accepted by the receiver from a file with a namespace:token line per token,
+// the empty lines and the lines starting with # are ignored
+func LoadTokens(filename string) (map[string]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tokens := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		namespace, token, found := strings.Cut(line, ":")
+		if !found || namespace == "" || token == "" {
+			return nil, fmt.Errorf("line %d of %s must be namespace:token", lineNumber, filename)
+		}
+		if _, ok := tokens[token]; ok {
+			return nil, fmt.Errorf("line %d of %s reuses the token of another line", lineNumber, filename)
+		}
+		tokens[token] = namespace
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no token found in %s", filename)
+	}
+	return tokens, nil
+}
+
+// authenticate returns the namespace of the bearer token of the authorization header value
+func (r *Receiver) authenticate(authorization string) (string, bool) {
+	token, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found || token == "" {
+		return "", false
+	}
+	// every token is compared so the time taken doesn't tell which one is close to match
+	namespace, ok := "", false
+	for candidate, candidateNamespace := range r.config.Tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			namespace, ok = candidateNamespace, true
+		}
+	}
+	return namespace, ok
+}
+
+// authenticateGRPC rejects the calls without a known bearer token and passes the namespace of the token to the service
+func (r *Receiver) authenticateGRPC(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		authorization = md.Get("authorization")[0]
+	}
+	namespace, ok := r.authenticate(authorization)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or unknown bearer token")
+	}
+	return handler(context.WithValue(ctx, namespaceContextKey{}, namespace), req)
+}
+
+// newGRPCServer creates the gRPC server of the metrics service authenticating the senders
+func (r *Receiver) newGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append(opts, grpc.UnaryInterceptor(r.authenticateGRPC))...)
+	collectormetrics.RegisterMetricsServiceServer(server, r)
+	return server
+}
+
+// Export implements the OTLP/gRPC metrics service
+func (r *Receiver) Export(ctx context.Context, request *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	namespace, ok := ctx.Value(namespaceContextKey{}).(string)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or unknown bearer token")
+	}
+	return r.ingest(namespace, request), nil
+}
+
+// ingest adds the metrics to the store, the data points rejected by the store are reported as a partial success
+func (r *Receiver) ingest(namespace string, request *collectormetrics.ExportMetricsServiceRequest) *collectormetrics.ExportMetricsServiceResponse {
+	response := &collectormetrics.ExportMetricsServiceResponse{}
+	if rejected := r.store.Ingest(namespace, request); rejected > 0 {
+		log.V(1).Info("Rejected data points of metrics with too many series", "namespace", namespace, "rejected", rejected)
+		response.PartialSuccess = &collectormetrics.ExportMetricsPartialSuccess{
+			RejectedDataPoints: rejected,
+			ErrorMessage:       fmt.Sprintf("the metrics are limited to %d series", maxSeriesPerMetric),
+		}
+	}
+	return response
+}
+
+// ServeHTTP implements the OTLP/HTTP metrics endpoint with binary protobuf and JSON encodings
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != MetricsPath {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	namespace, ok := r.authenticate(req.Header.Get("Authorization"))
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "missing or unknown bearer token", http.StatusUnauthorized)
+		return
+	}
+
+	contentType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || (contentType != contentTypeProtobuf && contentType != contentTypeJSON) {
+		http.Error(w, fmt.Sprintf("unsupported content type, must be %s or %s", contentTypeProtobuf, contentTypeJSON), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, req.Body, maxRequestSize)
+	switch req.Header.Get("Content-Encoding") {
+	case "", "identity":
+	case "gzip":
+		gzipReader, err := gzip.NewReader(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gzipReader.Close()
+		body = io.LimitReader(gzipReader, maxRequestSize)
+	default:
+		http.Error(w, "unsupported content encoding", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := &collectormetrics.ExportMetricsServiceRequest{}
+	if contentType == contentTypeJSON {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, request)
+	} else {
+		err = proto.Unmarshal(data, request)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error decoding the request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	exportResponse := r.ingest(namespace, request)
+
+	var response []byte
+	if contentType == contentTypeJSON {
+		response, err = protojson.Marshal(exportResponse)
+	} else {
+		response, err = proto.Marshal(exportResponse)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(response)
+}
+
+// loadTLSConfig returns the TLS configuration serving the certificate of the cert dir, reloaded when it's rotated
+func (r *Receiver) loadTLSConfig(ctx context.Context) (*tls.Config, error) {
+	watcher, err := certwatcher.New(path.Join(r.config.CertDir, "tls.crt"), path.Join(r.config.CertDir, "tls.key"))
+	if err != nil {
+		return nil, fmt.Errorf("error loading the OTLP receiver certificate: %w", err)
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			log.Error(err, "error watching the OTLP receiver certificate")
+		}
+	}()
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS13,
+		GetCertificate: watcher.GetCertificate,
+	}, nil
+}
+
+// sweep removes the expired data points from the store until the context is done
+func (r *Receiver) sweep(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.store.Expire()
+		}
+	}
+}
+
+// Start serves the enabled protocols until the context is done
+func (r *Receiver) Start(ctx context.Context) error {
+	if len(r.config.Tokens) == 0 {
+		return errors.New("the OTLP receiver requires at least one token")
+	}
+
+	var tlsConfig *tls.Config
+	if r.config.CertDir != "" {
+		if r.config.CertsReady != nil {
+			select {
+			case <-r.config.CertsReady:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		var err error
+		if tlsConfig, err = r.loadTLSConfig(ctx); err != nil {
+			return err
+		}
+	}
+
+	errChan := make(chan error, 2)
+
+	var grpcServer *grpc.Server
+	if r.config.GRPCAddress != "" {
+		lis, err := net.Listen("tcp", r.config.GRPCAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", r.config.GRPCAddress, err)
+		}
+		var opts []grpc.ServerOption
+		if tlsConfig != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		grpcServer = r.newGRPCServer(opts...)
+		go func() {
+			log.Info("Starting OTLP gRPC receiver", "address", r.config.GRPCAddress)
+			if err := grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				errChan <- fmt.Errorf("unable to serve the OTLP gRPC receiver on address %s: %w", r.config.GRPCAddress, err)
+			}
+		}()
+	}
+
+	var httpServer *http.Server
+	if r.config.HTTPAddress != "" {
+		lis, err := net.Listen("tcp", r.config.HTTPAddress)
+		if err != nil {
+			if grpcServer != nil {
+				grpcServer.Stop()
+			}
+			return fmt.Errorf("failed to listen on %s: %w", r.config.HTTPAddress, err)
+		}
+		if tlsConfig != nil {
+			lis = tls.NewListener(lis, tlsConfig)
+		}
+		httpServer = &http.Server{
+			Handler:           r,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Info("Starting OTLP HTTP receiver", "address", r.config.HTTPAddress)
+			if err := httpServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errChan <- fmt.Errorf("unable to serve the OTLP HTTP receiver on address %s: %w", r.config.HTTPAddress, err)
+			}
+		}()
+	}
+
+	sweepCtx, stopSweep := context.WithCancel(ctx)
+	defer stopSweep()
+	go r.sweep(sweepCtx)
+
+	var err error
+	select {
+	case err = <-errChan:
+		log.Error(err, "error serving the OTLP receiver")
+	case <-ctx.Done():
+		log.Info("Shutting down the OTLP receiver")
+	}
+
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if httpServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}
+	return err
+}
+
+// NeedLeaderElection makes only the leader receive the metrics, as only the leader runs the scale loops reading the store.
+// The other replicas don't listen, so the senders retry until they reach the leader.
+func (r *Receiver) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testNamespace = "shop"
+	testToken     = "shop-token"
+)
+
+var testTokens = map[string]string{testToken: testNamespace, "billing-token": "billing"}
+
+func stringAttribute(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
+
+// testExportRequest returns a request with a gauge of the queue length of the pod and a histogram, which is ignored
+func testExportRequest(pod string, queueLength int64) *collectormetrics.ExportMetricsServiceRequest {
+	return &collectormetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricsv1.ResourceMetrics{{
+			Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{stringAttribute("k8s.pod.name", pod)}},
+			ScopeMetrics: []*metricsv1.ScopeMetrics{{
+				Metrics: []*metricsv1.Metric{
+					{
+						Name: "queue_length",
+						Data: &metricsv1.Metric_Gauge{Gauge: &metricsv1.Gauge{DataPoints: []*metricsv1.NumberDataPoint{
+							{Attributes: []*commonv1.KeyValue{stringAttribute("queue", "orders")}, Value: &metricsv1.NumberDataPoint_AsInt{AsInt: queueLength}},
+							{Attributes: []*commonv1.KeyValue{stringAttribute("queue", "emails")}, Value: &metricsv1.NumberDataPoint_AsDouble{AsDouble: 100}},
+						}}},
+					},
+					{
+						Name: "request_duration",
+						Data: &metricsv1.Metric_Histogram{Histogram: &metricsv1.Histogram{DataPoints: []*metricsv1.HistogramDataPoint{{Count: 3}}}},
+					},
+				},
+			}},
+		}},
+	}
+}
+
+func TestStoreAggregate(t *testing.T) {
+	now := time.Now()
+	store := NewStore(10 * time.Minute)
+	store.now = func() time.Time { return now }
+
+	for i, value := range []float64{4, 10, 6} {
+		now = now.Add(time.Duration(i) * 20 * time.Second)
+		store.Add(testNamespace, "queue_length", map[string]string{"k8s.pod.name": "worker-0", "queue": "orders"}, value)
+		store.Add(testNamespace, "queue_length", map[string]string{"k8s.pod.name": "worker-1", "queue": "orders"}, value*10)
+	}
+	store.Add(testNamespace, "queue_length", map[string]string{"k8s.pod.name": "worker-0", "queue": "emails"}, 1000)
+
+	tests := []struct {
+		name        string
+		filter      map[string]string
+		aggregation Aggregation
+		window      time.Duration
+		expected    float64
+		found       bool
+	}{
+		{name: "last of every series", aggregation: AggregationLast, window: time.Minute, expected: 6 + 60 + 1000, found: true},
+		{name: "last of the filtered series", filter: map[string]string{"queue": "orders"}, aggregation: AggregationLast, window: time.Minute, expected: 66, found: true},
+		{name: "filter on every attribute", filter: map[string]string{"queue": "orders", "k8s.pod.name": "worker-1"}, aggregation: AggregationLast, window: time.Minute, expected: 60, found: true},
+		{name: "sum", filter: map[string]string{"k8s.pod.name": "worker-0", "queue": "orders"}, aggregation: AggregationSum, window: time.Minute, expected: 20, found: true},
+		{name: "avg", filter: map[string]string{"k8s.pod.name": "worker-0", "queue": "orders"}, aggregation: AggregationAvg, window: time.Minute, expected: 20.0 / 3, found: true},
+		{name: "max", filter: map[string]string{"queue": "orders"}, aggregation: AggregationMax, window: time.Minute, expected: 10 + 100, found: true},
+		{name: "min", filter: map[string]string{"queue": "orders"}, aggregation: AggregationMin, window: time.Minute, expected: 4 + 40, found: true},
+		{name: "window excludes older points", filter: map[string]string{"k8s.pod.name": "worker-0", "queue": "orders"}, aggregation: AggregationSum, window: 45 * time.Second, expected: 16, found: true},
+		{name: "no matching series", filter: map[string]string{"queue": "payments"}, aggregation: AggregationLast, window: time.Minute, found: false},
+		{name: "unknown metric", aggregation: AggregationLast, window: time.Minute, found: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metricName := "queue_length"
+			if test.name == "unknown metric" {
+				metricName = "unknown"
+			}
+			value, found := store.Aggregate(testNamespace, metricName, test.filter, test.aggregation, test.window)
+			assert.Equal(t, test.found, found)
+			assert.InDelta(t, test.expected, value, 0.0001)
+		})
+	}
+}
+
+func TestStoreRetention(t *testing.T) {
+	now := time.Now()
+	store := NewStore(time.Minute)
+	store.now = func() time.Time { return now }
+	key := metricKey{namespace: testNamespace, name: "queue_length"}
+
+	store.Add(testNamespace, "queue_length", map[string]string{"queue": "orders"}, 1)
+	store.Add(testNamespace, "other", nil, 1)
+	now = now.Add(2 * time.Minute)
+	store.Add(testNamespace, "queue_length", map[string]string{"queue": "emails"}, 2)
+
+	assert.Len(t, store.metrics[key], 2, "the expired series is kept until the sweep")
+	value, found := store.Aggregate(testNamespace, "queue_length", nil, AggregationSum, time.Minute)
+	assert.True(t, found)
+	assert.Equal(t, float64(2), value, "the expired series is out of the window")
+
+	store.Expire()
+	assert.Len(t, store.metrics[key], 1, "the expired series is removed")
+	assert.NotContains(t, store.metrics, metricKey{namespace: testNamespace, name: "other"}, "the metric without series is removed")
+
+	for i := 0; i < maxPointsPerSeries+10; i++ {
+		store.Add(testNamespace, "queue_length", map[string]string{"queue": "emails"}, 1)
+	}
+	assert.Len(t, store.metrics[key][seriesKey(map[string]string{"queue": "emails"})].points, maxPointsPerSeries)
+}
+
+func TestStoreSeriesLimit(t *testing.T) {
+	now := time.Now()
+	store := NewStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	for i := 0; i < maxSeriesPerMetric; i++ {
+		assert.True(t, store.Add(testNamespace, "queue_length", map[string]string{"pod": fmt.Sprint(i)}, 1))
+	}
+	assert.False(t, store.Add(testNamespace, "queue_length", map[string]string{"pod": "new"}, 1), "new series are rejected")
+	assert.True(t, store.Add(testNamespace, "queue_length", map[string]string{"pod": "0"}, 1), "existing series are still updated")
+	assert.True(t, store.Add("billing", "queue_length", map[string]string{"pod": "new"}, 1), "the limit is per namespace")
+
+	now = now.Add(2 * time.Minute)
+	store.Expire()
+	assert.True(t, store.Add(testNamespace, "queue_length", map[string]string{"pod": "new"}, 1), "new series are accepted once the others expired")
+}
+
+func TestStoreNamespaces(t *testing.T) {
+	store := NewStore(time.Minute)
+	store.Add(testNamespace, "queue_length", nil, 1)
+	store.Add("billing", "queue_length", nil, 10)
+
+	value, found := store.Aggregate(testNamespace, "queue_length", nil, AggregationSum, time.Minute)
+	assert.True(t, found)
+	assert.Equal(t, float64(1), value, "the metrics of other namespaces aren't read")
+	_, found = store.Aggregate("orders", "queue_length", nil, AggregationSum, time.Minute)
+	assert.False(t, found)
+}
+
+func TestStoreSubscribe(t *testing.T) {
+	store := NewStore(time.Minute)
+	updates, unsubscribe := store.Subscribe(testNamespace, "queue_length")
+
+	store.Add(testNamespace, "other", nil, 1)
+	store.Add("billing", "queue_length", nil, 1)
+	select {
+	case <-updates:
+		t.Fatal("notified of another metric")
+	default:
+	}
+
+	store.Ingest(testNamespace, testExportRequest("worker-0", 1))
+	store.Ingest(testNamespace, testExportRequest("worker-0", 2))
+	select {
+	case <-updates:
+	default:
+		t.Fatal("not notified of the received data points")
+	}
+	select {
+	case <-updates:
+		t.Fatal("pending notifications aren't coalesced")
+	default:
+	}
+
+	unsubscribe()
+	assert.Empty(t, store.subscribers)
+}
+
+func TestReceiverGRPC(t *testing.T) {
+	store := NewStore(time.Minute)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := NewReceiver(store, ReceiverConfig{Tokens: testTokens}).newGRPCServer()
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := collectormetrics.NewMetricsServiceClient(conn)
+
+	for _, authorization := range []string{"", "Bearer unknown", testToken} {
+		ctx := context.Background()
+		if authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+		}
+		_, err = client.Export(ctx, testExportRequest("worker-0", 1))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "authorization %q is rejected", authorization)
+	}
+	_, found := store.Aggregate(testNamespace, "queue_length", nil, AggregationLast, time.Minute)
+	assert.False(t, found, "the unauthenticated metrics aren't stored")
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken)
+	response, err := client.Export(ctx, testExportRequest("worker-0", 7))
+	assert.NoError(t, err)
+	assert.Nil(t, response.GetPartialSuccess())
+
+	value, found := store.Aggregate(testNamespace, "queue_length", map[string]string{"k8s.pod.name": "worker-0", "queue": "orders"}, AggregationLast, time.Minute)
+	assert.True(t, found)
+	assert.Equal(t, float64(7), value)
+	_, found = store.Aggregate("billing", "queue_length", nil, AggregationLast, time.Minute)
+	assert.False(t, found, "the metrics are bound to the namespace of the token")
+	_, found = store.Aggregate(testNamespace, "request_duration", nil, AggregationLast, time.Minute)
+	assert.False(t, found, "histograms are ignored")
+}
+
+func TestReceiverPartialSuccess(t *testing.T) {
+	store := NewStore(time.Minute)
+	for i := 0; i < maxSeriesPerMetric; i++ {
+		store.Add(testNamespace, "queue_length", map[string]string{"pod": fmt.Sprint(i)}, 1)
+	}
+
+	response := NewReceiver(store, ReceiverConfig{Tokens: testTokens}).ingest(testNamespace, testExportRequest("worker-0", 1))
+	assert.Equal(t, int64(2), response.GetPartialSuccess().GetRejectedDataPoints(), "the data points of both new series are rejected")
+	assert.NotEmpty(t, response.GetPartialSuccess().GetErrorMessage())
+}
+
+func TestReceiverHTTP(t *testing.T) {
+	protobufBody, err := proto.Marshal(testExportRequest("worker-0", 3))
+	assert.NoError(t, err)
+	var gzipBody bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipBody)
+	_, _ = gzipWriter.Write(protobufBody)
+	gzipWriter.Close()
+	// int64 values are encoded as strings and the enums by name in OTLP/JSON
+	jsonBody := []byte(`{"resourceMetrics":[{"resource":{"attributes":[{"key":"k8s.pod.name","value":{"stringValue":"worker-1"}}]},` +
+		`"scopeMetrics":[{"metrics":[{"name":"queue_length","sum":{"aggregationTemporality":"AGGREGATION_TEMPORALITY_CUMULATIVE",` +
+		`"dataPoints":[{"attributes":[{"key":"queue","value":{"stringValue":"orders"}}],"asInt":"5"}]}}]}]}]}`)
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		contentType     string
+		contentEncoding string
+		authorization   string
+		body            []byte
+		status          int
+		pod             string
+		expected        float64
+	}{
+		{name: "protobuf", method: http.MethodPost, path: MetricsPath, contentType: "application/x-protobuf", body: protobufBody, status: http.StatusOK, pod: "worker-0", expected: 3},
+		{name: "gzip protobuf", method: http.MethodPost, path: MetricsPath, contentType: "application/x-protobuf", contentEncoding: "gzip", body: gzipBody.Bytes(), status: http.StatusOK, pod: "worker-0", expected: 3},
+		{name: "json", method: http.MethodPost, path: MetricsPath, contentType: "application/json; charset=utf-8", body: jsonBody, status: http.StatusOK, pod: "worker-1", expected: 5},
+		{name: "invalid protobuf", method: http.MethodPost, path: MetricsPath, contentType: "application/x-protobuf", body: []byte("not protobuf"), status: http.StatusBadRequest},
+		{name: "unsupported content type", method: http.MethodPost, path: MetricsPath, contentType: "text/plain", body: protobufBody, status: http.StatusUnsupportedMediaType},
+		{name: "unsupported content encoding", method: http.MethodPost, path: MetricsPath, contentType: "application/x-protobuf", contentEncoding: "br", body: protobufBody, status: http.StatusUnsupportedMediaType},
+		{name: "wrong method", method: http.MethodGet, path: MetricsPath, status: http.StatusMethodNotAllowed},
+		{name: "wrong path", method: http.MethodPost, path: "/v1/traces", contentType: "application/x-protobuf", body: protobufBody, status: http.StatusNotFound},
+		{name: "missing token", method: http.MethodPost, path: MetricsPath, contentType: "application/x-protobuf", authorization: "-", body: protobufBody, status: http.StatusUnauthorized},
+		{name: "unknown token", method: http.MethodPost, path: MetricsPath, contentType: "application/x-protobuf", authorization: "Bearer unknown", body: protobufBody, status: http.StatusUnauthorized},
+		{name: "token without scheme", method: http.MethodPost, path: MetricsPath, contentType: "application/x-protobuf", authorization: testToken, body: protobufBody, status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := NewStore(time.Minute)
+			server := httptest.NewServer(NewReceiver(store, ReceiverConfig{Tokens: testTokens}))
+			defer server.Close()
+
+			req, err := http.NewRequest(test.method, server.URL+test.path, bytes.NewReader(test.body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", test.contentType)
+			if test.contentEncoding != "" {
+				req.Header.Set("Content-Encoding", test.contentEncoding)
+			}
+			switch test.authorization {
+			case "":
+				req.Header.Set("Authorization", "Bearer "+testToken)
+			case "-":
+			default:
+				req.Header.Set("Authorization", test.authorization)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, test.status, resp.StatusCode)
+			if test.status != http.StatusOK {
+				return
+			}
+			value, found := store.Aggregate(testNamespace, "queue_length", map[string]string{"k8s.pod.name": test.pod, "queue": "orders"}, AggregationLast, time.Minute)
+			assert.True(t, found)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestLoadTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected map[string]string
+		isError  bool
+	}{
+		{name: "tokens", content: "# senders of the shop\nshop:shop-token\n\nbilling:billing:token\n", expected: map[string]string{"shop-token": "shop", "billing:token": "billing"}},
+		{name: "missing token", content: "shop:\n", isError: true},
+		{name: "missing namespace", content: "shop-token\n", isError: true},
+		{name: "reused token", content: "shop:token\nbilling:token\n", isError: true},
+		{name: "empty file", content: "# no sender yet\n", isError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "tokens")
+			assert.NoError(t, os.WriteFile(filename, []byte(test.content), 0600))
+
+			tokens, err := LoadTokens(filename)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, tokens)
+		})
+	}
+
+	_, err := LoadTokens(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestReceiverStart(t *testing.T) {
+	store := NewStore(time.Minute)
+	receiver := NewReceiver(store, ReceiverConfig{GRPCAddress: "127.0.0.1:0", HTTPAddress: "127.0.0.1:0", Tokens: testTokens})
+	assert.True(t, receiver.NeedLeaderElection())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- receiver.Start(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("receiver didn't stop")
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer lis.Close()
+	err = NewReceiver(store, ReceiverConfig{HTTPAddress: lis.Addr().String(), Tokens: testTokens}).Start(context.Background())
+	assert.Error(t, err, "the address is already in use")
+
+	err = NewReceiver(store, ReceiverConfig{HTTPAddress: "127.0.0.1:0"}).Start(context.Background())
+	assert.Error(t, err, "the receiver doesn't accept unauthenticated metrics")
+}
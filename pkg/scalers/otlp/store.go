@@ -0,0 +1,350 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package otlp receives the metrics pushed with the OpenTelemetry protocol and keeps
+// the recent data points in memory for the otlp scaler.
+package otlp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+const (
+	// DefaultRetention is how long the data points are kept, it bounds the window of the otlp scaler
+	DefaultRetention = 10 * time.Minute
+	// maxPointsPerSeries bounds the memory used by series pushed at a high frequency
+	maxPointsPerSeries = 1000
+	// maxSeriesPerMetric bounds the memory used by metrics with attributes of a high cardinality,
+	// the data points of new series are rejected until older series expire
+	maxSeriesPerMetric = 1000
+)
+
+// Aggregation reduces the data points of a series over a window
+type Aggregation string
+
+const (
+	AggregationLast Aggregation = "last"
+	AggregationSum  Aggregation = "sum"
+	AggregationAvg  Aggregation = "avg"
+	AggregationMin  Aggregation = "min"
+	AggregationMax  Aggregation = "max"
+)
+
+// ParseAggregation returns the aggregation with the given name
+func ParseAggregation(name string) (Aggregation, error) {
+	switch aggregation := Aggregation(name); aggregation {
+	case AggregationLast, AggregationSum, AggregationAvg, AggregationMin, AggregationMax:
+		return aggregation, nil
+	default:
+		return "", fmt.Errorf("aggregation must be one of last, sum, avg, min or max, got %s", name)
+	}
+}
+
+// DefaultStore is the store filled by the receiver of the operator and read by the otlp scalers
+var DefaultStore = NewStore(DefaultRetention)
+
+// Store keeps the data points received during the retention period, grouped by namespace, metric name and attributes
+type Store struct {
+	mu          sync.RWMutex
+	retention   time.Duration
+	metrics     map[metricKey]map[string]*series
+	subscribers map[metricKey]map[chan struct{}]struct{}
+	now         func() time.Time
+}
+
+// metricKey identifies a metric pushed by the senders of a namespace, so the scalers only read the metrics of their namespace
+type metricKey struct {
+	namespace string
+	name      string
+}
+
+type series struct {
+	attributes map[string]string
+	points     []point
+}
+
+type point struct {
+	timestamp time.Time
+	value     float64
+}
+
+// NewStore creates a store keeping the data points for the retention period
+func NewStore(retention time.Duration) *Store {
+	return &Store{
+		retention:   retention,
+		metrics:     map[metricKey]map[string]*series{},
+		subscribers: map[metricKey]map[chan struct{}]struct{}{},
+		now:         time.Now,
+	}
+}
+
+// Retention returns how long the data points are kept
+func (s *Store) Retention() time.Duration {
+	return s.retention
+}
+
+// Ingest adds the number data points of the gauges and sums of the request to the metrics of the namespace, the resource
+// attributes are merged into the attributes of each data point. Points are timestamped on reception, so the clocks of the
+// senders don't shift the windows. It returns the number of data points rejected because their metric has too many series.
+func (s *Store) Ingest(namespace string, request *collectormetrics.ExportMetricsServiceRequest) int64 {
+	var rejected int64
+	updated := map[metricKey]struct{}{}
+	s.mu.Lock()
+	now := s.now()
+	for _, resourceMetrics := range request.GetResourceMetrics() {
+		resourceAttributes := attributesToMap(resourceMetrics.GetResource().GetAttributes(), nil)
+		for _, scopeMetrics := range resourceMetrics.GetScopeMetrics() {
+			for _, metric := range scopeMetrics.GetMetrics() {
+				var dataPoints []*metricsv1.NumberDataPoint
+				switch data := metric.GetData().(type) {
+				case *metricsv1.Metric_Gauge:
+					dataPoints = data.Gauge.GetDataPoints()
+				case *metricsv1.Metric_Sum:
+					dataPoints = data.Sum.GetDataPoints()
+				default:
+					continue
+				}
+				for _, dataPoint := range dataPoints {
+					var value float64
+					switch v := dataPoint.GetValue().(type) {
+					case *metricsv1.NumberDataPoint_AsDouble:
+						value = v.AsDouble
+					case *metricsv1.NumberDataPoint_AsInt:
+						value = float64(v.AsInt)
+					default:
+						continue
+					}
+					key := metricKey{namespace: namespace, name: metric.GetName()}
+					if !s.add(key, attributesToMap(dataPoint.GetAttributes(), resourceAttributes), value, now) {
+						rejected++
+						continue
+					}
+					updated[key] = struct{}{}
+				}
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for key := range updated {
+		s.notify(key)
+	}
+	return rejected
+}
+
+// Add adds a data point to the series of the metric of the namespace with the attributes, it returns false when
+// the data point is rejected because the metric has too many series
+func (s *Store) Add(namespace, metricName string, attributes map[string]string, value float64) bool {
+	key := metricKey{namespace: namespace, name: metricName}
+	s.mu.Lock()
+	added := s.add(key, attributes, value, s.now())
+	s.mu.Unlock()
+	if added {
+		s.notify(key)
+	}
+	return added
+}
+
+func (s *Store) add(key metricKey, attributes map[string]string, value float64, now time.Time) bool {
+	metricSeries, ok := s.metrics[key]
+	if !ok {
+		metricSeries = map[string]*series{}
+		s.metrics[key] = metricSeries
+	}
+	attributesKey := seriesKey(attributes)
+	sr, ok := metricSeries[attributesKey]
+	if !ok {
+		if len(metricSeries) >= maxSeriesPerMetric {
+			return false
+		}
+		sr = &series{attributes: attributes}
+		metricSeries[attributesKey] = sr
+	}
+	sr.points = append(sr.points, point{timestamp: now, value: value})
+	if len(sr.points) > maxPointsPerSeries {
+		sr.points = sr.points[len(sr.points)-maxPointsPerSeries:]
+	}
+	return true
+}
+
+// Expire removes the data points older than the retention period, and the series and metrics without data points.
+// It's called periodically by the receiver, the data points outside the windows are ignored by Aggregate meanwhile.
+func (s *Store) Expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldest := s.now().Add(-s.retention)
+	for key, metricSeries := range s.metrics {
+		for attributesKey, sr := range metricSeries {
+			i := sort.Search(len(sr.points), func(i int) bool { return !sr.points[i].timestamp.Before(oldest) })
+			if i > 0 {
+				// copy the remaining points, so the expired ones don't stay referenced by the underlying array
+				sr.points = append([]point(nil), sr.points[i:]...)
+			}
+			if len(sr.points) == 0 {
+				delete(metricSeries, attributesKey)
+			}
+		}
+		if len(metricSeries) == 0 {
+			delete(s.metrics, key)
+		}
+	}
+}
+
+// Aggregate reduces the data points received during the window of each series of the metric of the namespace matching
+// all the attributes of the filter, and returns the sum of the series values. It returns false when there isn't any data
+// point in the window.
+func (s *Store) Aggregate(namespace, metricName string, filter map[string]string, aggregation Aggregation, window time.Duration) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	oldest := s.now().Add(-window)
+	var total float64
+	found := false
+	for _, sr := range s.metrics[metricKey{namespace: namespace, name: metricName}] {
+		if !matches(sr.attributes, filter) {
+			continue
+		}
+		i := sort.Search(len(sr.points), func(i int) bool { return !sr.points[i].timestamp.Before(oldest) })
+		points := sr.points[i:]
+		if len(points) == 0 {
+			continue
+		}
+		total += reduce(points, aggregation)
+		found = true
+	}
+	return total, found
+}
+
+func reduce(points []point, aggregation Aggregation) float64 {
+	switch aggregation {
+	case AggregationSum, AggregationAvg:
+		var sum float64
+		for _, p := range points {
+			sum += p.value
+		}
+		if aggregation == AggregationAvg {
+			return sum / float64(len(points))
+		}
+		return sum
+	case AggregationMin:
+		value := math.Inf(1)
+		for _, p := range points {
+			value = math.Min(value, p.value)
+		}
+		return value
+	case AggregationMax:
+		value := math.Inf(-1)
+		for _, p := range points {
+			value = math.Max(value, p.value)
+		}
+		return value
+	default:
+		return points[len(points)-1].value
+	}
+}
+
+// Subscribe returns a channel receiving a notification when data points of the metric of the namespace are added, and a
+// function to call once the notifications aren't needed anymore. Notifications are coalesced while the channel isn't read.
+func (s *Store) Subscribe(namespace, metricName string) (<-chan struct{}, func()) {
+	key := metricKey{namespace: namespace, name: metricName}
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	if s.subscribers[key] == nil {
+		s.subscribers[key] = map[chan struct{}]struct{}{}
+	}
+	s.subscribers[key][ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers[key], ch)
+		if len(s.subscribers[key]) == 0 {
+			delete(s.subscribers, key)
+		}
+	}
+}
+
+func (s *Store) notify(key metricKey) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for ch := range s.subscribers[key] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func matches(attributes, filter map[string]string) bool {
+	for key, value := range filter {
+		if attributes[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// seriesKey identifies the series of the attributes regardless of their order
+func seriesKey(attributes map[string]string) string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%q=%q,", key, attributes[key])
+	}
+	return b.String()
+}
+
+// attributesToMap returns the string representation of the attributes, merged over the base attributes
+func attributesToMap(attributes []*commonv1.KeyValue, base map[string]string) map[string]string {
+	result := make(map[string]string, len(base)+len(attributes))
+	for key, value := range base {
+		result[key] = value
+	}
+	for _, attribute := range attributes {
+		result[attribute.GetKey()] = anyValueToString(attribute.GetValue())
+	}
+	return result
+}
+
+func anyValueToString(value *commonv1.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case *commonv1.AnyValue_StringValue:
+		return v.StringValue
+	case *commonv1.AnyValue_BoolValue:
+		return fmt.Sprintf("%t", v.BoolValue)
+	case *commonv1.AnyValue_IntValue:
+		return fmt.Sprintf("%d", v.IntValue)
+	case *commonv1.AnyValue_DoubleValue:
+		return fmt.Sprintf("%g", v.DoubleValue)
+	default:
+		return ""
+	}
+}
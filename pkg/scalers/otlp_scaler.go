@@ -0,0 +1,187 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/otlp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultOTLPWindowSeconds = 60
+)
+
+type otlpScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *otlpMetadata
+	store      *otlp.Store
+	logger     logr.Logger
+}
+
+type otlpMetadata struct {
+	namespace             string
+	metricName            string
+	attributes            map[string]string
+	aggregation           otlp.Aggregation
+	window                time.Duration
+	targetValue           float64
+	activationTargetValue float64
+	triggerIndex          int
+}
+
+// NewOTLPScaler creates a new otlp push scaler reading the metrics received by the OTLP receiver of the operator,
+// only the metrics pushed with a token of the namespace of the scaled object are read
+func NewOTLPScaler(config *scalersconfig.ScalerConfig) (PushScaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOTLPMetadata(config, otlp.DefaultStore.Retention())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing otlp metadata: %w", err)
+	}
+
+	return &otlpScaler{
+		metricType: metricType,
+		metadata:   meta,
+		store:      otlp.DefaultStore,
+		logger:     InitializeLogger(config, "otlp_scaler"),
+	}, nil
+}
+
+func parseOTLPMetadata(config *scalersconfig.ScalerConfig, retention time.Duration) (*otlpMetadata, error) {
+	meta := otlpMetadata{namespace: config.ScalableObjectNamespace}
+
+	if val, ok := config.TriggerMetadata["metricName"]; ok && val != "" {
+		meta.metricName = val
+	} else {
+		return nil, errors.New("no metricName given")
+	}
+
+	meta.attributes = map[string]string{}
+	if val, ok := config.TriggerMetadata["attributes"]; ok && val != "" {
+		for _, attribute := range splitAndTrim(val) {
+			key, value, found := strings.Cut(attribute, "=")
+			key = strings.TrimSpace(key)
+			if !found || key == "" {
+				return nil, fmt.Errorf("attributes must be a comma separated list of key=value, got %s", attribute)
+			}
+			meta.attributes[key] = strings.TrimSpace(value)
+		}
+	}
+
+	meta.aggregation = otlp.AggregationLast
+	if val, ok := config.TriggerMetadata["aggregation"]; ok && val != "" {
+		aggregation, err := otlp.ParseAggregation(strings.ToLower(val))
+		if err != nil {
+			return nil, err
+		}
+		meta.aggregation = aggregation
+	}
+
+	meta.window = defaultOTLPWindowSeconds * time.Second
+	if val, ok := config.TriggerMetadata["windowSeconds"]; ok && val != "" {
+		windowSeconds, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("windowSeconds parsing error %w", err)
+		}
+		meta.window = time.Duration(windowSeconds) * time.Second
+		if meta.window <= 0 || meta.window > retention {
+			return nil, fmt.Errorf("windowSeconds must be between 1 and %d", int(retention.Seconds()))
+		}
+	}
+
+	if val, ok := config.TriggerMetadata["targetValue"]; ok && val != "" {
+		targetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("targetValue parsing error %w", err)
+		}
+		meta.targetValue = targetValue
+	} else {
+		if config.AsMetricSource {
+			meta.targetValue = 0
+		} else {
+			return nil, errors.New("no targetValue given")
+		}
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		activationTargetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("activationTargetValue parsing error %w", err)
+		}
+		meta.activationTargetValue = activationTargetValue
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return &meta, nil
+}
+
+func (s *otlpScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *otlpScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("otlp-%s", s.metadata.metricName))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the aggregated value of the received data points, 0 when nothing was received during the window
+func (s *otlpScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value := s.getValue()
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.activationTargetValue, nil
+}
+
+func (s *otlpScaler) getValue() float64 {
+	value, _ := s.store.Aggregate(s.metadata.namespace, s.metadata.metricName, s.metadata.attributes, s.metadata.aggregation, s.metadata.window)
+	return value
+}
+
+// Run sends the activity each time data points of the metric are received, so the target is activated without waiting for
+// the polling interval. The activity isn't sent again while it doesn't change.
+func (s *otlpScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+
+	updates, unsubscribe := s.store.Subscribe(s.metadata.namespace, s.metadata.metricName)
+	defer unsubscribe()
+
+	wasActive := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-updates:
+			isActive := s.getValue() > s.metadata.activationTargetValue
+			if isActive == wasActive {
+				continue
+			}
+			s.logger.V(1).Info("Activity changed", "metricName", s.metadata.metricName, "active", isActive)
+			select {
+			case active <- isActive:
+				wasActive = isActive
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
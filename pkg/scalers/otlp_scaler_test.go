@@ -0,0 +1,121 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/otlp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseOTLPMetadataTestData struct {
+	name     string
+	metadata map[string]string
+	isError  bool
+}
+
+var testOTLPMetadata = []parseOTLPMetadataTestData{
+	{name: "nothing passed", metadata: map[string]string{}, isError: true},
+	{name: "properly formed", metadata: map[string]string{"metricName": "queue_length", "targetValue": "10"}},
+	{name: "all parameters", metadata: map[string]string{"metricName": "queue_length", "attributes": "queue=orders, k8s.namespace.name=shop", "aggregation": "Max", "windowSeconds": "120", "targetValue": "10", "activationTargetValue": "2.5"}},
+	{name: "missing metricName", metadata: map[string]string{"targetValue": "10"}, isError: true},
+	{name: "missing targetValue", metadata: map[string]string{"metricName": "queue_length"}, isError: true},
+	{name: "invalid targetValue", metadata: map[string]string{"metricName": "queue_length", "targetValue": "a"}, isError: true},
+	{name: "invalid activationTargetValue", metadata: map[string]string{"metricName": "queue_length", "targetValue": "10", "activationTargetValue": "a"}, isError: true},
+	{name: "invalid attributes", metadata: map[string]string{"metricName": "queue_length", "targetValue": "10", "attributes": "queue"}, isError: true},
+	{name: "invalid aggregation", metadata: map[string]string{"metricName": "queue_length", "targetValue": "10", "aggregation": "p99"}, isError: true},
+	{name: "invalid windowSeconds", metadata: map[string]string{"metricName": "queue_length", "targetValue": "10", "windowSeconds": "a"}, isError: true},
+	{name: "windowSeconds above retention", metadata: map[string]string{"metricName": "queue_length", "targetValue": "10", "windowSeconds": "3600"}, isError: true},
+	{name: "zero windowSeconds", metadata: map[string]string{"metricName": "queue_length", "targetValue": "10", "windowSeconds": "0"}, isError: true},
+}
+
+func TestParseOTLPMetadata(t *testing.T) {
+	for _, testData := range testOTLPMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseOTLPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata}, otlp.DefaultRetention)
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	meta, err := parseOTLPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testOTLPMetadata[2].metadata}, otlp.DefaultRetention)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"queue": "orders", "k8s.namespace.name": "shop"}, meta.attributes)
+	assert.Equal(t, otlp.AggregationMax, meta.aggregation)
+	assert.Equal(t, 2*time.Minute, meta.window)
+}
+
+func TestOTLPGetMetricSpecForScaling(t *testing.T) {
+	scaler, err := NewOTLPScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testOTLPMetadata[1].metadata, TriggerIndex: 2})
+	assert.NoError(t, err)
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s2-otlp-queue_length", metricSpec[0].External.Metric.Name)
+}
+
+func TestOTLPGetMetricsAndActivity(t *testing.T) {
+	meta, err := parseOTLPMetadata(&scalersconfig.ScalerConfig{ScalableObjectNamespace: "shop", TriggerMetadata: map[string]string{"metricName": "queue_length", "attributes": "queue=orders", "targetValue": "10", "activationTargetValue": "5"}}, otlp.DefaultRetention)
+	assert.NoError(t, err)
+	store := otlp.NewStore(otlp.DefaultRetention)
+	scaler := otlpScaler{metadata: meta, store: store, logger: logr.Discard()}
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "otlp")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), metrics[0].Value.MilliValue(), "nothing received yet")
+	assert.False(t, active)
+
+	store.Add("shop", "queue_length", map[string]string{"queue": "orders", "pod": "a"}, 4)
+	store.Add("shop", "queue_length", map[string]string{"queue": "orders", "pod": "b"}, 3.5)
+	store.Add("shop", "queue_length", map[string]string{"queue": "emails", "pod": "a"}, 100)
+	store.Add("billing", "queue_length", map[string]string{"queue": "orders", "pod": "a"}, 100)
+
+	metrics, active, err = scaler.GetMetricsAndActivity(context.Background(), "otlp")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7500), metrics[0].Value.MilliValue())
+	assert.True(t, active)
+}
+
+func TestOTLPRun(t *testing.T) {
+	meta, err := parseOTLPMetadata(&scalersconfig.ScalerConfig{ScalableObjectNamespace: "shop", TriggerMetadata: map[string]string{"metricName": "queue_length", "targetValue": "10", "activationTargetValue": "5"}}, otlp.DefaultRetention)
+	assert.NoError(t, err)
+	store := otlp.NewStore(otlp.DefaultRetention)
+	scaler := otlpScaler{metadata: meta, store: store, logger: logr.Discard()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	active := make(chan bool)
+	go scaler.Run(ctx, active)
+
+	// the store is subscribed asynchronously, so keep pushing until the scaler reports the activation
+	expectActivity := func(value float64, expected bool) {
+		t.Helper()
+		timeout := time.After(10 * time.Second)
+		for {
+			store.Add("shop", "queue_length", nil, value)
+			select {
+			case isActive := <-active:
+				assert.Equal(t, expected, isActive)
+				return
+			case <-time.After(10 * time.Millisecond):
+			case <-timeout:
+				t.Fatalf("activity %t not reported", expected)
+			}
+		}
+	}
+	expectActivity(8, true)
+	expectActivity(1, false)
+
+	cancel()
+	select {
+	case _, ok := <-active:
+		assert.False(t, ok, "the channel is closed once the context is done")
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run didn't return")
+	}
+}
@@ -1,124 +1,49 @@
 package scalers
 
 import (
-	"context"
-	"database/sql"
 	"fmt"
-	"strconv"
 	"strings"
 
-	"github.com/go-logr/logr"
 	// PostreSQL drive required for this scaler
 	_ "github.com/jackc/pgx/v5/stdlib"
-	v2 "k8s.io/api/autoscaling/v2"
-	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
-	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-type postgreSQLScaler struct {
-	metricType v2.MetricTargetType
-	metadata   *postgreSQLMetadata
-	connection *sql.DB
-	logger     logr.Logger
-}
-
-type postgreSQLMetadata struct {
-	targetQueryValue           float64
-	activationTargetQueryValue float64
-	connection                 string
-	query                      string
-	triggerIndex               int
-}
-
-// NewPostgreSQLScaler creates a new postgreSQL scaler
-func NewPostgreSQLScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
-	metricType, err := GetMetricTargetType(config)
-	if err != nil {
-		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
-	}
-
-	logger := InitializeLogger(config, "postgresql_scaler")
-
-	meta, err := parsePostgreSQLMetadata(config)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing postgreSQL metadata: %w", err)
-	}
-
-	conn, err := getConnection(meta, logger)
-	if err != nil {
-		return nil, fmt.Errorf("error establishing postgreSQL connection: %w", err)
-	}
-	return &postgreSQLScaler{
-		metricType: metricType,
-		metadata:   meta,
-		connection: conn,
-		logger:     logger,
-	}, nil
-}
-
-func parsePostgreSQLMetadata(config *scalersconfig.ScalerConfig) (*postgreSQLMetadata, error) {
-	meta := postgreSQLMetadata{}
-
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no query given")
-	}
-
-	if val, ok := config.TriggerMetadata["targetQueryValue"]; ok {
-		targetQueryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("queryValue parsing error %w", err)
-		}
-		meta.targetQueryValue = targetQueryValue
-	} else {
-		if config.AsMetricSource {
-			meta.targetQueryValue = 0
-		} else {
-			return nil, fmt.Errorf("no targetQueryValue given")
-		}
-	}
-
-	meta.activationTargetQueryValue = 0
-	if val, ok := config.TriggerMetadata["activationTargetQueryValue"]; ok {
-		activationTargetQueryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("activationTargetQueryValue parsing error %w", err)
-		}
-		meta.activationTargetQueryValue = activationTargetQueryValue
-	}
+// The postgresql trigger is an alias of the sql trigger with the postgres driver, see sql_scaler.go
 
+// parsePostgreSQLConnection returns the connection string given in the auth params or the env,
+// or builds it from the host, port, userName, dbName, sslmode and password parameters
+func parsePostgreSQLConnection(config *scalersconfig.ScalerConfig) (string, error) {
 	switch {
 	case config.AuthParams["connection"] != "":
-		meta.connection = config.AuthParams["connection"]
+		return config.AuthParams["connection"], nil
 	case config.TriggerMetadata["connectionFromEnv"] != "":
-		meta.connection = config.ResolvedEnv[config.TriggerMetadata["connectionFromEnv"]]
+		return config.ResolvedEnv[config.TriggerMetadata["connectionFromEnv"]], nil
 	default:
 		host, err := GetFromAuthOrMeta(config, "host")
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		port, err := GetFromAuthOrMeta(config, "port")
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		userName, err := GetFromAuthOrMeta(config, "userName")
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		dbName, err := GetFromAuthOrMeta(config, "dbName")
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		sslmode, err := GetFromAuthOrMeta(config, "sslmode")
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		var password string
@@ -136,70 +61,8 @@ func parsePostgreSQLMetadata(config *scalersconfig.ScalerConfig) (*postgreSQLMet
 		params = append(params, "dbname="+escapePostgreConnectionParameter(dbName))
 		params = append(params, "sslmode="+escapePostgreConnectionParameter(sslmode))
 		params = append(params, "password="+escapePostgreConnectionParameter(password))
-		meta.connection = strings.Join(params, " ")
-	}
-	meta.triggerIndex = config.TriggerIndex
-	return &meta, nil
-}
-
-func getConnection(meta *postgreSQLMetadata, logger logr.Logger) (*sql.DB, error) {
-	db, err := sql.Open("pgx", meta.connection)
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Found error opening postgreSQL: %s", err))
-		return nil, err
-	}
-	err = db.Ping()
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Found error pinging postgreSQL: %s", err))
-		return nil, err
+		return strings.Join(params, " "), nil
 	}
-	return db, nil
-}
-
-// Close disposes of postgres connections
-func (s *postgreSQLScaler) Close(context.Context) error {
-	err := s.connection.Close()
-	if err != nil {
-		s.logger.Error(err, "Error closing postgreSQL connection")
-		return err
-	}
-	return nil
-}
-
-func (s *postgreSQLScaler) getActiveNumber(ctx context.Context) (float64, error) {
-	var id float64
-	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&id)
-	if err != nil {
-		s.logger.Error(err, fmt.Sprintf("could not query postgreSQL: %s", err))
-		return 0, fmt.Errorf("could not query postgreSQL: %w", err)
-	}
-	return id, nil
-}
-
-// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *postgreSQLScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	externalMetric := &v2.ExternalMetricSource{
-		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString("postgresql")),
-		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueryValue),
-	}
-	metricSpec := v2.MetricSpec{
-		External: externalMetric, Type: externalMetricType,
-	}
-	return []v2.MetricSpec{metricSpec}
-}
-
-// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
-func (s *postgreSQLScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	num, err := s.getActiveNumber(ctx)
-	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting postgreSQL: %w", err)
-	}
-
-	metric := GenerateMetricInMili(metricName, num)
-
-	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationTargetQueryValue, nil
 }
 
 func escapePostgreConnectionParameter(str string) string {
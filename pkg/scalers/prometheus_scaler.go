@@ -96,7 +96,7 @@ func NewPrometheusScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
 
 	if meta.prometheusAuth != nil {
-		if meta.prometheusAuth.CA != "" || meta.prometheusAuth.EnableTLS {
+		if meta.prometheusAuth.CA != "" || meta.prometheusAuth.EnableTLS || meta.prometheusAuth.EnableServiceAccountTokenAuth {
 			// create http.RoundTripper with auth settings from ScalerConfig
 			transport, err := authentication.CreateHTTPRoundTripper(
 				authentication.NetHTTP,
@@ -141,6 +141,15 @@ func NewPrometheusScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		if err == nil && awsTransport != nil {
 			httpClient.Transport = awsTransport
 		}
+
+		if config.PodIdentity.Provider == kedav1alpha1.PodIdentityProviderOIDC {
+			oidcTransport, err := authentication.NewOIDCRoundTripper(config.AuthParams, httpClient.Transport)
+			if err != nil {
+				logger.V(1).Error(err, "failed to get OIDC client HTTP transport")
+				return nil, err
+			}
+			httpClient.Transport = oidcTransport
+		}
 	}
 
 	return &prometheusScaler{
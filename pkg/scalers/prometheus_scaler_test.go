@@ -111,6 +111,12 @@ var testPrometheusAuthMetadata = []prometheusAuthMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "custom"}, map[string]string{"customAuthHeader": "header\n", "customAuthValue": "value\n"}, "", false},
 
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "tls,basic"}, map[string]string{"username": "user", "password": "pass"}, "", true},
+	// success serviceAccountToken
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "serviceAccountToken"}, map[string]string{"serviceAccountToken": "tooooken"}, "", false},
+	// fail serviceAccountToken with no token
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "serviceAccountToken"}, map[string]string{}, "", true},
+	// fail serviceAccountToken and bearer together
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "bearer,serviceAccountToken"}, map[string]string{"bearerToken": "tooooken", "serviceAccountToken": "tooooken"}, "", true},
 	// pod identity and other auth modes enabled together
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "basic"}, map[string]string{"username": "user", "password": "pass"}, "azure-workload", true},
 	// azure workload identity
@@ -166,7 +172,8 @@ func TestPrometheusScalerAuthParams(t *testing.T) {
 				if (meta.prometheusAuth.EnableBearerAuth && !strings.Contains(testData.metadata["authModes"], "bearer")) ||
 					(meta.prometheusAuth.EnableBasicAuth && !strings.Contains(testData.metadata["authModes"], "basic")) ||
 					(meta.prometheusAuth.EnableTLS && !strings.Contains(testData.metadata["authModes"], "tls")) ||
-					(meta.prometheusAuth.EnableCustomAuth && !strings.Contains(testData.metadata["authModes"], "custom")) {
+					(meta.prometheusAuth.EnableCustomAuth && !strings.Contains(testData.metadata["authModes"], "custom")) ||
+					(meta.prometheusAuth.EnableServiceAccountTokenAuth && !strings.Contains(testData.metadata["authModes"], "serviceAccountToken")) {
 					t.Error("wrong auth mode detected")
 				}
 			}
@@ -378,6 +385,55 @@ func TestPrometheusScalerCustomHeaders(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPrometheusScalerServiceAccountToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer sa-token", request.Header.Get("Authorization"))
+
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(`{"data":{"result":[]}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	scaler, err := NewPrometheusScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "threshold": "100", "query": "up", "ignoreNullValues": "true", "authModes": "serviceAccountToken"},
+		AuthParams:      map[string]string{"serviceAccountToken": "sa-token"},
+	})
+	assert.NoError(t, err)
+
+	_, err = scaler.(*prometheusScaler).ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+}
+
+func TestPrometheusScalerOIDCPodIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer oidc-token", request.Header.Get("Authorization"))
+
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(`{"data":{"result":[]}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "threshold": "100", "query": "up", "ignoreNullValues": "true"},
+		AuthParams:      map[string]string{"oidcAccessToken": "oidc-token"},
+		PodIdentity:     kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderOIDC},
+	}
+	scaler, err := NewPrometheusScaler(config)
+	assert.NoError(t, err)
+
+	_, err = scaler.(*prometheusScaler).ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+
+	// the access token has to be resolved by the oidc pod identity provider
+	config.AuthParams = map[string]string{}
+	_, err = NewPrometheusScaler(config)
+	assert.Error(t, err)
+}
+
 func TestPrometheusScalerExecutePromQueryParameters(t *testing.T) {
 	testData := prometheusQromQueryResultTestData{
 		name:             "no values",
@@ -37,6 +37,10 @@ const (
 	rabbitActivationValueTriggerConfigName = "activationValue"
 	rabbitModeQueueLength                  = "QueueLength"
 	rabbitModeMessageRate                  = "MessageRate"
+	rabbitModeUnacked                      = "Unacked"
+	rabbitModeConsumerUtilisation          = "ConsumerUtilisation"
+	rabbitModeStreamLag                    = "StreamLag"
+	rabbitQueueTypeStream                  = "stream"
 	defaultRabbitMQQueueLength             = 20
 	rabbitMetricType                       = "External"
 	rabbitRootVhostPath                    = "/%2F"
@@ -69,8 +73,8 @@ type rabbitMQScaler struct {
 
 type rabbitMQMetadata struct {
 	queueName             string
-	mode                  string        // QueueLength or MessageRate
-	value                 float64       // trigger value (queue length or publish/sec. rate)
+	mode                  string        // QueueLength, MessageRate, Unacked, ConsumerUtilisation or StreamLag
+	value                 float64       // trigger value (queue length, publish/sec. rate, unacked messages, consumer saturation or stream lag)
 	activationValue       float64       // activation value
 	host                  string        // connection string for either HTTP or AMQP protocol
 	protocol              string        // either http or amqp protocol
@@ -103,6 +107,13 @@ type queueInfo struct {
 	MessagesUnacknowledged int         `json:"messages_unacknowledged"`
 	MessageStat            messageStat `json:"message_stats"`
 	Name                   string      `json:"name"`
+	Type                   string      `json:"type"`
+	Consumers              int         `json:"consumers"`
+	ConsumerUtilisation    *float64    `json:"consumer_utilisation"`
+
+	// computed from the management API responses, so they can be aggregated across queues
+	consumerSaturation float64
+	streamLag          int64
 }
 
 type regexQueueInfo struct {
@@ -110,6 +121,13 @@ type regexQueueInfo struct {
 	TotalPages int         `json:"page_count"`
 }
 
+type streamConsumerInfo struct {
+	OffsetLag int64 `json:"offset_lag"`
+	Queue     struct {
+		Name string `json:"name"`
+	} `json:"queue"`
+}
+
 type messageStat struct {
 	PublishDetail publishDetail `json:"publish_details"`
 }
@@ -418,10 +436,10 @@ func parseTrigger(meta *rabbitMQMetadata, config *scalersconfig.ScalerConfig) (*
 	switch mode {
 	case rabbitModeQueueLength:
 		meta.mode = rabbitModeQueueLength
-	case rabbitModeMessageRate:
-		meta.mode = rabbitModeMessageRate
+	case rabbitModeMessageRate, rabbitModeUnacked, rabbitModeConsumerUtilisation, rabbitModeStreamLag:
+		meta.mode = mode
 	default:
-		return nil, fmt.Errorf("trigger mode %s must be one of %s, %s", mode, rabbitModeQueueLength, rabbitModeMessageRate)
+		return nil, fmt.Errorf("trigger mode %s must be one of %s, %s, %s, %s, %s", mode, rabbitModeQueueLength, rabbitModeMessageRate, rabbitModeUnacked, rabbitModeConsumerUtilisation, rabbitModeStreamLag)
 	}
 	triggerValue, err := strconv.ParseFloat(value, 64)
 	if err != nil {
@@ -429,8 +447,8 @@ func parseTrigger(meta *rabbitMQMetadata, config *scalersconfig.ScalerConfig) (*
 	}
 	meta.value = triggerValue
 
-	if meta.mode == rabbitModeMessageRate && meta.protocol != httpProtocol {
-		return nil, fmt.Errorf("protocol %s not supported; must be http to use mode %s", meta.protocol, rabbitModeMessageRate)
+	if meta.mode != rabbitModeQueueLength && meta.protocol != httpProtocol {
+		return nil, fmt.Errorf("protocol %s not supported; must be http to use mode %s", meta.protocol, meta.mode)
 	}
 
 	return meta, nil
@@ -479,6 +497,7 @@ func (s *rabbitMQScaler) Close(context.Context) error {
 	return nil
 }
 
+// getQueueStatus returns the number of messages in the queue and the value of the trigger mode
 func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, error) {
 	if s.metadata.protocol == httpProtocol {
 		info, err := s.getQueueInfoViaHTTP(ctx)
@@ -486,12 +505,25 @@ func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, er
 			return -1, -1, err
 		}
 
+		// messages count includes count of ready and unack-ed
+		messages := int64(info.Messages)
 		if s.metadata.excludeUnacknowledged {
 			// messages count includes only ready
-			return int64(info.MessagesReady), info.MessageStat.PublishDetail.Rate, nil
+			messages = int64(info.MessagesReady)
+		}
+
+		switch s.metadata.mode {
+		case rabbitModeMessageRate:
+			return messages, info.MessageStat.PublishDetail.Rate, nil
+		case rabbitModeUnacked:
+			return messages, float64(info.MessagesUnacknowledged), nil
+		case rabbitModeConsumerUtilisation:
+			return messages, info.consumerSaturation, nil
+		case rabbitModeStreamLag:
+			return messages, float64(info.streamLag), nil
+		default:
+			return messages, float64(messages), nil
 		}
-		// messages count includes count of ready and unack-ed
-		return int64(info.Messages), info.MessageStat.PublishDetail.Rate, nil
 	}
 
 	// QueueDeclarePassive assumes that the queue exists and fails if it doesn't
@@ -500,15 +532,13 @@ func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, er
 		return -1, -1, err
 	}
 
-	return int64(items.Messages), 0, nil
+	return int64(items.Messages), float64(items.Messages), nil
 }
 
-func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, error) {
-	var result queueInfo
-
+func getJSON(ctx context.Context, s *rabbitMQScaler, url string, result interface{}) error {
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return result, err
+		return err
 	}
 
 	if s.metadata.workloadIdentityResource != "" {
@@ -518,7 +548,7 @@ func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, err
 
 		err = s.azureOAuth.Refresh()
 		if err != nil {
-			return result, err
+			return err
 		}
 
 		request.Header.Set("Authorization", "Bearer "+s.azureOAuth.OAuthToken())
@@ -526,31 +556,17 @@ func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, err
 
 	r, err := s.httpClient.Do(request)
 	if err != nil {
-		return result, err
+		return err
 	}
 
 	defer r.Body.Close()
 
 	if r.StatusCode == 200 {
-		if s.metadata.useRegex {
-			var queues regexQueueInfo
-			err = json.NewDecoder(r.Body).Decode(&queues)
-			if err != nil {
-				return queueInfo{}, err
-			}
-			if queues.TotalPages > 1 {
-				return queueInfo{}, fmt.Errorf("regex matches more queues than can be recovered at once")
-			}
-			result, err := getComposedQueue(s, queues.Queues)
-			return result, err
-		}
-
-		err = json.NewDecoder(r.Body).Decode(&result)
-		return result, err
+		return json.NewDecoder(r.Body).Decode(result)
 	}
 
 	body, _ := io.ReadAll(r.Body)
-	return result, fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
+	return fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
 }
 
 func getVhostAndPathFromURL(rawPath, vhostName string) (resolvedVhostPath, resolvedPath string) {
@@ -581,23 +597,94 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP(ctx context.Context) (*queueInfo, e
 	vhost, subpaths := getVhostAndPathFromURL(parsedURL.Path, s.metadata.vhostName)
 	parsedURL.Path = subpaths
 
-	var getQueueInfoManagementURI string
+	var queues []queueInfo
 	if s.metadata.useRegex {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName), s.metadata.pageSize)
+		getQueueInfoManagementURI := fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName), s.metadata.pageSize)
+		var regexQueues regexQueueInfo
+		if err := getJSON(ctx, s, getQueueInfoManagementURI, &regexQueues); err != nil {
+			return nil, err
+		}
+		if regexQueues.TotalPages > 1 {
+			return nil, fmt.Errorf("regex matches more queues than can be recovered at once")
+		}
+		queues = regexQueues.Queues
 	} else {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName))
+		getQueueInfoManagementURI := fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName))
+		var info queueInfo
+		if err := getJSON(ctx, s, getQueueInfoManagementURI, &info); err != nil {
+			return nil, err
+		}
+		queues = []queueInfo{info}
 	}
 
-	var info queueInfo
-	info, err = getJSON(ctx, s, getQueueInfoManagementURI)
+	if s.metadata.mode == rabbitModeConsumerUtilisation {
+		for i := range queues {
+			if queues[i].consumerSaturation, err = getConsumerSaturation(queues[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if s.metadata.mode == rabbitModeStreamLag {
+		if err := s.setStreamLags(ctx, fmt.Sprintf("%s/api/stream/consumers%s", parsedURL.String(), vhost), queues); err != nil {
+			return nil, err
+		}
+	}
 
+	if !s.metadata.useRegex {
+		return &queues[0], nil
+	}
+	info, err := getComposedQueue(s, queues)
 	if err != nil {
 		return nil, err
 	}
-
 	return &info, nil
 }
 
+// getConsumerSaturation returns the percentage of time the consumers of the queue can't take the messages, i.e. 100 minus the
+// consumer utilisation reported by the management API, so that it grows when the consumers don't keep up like the other modes.
+// A queue without consumer is saturated as soon as it has messages. The management API doesn't report the utilisation of
+// every queue type, e.g. quorum queues, which can't be used with this mode.
+func getConsumerSaturation(q queueInfo) (float64, error) {
+	if q.Consumers == 0 {
+		if q.Messages > 0 {
+			return 100, nil
+		}
+		return 0, nil
+	}
+	if q.ConsumerUtilisation == nil {
+		return 0, fmt.Errorf("queue %s has no consumer_utilisation, mode %s isn't supported by its type %s", q.Name, rabbitModeConsumerUtilisation, q.Type)
+	}
+	return (1 - *q.ConsumerUtilisation) * 100, nil
+}
+
+// setStreamLags sets the lag of the slowest consumer of each stream, read from the stream consumers of the management API.
+// The offset of a consumer is only known while it is connected, so the lag of a stream without consumer is its length,
+// as a consumer started when the target scales out may have to read the whole stream.
+func (s *rabbitMQScaler) setStreamLags(ctx context.Context, streamConsumersURI string, queues []queueInfo) error {
+	var consumers []streamConsumerInfo
+	if err := getJSON(ctx, s, streamConsumersURI, &consumers); err != nil {
+		return err
+	}
+
+	for i := range queues {
+		if queues[i].Type != rabbitQueueTypeStream {
+			return fmt.Errorf("queue %s is of type %s, mode %s requires x-queue-type %s", queues[i].Name, queues[i].Type, rabbitModeStreamLag, rabbitQueueTypeStream)
+		}
+		hasConsumer := false
+		for _, consumer := range consumers {
+			if consumer.Queue.Name != queues[i].Name {
+				continue
+			}
+			hasConsumer = true
+			queues[i].streamLag = max(queues[i].streamLag, consumer.OffsetLag)
+		}
+		if !hasConsumer {
+			queues[i].streamLag = int64(queues[i].Messages)
+		}
+	}
+	return nil
+}
+
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
@@ -615,19 +702,15 @@ func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	messages, publishRate, err := s.getQueueStatus(ctx)
+	messages, value, err := s.getQueueStatus(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, s.anonymizeRabbitMQError(err)
 	}
 
-	var metric external_metrics.ExternalMetricValue
-	var isActive bool
-	if s.metadata.mode == rabbitModeQueueLength {
-		metric = GenerateMetricInMili(metricName, float64(messages))
-		isActive = float64(messages) > s.metadata.activationValue
-	} else {
-		metric = GenerateMetricInMili(metricName, publishRate)
-		isActive = publishRate > s.metadata.activationValue || float64(messages) > s.metadata.activationValue
+	metric := GenerateMetricInMili(metricName, value)
+	isActive := value > s.metadata.activationValue
+	if s.metadata.mode == rabbitModeMessageRate {
+		isActive = isActive || float64(messages) > s.metadata.activationValue
 	}
 
 	return []external_metrics.ExternalMetricValue{metric}, isActive, nil
@@ -635,70 +718,59 @@ func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 
 func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	var queue = queueInfo{}
-	queue.Name = "composed-queue"
-	queue.MessagesUnacknowledged = 0
 	if len(q) > 0 {
 		switch s.metadata.operation {
 		case sumOperation:
-			sumMessages, sumReady, sumRate := getSum(q)
-			queue.Messages = sumMessages
-			queue.MessagesReady = sumReady
-			queue.MessageStat.PublishDetail.Rate = sumRate
+			queue = getSum(q)
 		case avgOperation:
-			avgMessages, avgReady, avgRate := getAverage(q)
-			queue.Messages = avgMessages
-			queue.MessagesReady = avgReady
-			queue.MessageStat.PublishDetail.Rate = avgRate
+			queue = getAverage(q)
 		case maxOperation:
-			maxMessages, maxReady, maxRate := getMaximum(q)
-			queue.Messages = maxMessages
-			queue.MessagesReady = maxReady
-			queue.MessageStat.PublishDetail.Rate = maxRate
+			queue = getMaximum(q)
 		default:
 			return queue, fmt.Errorf("operation mode %s must be one of %s, %s, %s", s.metadata.operation, sumOperation, avgOperation, maxOperation)
 		}
-	} else {
-		queue.Messages = 0
-		queue.MessageStat.PublishDetail.Rate = 0
 	}
+	queue.Name = "composed-queue"
 
 	return queue, nil
 }
 
-func getSum(q []queueInfo) (int, int, float64) {
-	var sumMessages int
-	var sumMessagesReady int
-	var sumRate float64
+func getSum(q []queueInfo) queueInfo {
+	var sum queueInfo
 	for _, value := range q {
-		sumMessages += value.Messages
-		sumMessagesReady += value.MessagesReady
-		sumRate += value.MessageStat.PublishDetail.Rate
-	}
-	return sumMessages, sumMessagesReady, sumRate
+		sum.Messages += value.Messages
+		sum.MessagesReady += value.MessagesReady
+		sum.MessagesUnacknowledged += value.MessagesUnacknowledged
+		sum.MessageStat.PublishDetail.Rate += value.MessageStat.PublishDetail.Rate
+		sum.consumerSaturation += value.consumerSaturation
+		sum.streamLag += value.streamLag
+	}
+	return sum
 }
 
-func getAverage(q []queueInfo) (int, int, float64) {
-	sumMessages, sumReady, sumRate := getSum(q)
+func getAverage(q []queueInfo) queueInfo {
+	avg := getSum(q)
 	length := len(q)
-	return sumMessages / length, sumReady / length, sumRate / float64(length)
+	avg.Messages /= length
+	avg.MessagesReady /= length
+	avg.MessagesUnacknowledged /= length
+	avg.MessageStat.PublishDetail.Rate /= float64(length)
+	avg.consumerSaturation /= float64(length)
+	avg.streamLag /= int64(length)
+	return avg
 }
 
-func getMaximum(q []queueInfo) (int, int, float64) {
-	var maxMessages int
-	var maxReady int
-	var maxRate float64
+func getMaximum(q []queueInfo) queueInfo {
+	var maximum queueInfo
 	for _, value := range q {
-		if value.Messages > maxMessages {
-			maxMessages = value.Messages
-		}
-		if value.MessagesReady > maxReady {
-			maxReady = value.MessagesReady
-		}
-		if value.MessageStat.PublishDetail.Rate > maxRate {
-			maxRate = value.MessageStat.PublishDetail.Rate
-		}
-	}
-	return maxMessages, maxReady, maxRate
+		maximum.Messages = max(maximum.Messages, value.Messages)
+		maximum.MessagesReady = max(maximum.MessagesReady, value.MessagesReady)
+		maximum.MessagesUnacknowledged = max(maximum.MessagesUnacknowledged, value.MessagesUnacknowledged)
+		maximum.MessageStat.PublishDetail.Rate = max(maximum.MessageStat.PublishDetail.Rate, value.MessageStat.PublishDetail.Rate)
+		maximum.consumerSaturation = max(maximum.consumerSaturation, value.consumerSaturation)
+		maximum.streamLag = max(maximum.streamLag, value.streamLag)
+	}
+	return maximum
 }
 
 // Mask host for log purposes
@@ -135,6 +135,18 @@ var testRabbitMQMetadata = []parseRabbitMQMetadataTestData{
 	{map[string]string{"queueName": "sample", "host": "https://", "unsafeSsl": "true"}, false, map[string]string{}},
 	// unsafeSsl wrong input
 	{map[string]string{"queueName": "sample", "host": "https://", "unsafeSsl": "random"}, true, map[string]string{}},
+	// unacked http
+	{map[string]string{"mode": "Unacked", "value": "10", "queueName": "sample", "host": "http://"}, false, map[string]string{}},
+	// unacked amqp
+	{map[string]string{"mode": "Unacked", "value": "10", "queueName": "sample", "host": "amqp://"}, true, map[string]string{}},
+	// consumer utilisation http and useRegex
+	{map[string]string{"mode": "ConsumerUtilisation", "value": "50", "queueName": "sample", "host": "http://", "useRegex": "true", "operation": "max"}, false, map[string]string{}},
+	// consumer utilisation amqp
+	{map[string]string{"mode": "ConsumerUtilisation", "value": "50", "queueName": "sample", "host": "amqp://"}, true, map[string]string{}},
+	// stream lag http
+	{map[string]string{"mode": "StreamLag", "value": "1000", "queueName": "sample", "host": "http://"}, false, map[string]string{}},
+	// stream lag amqp
+	{map[string]string{"mode": "StreamLag", "value": "1000", "queueName": "sample", "host": "amqp://"}, true, map[string]string{}},
 }
 
 var testRabbitMQAuthParamData = []parseRabbitMQAuthParamTestData{
@@ -182,6 +194,11 @@ func TestRabbitMQParseMetadata(t *testing.T) {
 	}
 }
 
+func TestRabbitMQParseMetadataModeRequiresHTTP(t *testing.T) {
+	_, err := parseRabbitMQMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: sampleRabbitMqResolvedEnv, TriggerMetadata: map[string]string{"mode": "StreamLag", "value": "1000", "queueName": "sample", "host": "amqp://"}})
+	assert.ErrorContains(t, err, "must be http to use mode StreamLag")
+}
+
 func TestRabbitMQParseAuthParamData(t *testing.T) {
 	for _, testData := range testRabbitMQAuthParamData {
 		metadata, err := parseRabbitMQMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: sampleRabbitMqResolvedEnv, TriggerMetadata: testData.metadata, AuthParams: testData.authParams, PodIdentity: testData.podIdentity})
@@ -746,3 +763,136 @@ func TestRegexQueueMissingError(t *testing.T) {
 		}
 	}
 }
+
+type rabbitMQModeTestData struct {
+	name            string
+	queues          string
+	streamConsumers string
+	metadata        map[string]string
+	value           float64
+	isActive        bool
+	isError         bool
+}
+
+var testRabbitMQModeTestData = []rabbitMQModeTestData{
+	{
+		name:     "unacked",
+		queues:   `{"messages": 10, "messages_ready": 4, "messages_unacknowledged": 6, "type": "quorum", "name": "evaluate_trials"}`,
+		metadata: map[string]string{"mode": "Unacked", "value": "5"},
+		value:    6,
+		isActive: true,
+	},
+	{
+		name:     "unacked with regex",
+		queues:   `{"items":[{"messages": 10, "messages_unacknowledged": 6, "name": "evaluate_trials"},{"messages": 3, "messages_unacknowledged": 2, "name": "evaluate_trial2"}]}`,
+		metadata: map[string]string{"mode": "Unacked", "value": "5", "useRegex": "true", "operation": "avg"},
+		value:    4,
+		isActive: true,
+	},
+	{
+		name:     "consumer utilisation",
+		queues:   `{"messages": 10, "consumers": 2, "consumer_utilisation": 0.25, "name": "evaluate_trials"}`,
+		metadata: map[string]string{"mode": "ConsumerUtilisation", "value": "50"},
+		value:    75,
+		isActive: true,
+	},
+	{
+		name:     "consumer utilisation without consumer",
+		queues:   `{"messages": 10, "consumers": 0, "name": "evaluate_trials"}`,
+		metadata: map[string]string{"mode": "ConsumerUtilisation", "value": "50"},
+		value:    100,
+		isActive: true,
+	},
+	{
+		name:     "consumer utilisation of idle queue",
+		queues:   `{"messages": 0, "consumers": 0, "name": "evaluate_trials"}`,
+		metadata: map[string]string{"mode": "ConsumerUtilisation", "value": "50"},
+		value:    0,
+	},
+	{
+		name:     "consumer utilisation not reported",
+		queues:   `{"messages": 10, "consumers": 2, "type": "quorum", "name": "evaluate_trials"}`,
+		metadata: map[string]string{"mode": "ConsumerUtilisation", "value": "50"},
+		isError:  true,
+	},
+	{
+		name:     "consumer utilisation with regex",
+		queues:   `{"items":[{"messages": 10, "consumers": 1, "consumer_utilisation": 1, "name": "evaluate_trials"},{"messages": 3, "consumers": 1, "consumer_utilisation": 0.5, "name": "evaluate_trial2"}]}`,
+		metadata: map[string]string{"mode": "ConsumerUtilisation", "value": "50", "useRegex": "true", "operation": "max"},
+		value:    50,
+		isActive: true,
+	},
+	{
+		name:            "stream lag",
+		queues:          `{"messages": 100000, "type": "stream", "name": "evaluate_trials"}`,
+		streamConsumers: `[{"offset_lag": 30, "queue": {"name": "evaluate_trials", "vhost": "/"}},{"offset_lag": 120, "queue": {"name": "evaluate_trials", "vhost": "/"}},{"offset_lag": 500, "queue": {"name": "other", "vhost": "/"}}]`,
+		metadata:        map[string]string{"mode": "StreamLag", "value": "100"},
+		value:           120,
+		isActive:        true,
+	},
+	{
+		name:            "stream lag without consumer",
+		queues:          `{"messages": 100000, "type": "stream", "name": "evaluate_trials"}`,
+		streamConsumers: `[{"offset_lag": 500, "queue": {"name": "other", "vhost": "/"}}]`,
+		metadata:        map[string]string{"mode": "StreamLag", "value": "100"},
+		value:           100000,
+		isActive:        true,
+	},
+	{
+		name:            "stream lag with regex",
+		queues:          `{"items":[{"messages": 100000, "type": "stream", "name": "evaluate_trials"},{"messages": 100000, "type": "stream", "name": "evaluate_trial2"}]}`,
+		streamConsumers: `[{"offset_lag": 30, "queue": {"name": "evaluate_trials", "vhost": "/"}},{"offset_lag": 50, "queue": {"name": "evaluate_trial2", "vhost": "/"}}]`,
+		metadata:        map[string]string{"mode": "StreamLag", "value": "100", "useRegex": "true", "operation": "sum", "activationValue": "90"},
+		value:           80,
+	},
+	{
+		name:            "stream lag of a classic queue",
+		queues:          `{"messages": 10, "type": "classic", "name": "evaluate_trials"}`,
+		streamConsumers: `[]`,
+		metadata:        map[string]string{"mode": "StreamLag", "value": "100"},
+		isError:         true,
+	},
+}
+
+func TestRabbitMQGetMetricsWithModes(t *testing.T) {
+	for _, testData := range testRabbitMQModeTestData {
+		t.Run(testData.name, func(t *testing.T) {
+			var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.EscapedPath() {
+				case "/api/queues/%2F", "/api/queues/%2F/evaluate_trials":
+					_, _ = w.Write([]byte(testData.queues))
+				case "/api/stream/consumers/%2F":
+					_, _ = w.Write([]byte(testData.streamConsumers))
+				default:
+					t.Error("Unexpected request path", r.URL.EscapedPath())
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer apiStub.Close()
+
+			metadata := map[string]string{
+				"queueName": "evaluate_trials",
+				"host":      apiStub.URL,
+			}
+			for k, v := range testData.metadata {
+				metadata[k] = v
+			}
+
+			s, err := NewRabbitMQScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, GlobalHTTPTimeout: 1000 * time.Millisecond})
+			if err != nil {
+				t.Fatal("Expect success", err)
+			}
+
+			metrics, active, err := s.GetMetricsAndActivity(context.Background(), "Metric")
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatal("Expect success", err)
+			}
+			assert.Equal(t, int64(testData.value*1000), metrics[0].Value.MilliValue())
+			assert.Equal(t, testData.isActive, active)
+		})
+	}
+}
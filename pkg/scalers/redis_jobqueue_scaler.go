@@ -0,0 +1,380 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	redisJobQueueFrameworkSidekiq = "sidekiq"
+	redisJobQueueFrameworkCelery  = "celery"
+	redisJobQueueFrameworkBullMQ  = "bullmq"
+	redisJobQueueFrameworkRQ      = "rq"
+
+	defaultRedisJobQueueJobCount = 5
+	defaultBullMQKeyPrefix       = "bull:"
+	defaultCeleryPrioritySteps   = "0,3,6,9"
+
+	// celeryPrioritySeparator separates the queue name and the priority step in the names of kombu priority lists
+	celeryPrioritySeparator = "\x06\x16"
+
+	// sidekiqDueJobsScript counts the jobs of the sorted set KEYS[1] due before ARGV[1] and enqueued to the queue ARGV[2].
+	// Jobs are scanned in batches on the server and only the jobs containing the queue name are decoded,
+	// so the jobs of busy schedule and retry sets are neither sent to KEDA nor all decoded.
+	sidekiqDueJobsScript = `
+local count = 0
+local offset = 0
+local batch = 1000
+local pattern = '"queue":"' .. ARGV[2] .. '"'
+while true do
+	local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', offset, batch)
+	for _, job in ipairs(jobs) do
+		if string.find(job, pattern, 1, true) then
+			local ok, decoded = pcall(cjson.decode, job)
+			if ok and type(decoded) == 'table' and decoded['queue'] == ARGV[2] then
+				count = count + 1
+			end
+		end
+	end
+	if #jobs < batch then
+		return count
+	end
+	offset = offset + batch
+end`
+)
+
+// ErrRedisJobQueueNoQueueName is returned when "queueName" is missing from the config.
+var ErrRedisJobQueueNoQueueName = errors.New("no queue name given")
+
+// redisJobQueueClient is the subset of the redis commands used to count jobs,
+// implemented by standalone, sentinel and cluster clients
+type redisJobQueueClient interface {
+	LLen(ctx context.Context, key string) *redis.IntCmd
+	ZCard(ctx context.Context, key string) *redis.IntCmd
+	ZCount(ctx context.Context, key, min, max string) *redis.IntCmd
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+	Close() error
+}
+
+type redisJobQueueScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *redisJobQueueMetadata
+	client     redisJobQueueClient
+	now        func() time.Time
+	logger     logr.Logger
+}
+
+type redisJobQueueMetadata struct {
+	framework          string
+	queueName          string
+	keyPrefix          string
+	jobCount           int64
+	activationJobCount int64
+	includeDelayed     bool
+	includeRetries     bool
+	prioritySteps      []string
+	databaseIndex      int
+	connectionInfo     redisConnectionInfo
+	triggerIndex       int
+}
+
+// NewRedisJobQueueScaler creates a new redisJobQueueScaler
+func NewRedisJobQueueScaler(ctx context.Context, isClustered, isSentinel bool, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "redis_jobqueue_scaler")
+
+	parserFn := parseRedisAddress
+	if isClustered {
+		parserFn = parseRedisClusterAddress
+	} else if isSentinel {
+		parserFn = parseRedisSentinelAddress
+	}
+	meta, err := parseRedisJobQueueMetadata(config, parserFn)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing redis jobqueue metadata: %w", err)
+	}
+
+	var client redisJobQueueClient
+	switch {
+	case isClustered:
+		client, err = getRedisClusterClient(ctx, meta.connectionInfo)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis cluster failed: %w", err)
+		}
+	case isSentinel:
+		client, err = getRedisSentinelClient(ctx, meta.connectionInfo, meta.databaseIndex)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis sentinel failed: %w", err)
+		}
+	default:
+		client, err = getRedisClient(ctx, meta.connectionInfo, meta.databaseIndex)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis failed: %w", err)
+		}
+	}
+
+	return &redisJobQueueScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		now:        time.Now,
+		logger:     logger,
+	}, nil
+}
+
+func parseRedisJobQueueMetadata(config *scalersconfig.ScalerConfig, parserFn redisAddressParser) (*redisJobQueueMetadata, error) {
+	connInfo, err := parserFn(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams)
+	if err != nil {
+		return nil, err
+	}
+	meta := redisJobQueueMetadata{
+		connectionInfo: connInfo,
+	}
+
+	err = parseTLSConfigIntoConnectionInfo(config, &meta.connectionInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.framework = strings.ToLower(config.TriggerMetadata["framework"])
+	switch meta.framework {
+	case redisJobQueueFrameworkSidekiq, redisJobQueueFrameworkCelery, redisJobQueueFrameworkRQ:
+	case redisJobQueueFrameworkBullMQ:
+		meta.keyPrefix = defaultBullMQKeyPrefix
+	case "":
+		return nil, errors.New("no framework given")
+	default:
+		return nil, fmt.Errorf("framework must be one of %s, %s, %s or %s, got %s", redisJobQueueFrameworkSidekiq,
+			redisJobQueueFrameworkCelery, redisJobQueueFrameworkBullMQ, redisJobQueueFrameworkRQ, meta.framework)
+	}
+
+	if val, ok := config.TriggerMetadata["queueName"]; ok && val != "" {
+		meta.queueName = val
+	} else {
+		return nil, ErrRedisJobQueueNoQueueName
+	}
+
+	if val, ok := config.TriggerMetadata["keyPrefix"]; ok {
+		meta.keyPrefix = val
+	}
+
+	meta.jobCount = defaultRedisJobQueueJobCount
+	if val, ok := config.TriggerMetadata["jobCount"]; ok {
+		jobCount, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("jobCount parsing error: %w", err)
+		}
+		meta.jobCount = jobCount
+	}
+
+	meta.activationJobCount = 0
+	if val, ok := config.TriggerMetadata["activationJobCount"]; ok {
+		activationJobCount, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("activationJobCount parsing error: %w", err)
+		}
+		meta.activationJobCount = activationJobCount
+	}
+
+	meta.includeDelayed = true
+	if val, ok := config.TriggerMetadata["includeDelayed"]; ok {
+		includeDelayed, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("includeDelayed parsing error: %w", err)
+		}
+		meta.includeDelayed = includeDelayed
+	}
+
+	meta.includeRetries = true
+	if val, ok := config.TriggerMetadata["includeRetries"]; ok {
+		includeRetries, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("includeRetries parsing error: %w", err)
+		}
+		meta.includeRetries = includeRetries
+	}
+
+	if meta.framework == redisJobQueueFrameworkCelery {
+		prioritySteps := defaultCeleryPrioritySteps
+		if val, ok := config.TriggerMetadata["prioritySteps"]; ok && val != "" {
+			prioritySteps = val
+		}
+		for _, step := range splitAndTrim(prioritySteps) {
+			if _, err := strconv.Atoi(step); err != nil {
+				return nil, fmt.Errorf("prioritySteps parsing error: %w", err)
+			}
+			meta.prioritySteps = append(meta.prioritySteps, step)
+		}
+	}
+
+	meta.databaseIndex = defaultDBIdx
+	if val, ok := config.TriggerMetadata["databaseIndex"]; ok {
+		dbIndex, err := strconv.ParseInt(val, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("databaseIndex: parsing error %w", err)
+		}
+		meta.databaseIndex = int(dbIndex)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return &meta, nil
+}
+
+func (s *redisJobQueueScaler) Close(context.Context) error {
+	if err := s.client.Close(); err != nil {
+		s.logger.Error(err, "error closing redis client")
+		return err
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *redisJobQueueScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("redis-jobqueue-%s-%s", s.metadata.framework, s.metadata.queueName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.jobCount),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity connects to Redis and counts the jobs waiting to be processed
+func (s *redisJobQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	jobCount, err := s.getJobCount(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting job count")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(jobCount))
+
+	return []external_metrics.ExternalMetricValue{metric}, jobCount > s.metadata.activationJobCount, nil
+}
+
+// getJobCount counts the ready jobs of the queue, plus the delayed and retried jobs which are due.
+// Due jobs are only moved to the ready jobs by a running worker, so they are counted to scale from zero.
+func (s *redisJobQueueScaler) getJobCount(ctx context.Context) (int64, error) {
+	switch s.metadata.framework {
+	case redisJobQueueFrameworkSidekiq:
+		return s.getSidekiqJobCount(ctx)
+	case redisJobQueueFrameworkCelery:
+		return s.getCeleryJobCount(ctx)
+	case redisJobQueueFrameworkBullMQ:
+		return s.getBullMQJobCount(ctx)
+	default:
+		return s.getRQJobCount(ctx)
+	}
+}
+
+// getSidekiqJobCount counts the jobs of the queue:<name> list and the due jobs of the queue in the schedule and retry sorted sets,
+// which are shared by all queues and scored by the time the job is due in seconds, so their jobs are filtered by queue on the server
+func (s *redisJobQueueScaler) getSidekiqJobCount(ctx context.Context) (int64, error) {
+	prefix := s.metadata.keyPrefix
+	count, err := s.client.LLen(ctx, prefix+"queue:"+s.metadata.queueName).Result()
+	if err != nil {
+		return -1, err
+	}
+
+	now := strconv.FormatFloat(float64(s.now().UnixMilli())/1000, 'f', 3, 64)
+	var sets []string
+	if s.metadata.includeDelayed {
+		sets = append(sets, prefix+"schedule")
+	}
+	if s.metadata.includeRetries {
+		sets = append(sets, prefix+"retry")
+	}
+	// each set is counted by its own script call, as the sets can be stored in different slots of a cluster
+	for _, set := range sets {
+		due, err := s.client.Eval(ctx, sidekiqDueJobsScript, []string{set}, now, s.metadata.queueName).Int64()
+		if err != nil {
+			return -1, err
+		}
+		count += due
+	}
+	return count, nil
+}
+
+// getCeleryJobCount counts the jobs of the kombu priority lists of the queue, the list of the
+// first step is named after the queue and the others have the step appended after a separator
+func (s *redisJobQueueScaler) getCeleryJobCount(ctx context.Context) (int64, error) {
+	var count int64
+	for i, step := range s.metadata.prioritySteps {
+		key := s.metadata.keyPrefix + s.metadata.queueName
+		if i > 0 {
+			key += celeryPrioritySeparator + step
+		}
+		length, err := s.client.LLen(ctx, key).Result()
+		if err != nil {
+			return -1, err
+		}
+		count += length
+	}
+	return count, nil
+}
+
+// getBullMQJobCount counts the jobs of the wait list and the prioritized sorted set, and the due jobs of the delayed
+// sorted set, which is scored by the time the job is due in milliseconds shifted left by 12 bits to hold a counter
+func (s *redisJobQueueScaler) getBullMQJobCount(ctx context.Context) (int64, error) {
+	prefix := s.metadata.keyPrefix + s.metadata.queueName + ":"
+	count, err := s.client.LLen(ctx, prefix+"wait").Result()
+	if err != nil {
+		return -1, err
+	}
+
+	prioritized, err := s.client.ZCard(ctx, prefix+"prioritized").Result()
+	if err != nil {
+		return -1, err
+	}
+	count += prioritized
+
+	// failed jobs with a backoff are retried through the delayed set
+	if s.metadata.includeDelayed || s.metadata.includeRetries {
+		maxScore := strconv.FormatInt(s.now().UnixMilli()*0x1000+0xfff, 10)
+		delayed, err := s.client.ZCount(ctx, prefix+"delayed", "-inf", maxScore).Result()
+		if err != nil {
+			return -1, err
+		}
+		count += delayed
+	}
+	return count, nil
+}
+
+// getRQJobCount counts the jobs of the rq:queue:<name> list and the due jobs of the rq:scheduled:<name>
+// sorted set, which is scored by the time the job is due in seconds
+func (s *redisJobQueueScaler) getRQJobCount(ctx context.Context) (int64, error) {
+	prefix := s.metadata.keyPrefix
+	count, err := s.client.LLen(ctx, prefix+"rq:queue:"+s.metadata.queueName).Result()
+	if err != nil {
+		return -1, err
+	}
+
+	// jobs retried with an interval are scheduled
+	if s.metadata.includeDelayed || s.metadata.includeRetries {
+		scheduled, err := s.client.ZCount(ctx, prefix+"rq:scheduled:"+s.metadata.queueName, "-inf", strconv.FormatInt(s.now().Unix(), 10)).Result()
+		if err != nil {
+			return -1, err
+		}
+		count += scheduled
+	}
+	return count, nil
+}
@@ -0,0 +1,232 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseRedisJobQueueMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testRedisJobQueueMetadata = []parseRedisJobQueueMetadataTestData{
+	{"nothing passed", map[string]string{}, map[string]string{}, true},
+	{"sidekiq", map[string]string{"framework": "sidekiq", "queueName": "default", "address": "localhost:6379"}, map[string]string{}, false},
+	{"celery with priority steps", map[string]string{"framework": "celery", "queueName": "celery", "prioritySteps": "0,5"}, map[string]string{"address": "localhost:6379"}, false},
+	{"bullmq with key prefix", map[string]string{"framework": "bullmq", "queueName": "emails", "keyPrefix": "app:"}, map[string]string{"host": "localhost", "port": "6379"}, false},
+	{"rq", map[string]string{"framework": "rq", "queueName": "high", "address": "localhost:6379", "jobCount": "10", "activationJobCount": "2"}, map[string]string{}, false},
+	{"missing framework", map[string]string{"queueName": "default", "address": "localhost:6379"}, map[string]string{}, true},
+	{"unknown framework", map[string]string{"framework": "resque", "queueName": "default", "address": "localhost:6379"}, map[string]string{}, true},
+	{"missing queueName", map[string]string{"framework": "sidekiq", "address": "localhost:6379"}, map[string]string{}, true},
+	{"missing address", map[string]string{"framework": "sidekiq", "queueName": "default"}, map[string]string{}, true},
+	{"invalid jobCount", map[string]string{"framework": "sidekiq", "queueName": "default", "address": "localhost:6379", "jobCount": "a"}, map[string]string{}, true},
+	{"invalid activationJobCount", map[string]string{"framework": "sidekiq", "queueName": "default", "address": "localhost:6379", "activationJobCount": "a"}, map[string]string{}, true},
+	{"invalid includeDelayed", map[string]string{"framework": "sidekiq", "queueName": "default", "address": "localhost:6379", "includeDelayed": "a"}, map[string]string{}, true},
+	{"invalid includeRetries", map[string]string{"framework": "sidekiq", "queueName": "default", "address": "localhost:6379", "includeRetries": "a"}, map[string]string{}, true},
+	{"invalid prioritySteps", map[string]string{"framework": "celery", "queueName": "celery", "address": "localhost:6379", "prioritySteps": "0,high"}, map[string]string{}, true},
+	{"tls", map[string]string{"framework": "sidekiq", "queueName": "default", "address": "localhost:6379"}, map[string]string{"tls": "enable", "ca": "caaa"}, false},
+}
+
+func TestParseRedisJobQueueMetadata(t *testing.T) {
+	for _, testData := range testRedisJobQueueMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseRedisJobQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams}, parseRedisAddress)
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestRedisJobQueueGetMetricSpecForScaling(t *testing.T) {
+	meta, err := parseRedisJobQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testRedisJobQueueMetadata[1].metadata, TriggerIndex: 1}, parseRedisAddress)
+	assert.NoError(t, err)
+	scaler := redisJobQueueScaler{metadata: meta, logger: logr.Discard()}
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-redis-jobqueue-sidekiq-default", metricSpec[0].External.Metric.Name)
+}
+
+// fakeRedisJobQueueClient stores the lists and sorted sets of a redis database in memory
+type fakeRedisJobQueueClient struct {
+	lists map[string][]string
+	zsets map[string]map[string]float64
+}
+
+func (c *fakeRedisJobQueueClient) LLen(_ context.Context, key string) *redis.IntCmd {
+	return redis.NewIntResult(int64(len(c.lists[key])), nil)
+}
+
+func (c *fakeRedisJobQueueClient) ZCard(_ context.Context, key string) *redis.IntCmd {
+	return redis.NewIntResult(int64(len(c.zsets[key])), nil)
+}
+
+func (c *fakeRedisJobQueueClient) ZCount(_ context.Context, key, min, max string) *redis.IntCmd {
+	return redis.NewIntResult(int64(len(c.zrangeByScore(key, min, max))), nil)
+}
+
+// Eval runs the sidekiq due jobs script, the only script used by the scaler
+func (c *fakeRedisJobQueueClient) Eval(_ context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	if script != sidekiqDueJobsScript {
+		return redis.NewCmdResult(nil, fmt.Errorf("unexpected script"))
+	}
+	var count int64
+	for _, job := range c.zrangeByScore(keys[0], "-inf", args[0].(string)) {
+		if gjson.Get(job, "queue").String() == args[1].(string) {
+			count++
+		}
+	}
+	return redis.NewCmdResult(count, nil)
+}
+
+func (c *fakeRedisJobQueueClient) Close() error {
+	return nil
+}
+
+func (c *fakeRedisJobQueueClient) zrangeByScore(key, min, max string) []string {
+	parseScore := func(s string) float64 {
+		score, _ := strconv.ParseFloat(s, 64)
+		return score
+	}
+	var members []string
+	for member, score := range c.zsets[key] {
+		if (min == "-inf" || score >= parseScore(min)) && (max == "+inf" || score <= parseScore(max)) {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func TestRedisJobQueueGetMetricsAndActivity(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	past := float64(now.Add(-time.Minute).Unix())
+	future := float64(now.Add(time.Minute).Unix())
+	bullMQScore := func(t time.Time, counter int64) float64 {
+		return float64(t.UnixMilli()*0x1000 + counter)
+	}
+
+	client := &fakeRedisJobQueueClient{
+		lists: map[string][]string{
+			"queue:default":           {"job1", "job2"},
+			"celery":                  {"task1"},
+			"celery\x06\x163":         {"task2", "task3"},
+			"celery\x06\x169":         {"task4"},
+			"bull:emails:wait":        {"1", "2", "3"},
+			"rq:queue:high":           {"a"},
+			"myapp:queue:default":     {"job1"},
+			"bull:emails:active":      {"4"},
+			"rq:queue:low":            {"b"},
+			"celery\x06\x165":         {"ignored"},
+			"queue:critical":          {"job3"},
+			"bull:notifications:wait": {"5"},
+		},
+		zsets: map[string]map[string]float64{
+			"schedule": {
+				`{"queue":"default","jid":"s1"}`:  past,
+				`{"queue":"default","jid":"s2"}`:  future,
+				`{"queue":"critical","jid":"s3"}`: past,
+			},
+			"retry": {
+				`{"queue":"default","jid":"r1"}`: past,
+				`{"queue":"default","jid":"r2"}`: past,
+			},
+			"bull:emails:prioritized": {"6": 1, "7": 2},
+			"bull:emails:delayed": {
+				"8": bullMQScore(now.Add(-time.Second), 1),
+				"9": bullMQScore(now.Add(time.Second), 2),
+			},
+			"rq:scheduled:high": {"c": past, "d": future},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		expected int64
+		active   bool
+	}{
+		{
+			name:     "sidekiq",
+			metadata: map[string]string{"framework": "sidekiq", "queueName": "default"},
+			expected: 5,
+			active:   true,
+		},
+		{
+			name:     "sidekiq without delayed and retries",
+			metadata: map[string]string{"framework": "sidekiq", "queueName": "default", "includeDelayed": "false", "includeRetries": "false"},
+			expected: 2,
+			active:   true,
+		},
+		{
+			name:     "sidekiq with namespace",
+			metadata: map[string]string{"framework": "sidekiq", "queueName": "default", "keyPrefix": "myapp:", "activationJobCount": "1"},
+			expected: 1,
+			active:   false,
+		},
+		{
+			name:     "celery",
+			metadata: map[string]string{"framework": "celery", "queueName": "celery"},
+			expected: 4,
+			active:   true,
+		},
+		{
+			name:     "bullmq",
+			metadata: map[string]string{"framework": "bullmq", "queueName": "emails"},
+			expected: 6,
+			active:   true,
+		},
+		{
+			name:     "bullmq without delayed",
+			metadata: map[string]string{"framework": "bullmq", "queueName": "emails", "includeDelayed": "false", "includeRetries": "false"},
+			expected: 5,
+			active:   true,
+		},
+		{
+			name:     "rq",
+			metadata: map[string]string{"framework": "rq", "queueName": "high"},
+			expected: 2,
+			active:   true,
+		},
+		{
+			name:     "empty queue",
+			metadata: map[string]string{"framework": "rq", "queueName": "missing"},
+			expected: 0,
+			active:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["address"] = "localhost:6379"
+			meta, err := parseRedisJobQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata}, parseRedisAddress)
+			assert.NoError(t, err)
+			scaler := &redisJobQueueScaler{
+				metadata: meta,
+				client:   client,
+				now:      func() time.Time { return now },
+				logger:   logr.Discard(),
+			}
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "redis-jobqueue")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, metrics[0].Value.Value())
+			assert.Equal(t, test.active, active)
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package scalersconfig
 
 import (
+	"os"
 	"time"
 
 	v2 "k8s.io/api/autoscaling/v2"
@@ -24,6 +25,63 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
+// AuthParamsLease keeps the credentials of resolved AuthParams valid, eg: Vault dynamic secrets leases
+type AuthParamsLease interface {
+	// Expiration returns the time at which the credentials have to be resolved again. Zero value means that they don't expire
+	Expiration() time.Time
+	// Revoke releases the credentials once the AuthParams aren't used anymore
+	Revoke()
+}
+
+// AuthParamsMetadata describes when resolved AuthParams have to be resolved again
+type AuthParamsMetadata struct {
+	// Expiration of the AuthParams, eg: OIDC access tokens. Zero value means that they don't expire
+	Expiration time.Time
+
+	// Files the AuthParams were read from, with their modification time
+	Files map[string]time.Time
+
+	// Leases owned by the AuthParams, they are revoked when the scaler using them is closed
+	Leases []AuthParamsLease
+}
+
+// ExpireAt sets the expiration of the AuthParams to the input time if it is earlier than the current one,
+// a zero input time is ignored
+func (m *AuthParamsMetadata) ExpireAt(expiration time.Time) {
+	if expiration.IsZero() {
+		return
+	}
+	if m.Expiration.IsZero() || expiration.Before(m.Expiration) {
+		m.Expiration = expiration
+	}
+}
+
+// IsOutdated returns whether the AuthParams have expired or any of the files they were read from has changed
+func (m AuthParamsMetadata) IsOutdated(now time.Time) bool {
+	if !m.Expiration.IsZero() && !now.Before(m.Expiration) {
+		return true
+	}
+	for _, lease := range m.Leases {
+		if expiration := lease.Expiration(); !expiration.IsZero() && !now.Before(expiration) {
+			return true
+		}
+	}
+	for path, modTime := range m.Files {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// Revoke releases the leases owned by the AuthParams
+func (m AuthParamsMetadata) Revoke() {
+	for _, lease := range m.Leases {
+		lease.Revoke()
+	}
+}
+
 // ScalerConfig contains config fields common for all scalers
 type ScalerConfig struct {
 	// ScalableObjectName specifies name of the ScaledObject/ScaledJob that owns this scaler
@@ -54,6 +112,9 @@ type ScalerConfig struct {
 	// AuthParams
 	AuthParams map[string]string
 
+	// AuthParamsMetadata describes when AuthParams have to be resolved again
+	AuthParamsMetadata AuthParamsMetadata
+
 	// PodIdentity
 	PodIdentity kedav1alpha1.AuthPodIdentity
 
@@ -0,0 +1,399 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// ClickHouse driver required for this scaler
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	// SQLite driver required for this scaler
+	_ "modernc.org/sqlite"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	sqlAggregationFirst = "first"
+	sqlAggregationSum   = "sum"
+	sqlAggregationMax   = "max"
+)
+
+var (
+	// ErrSQLNoQuery is returned when "query" is missing from the config.
+	ErrSQLNoQuery = errors.New("no query given")
+
+	// ErrSQLNoTargetValue is returned when the target value is missing from the config.
+	ErrSQLNoTargetValue = errors.New("no target value given")
+)
+
+// sqlDriver describes how the sql trigger connects to a database
+type sqlDriver struct {
+	// driverName is the name the database/sql driver is registered with
+	driverName string
+	// connectionString builds the connection string from the driver specific parameters,
+	// when connectionString isn't given in the auth params or the env
+	connectionString func(config *scalersconfig.ScalerConfig) (string, error)
+}
+
+// sqlDrivers are the drivers supported by the driver parameter of the sql trigger, the postgres, mysql and mssql
+// database/sql drivers are imported with the connection parameters of their triggers
+var sqlDrivers = map[string]sqlDriver{
+	"postgres": {driverName: "pgx", connectionString: parsePostgreSQLConnection},
+	"mysql": {driverName: "mysql", connectionString: func(config *scalersconfig.ScalerConfig) (string, error) {
+		meta := mySQLMetadata{}
+		if err := parseMySQLConnection(config, &meta); err != nil {
+			return "", err
+		}
+		return metadataToConnectionStr(&meta), nil
+	}},
+	"mssql": {driverName: "sqlserver", connectionString: func(config *scalersconfig.ScalerConfig) (string, error) {
+		meta := mssqlMetadata{}
+		if err := parseMSSQLConnection(config, &meta); err != nil {
+			return "", err
+		}
+		return getMSSQLConnectionString(&meta), nil
+	}},
+	"sqlite":     {driverName: "sqlite", connectionString: noSQLConnectionString},
+	"clickhouse": {driverName: "clickhouse", connectionString: noSQLConnectionString},
+}
+
+// noSQLConnectionString is used by the drivers that can only connect with a connectionString
+func noSQLConnectionString(*scalersconfig.ScalerConfig) (string, error) {
+	return "", errors.New("no connectionString given")
+}
+
+// sqlTrigger describes the parameters of a trigger handled by the sql scaler
+type sqlTrigger struct {
+	// driver is the driver of the trigger, it is read from the driver parameter when empty
+	driver string
+	// targetValue and activationTargetValue are the names of the target parameters
+	targetValue           string
+	activationTargetValue string
+	// metricName returns the name of the metric, without the trigger index
+	metricName func(meta *sqlMetadata) string
+}
+
+// sqlTriggers are the sql trigger and its aliases, the postgresql, mysql and mssql triggers keep
+// the target parameters and the metric names they had before the sql trigger
+var sqlTriggers = map[string]sqlTrigger{
+	"sql": {
+		targetValue:           "targetQueryValue",
+		activationTargetValue: "activationTargetQueryValue",
+		metricName:            func(meta *sqlMetadata) string { return fmt.Sprintf("sql-%s", meta.driver) },
+	},
+	"postgresql": {
+		driver:                "postgres",
+		targetValue:           "targetQueryValue",
+		activationTargetValue: "activationTargetQueryValue",
+		metricName:            func(*sqlMetadata) string { return "postgresql" },
+	},
+	"mysql": {
+		driver:                "mysql",
+		targetValue:           "queryValue",
+		activationTargetValue: "activationQueryValue",
+		metricName: func(meta *sqlMetadata) string {
+			return fmt.Sprintf("mysql-%s", parseMySQLDbNameFromConnectionStr(meta.connectionString))
+		},
+	},
+	"mssql": {
+		driver:                "mssql",
+		targetValue:           "targetValue",
+		activationTargetValue: "activationTargetValue",
+		metricName:            func(*sqlMetadata) string { return "mssql" },
+	},
+}
+
+type sqlScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *sqlMetadata
+	connection *sql.DB
+	logger     logr.Logger
+}
+
+type sqlMetadata struct {
+	driver                     string
+	driverName                 string
+	connectionString           string
+	query                      string
+	aggregation                string
+	queryTimeout               time.Duration
+	targetQueryValue           float64
+	activationTargetQueryValue float64
+	metricName                 string
+}
+
+// NewSQLScaler creates a new scaler for the sql trigger or one of its aliases
+func NewSQLScaler(triggerType string, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, fmt.Sprintf("%s_scaler", triggerType))
+
+	meta, err := parseSQLMetadata(triggerType, config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s metadata: %w", triggerType, err)
+	}
+
+	conn, err := getSQLConnection(meta.driverName, meta.connectionString, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error establishing %s connection: %w", meta.driver, err)
+	}
+	return &sqlScaler{
+		metricType: metricType,
+		metadata:   meta,
+		connection: conn,
+		logger:     logger,
+	}, nil
+}
+
+func parseSQLMetadata(triggerType string, config *scalersconfig.ScalerConfig) (*sqlMetadata, error) {
+	meta := sqlMetadata{}
+
+	trigger, ok := sqlTriggers[triggerType]
+	if !ok {
+		return nil, fmt.Errorf("%s isn't a sql trigger", triggerType)
+	}
+
+	meta.driver = trigger.driver
+	if meta.driver == "" {
+		meta.driver = strings.ToLower(config.TriggerMetadata["driver"])
+	}
+	driver, ok := sqlDrivers[meta.driver]
+	if !ok {
+		return nil, fmt.Errorf("driver must be one of postgres, mysql, mssql, sqlite or clickhouse, got %q", meta.driver)
+	}
+	meta.driverName = driver.driverName
+
+	if val, ok := config.TriggerMetadata["query"]; ok && val != "" {
+		meta.query = val
+	} else {
+		return nil, ErrSQLNoQuery
+	}
+
+	meta.aggregation = sqlAggregationFirst
+	if val, ok := config.TriggerMetadata["aggregation"]; ok && val != "" {
+		switch val {
+		case sqlAggregationFirst, sqlAggregationSum, sqlAggregationMax:
+			meta.aggregation = val
+		default:
+			return nil, fmt.Errorf("aggregation must be one of %s, %s or %s, got %s", sqlAggregationFirst, sqlAggregationSum, sqlAggregationMax, val)
+		}
+	}
+
+	meta.queryTimeout = config.GlobalHTTPTimeout
+	if val, ok := config.TriggerMetadata["queryTimeout"]; ok && val != "" {
+		queryTimeoutMS, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("queryTimeout parsing error %w", err)
+		}
+		if queryTimeoutMS <= 0 {
+			return nil, fmt.Errorf("queryTimeout must be greater than 0")
+		}
+		meta.queryTimeout = time.Duration(queryTimeoutMS) * time.Millisecond
+	}
+
+	if val, ok := config.TriggerMetadata[trigger.targetValue]; ok {
+		targetQueryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s parsing error %w", trigger.targetValue, err)
+		}
+		meta.targetQueryValue = targetQueryValue
+	} else {
+		if config.AsMetricSource {
+			meta.targetQueryValue = 0
+		} else {
+			return nil, fmt.Errorf("%w: %s", ErrSQLNoTargetValue, trigger.targetValue)
+		}
+	}
+
+	meta.activationTargetQueryValue = 0
+	if val, ok := config.TriggerMetadata[trigger.activationTargetValue]; ok {
+		activationTargetQueryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s parsing error %w", trigger.activationTargetValue, err)
+		}
+		meta.activationTargetQueryValue = activationTargetQueryValue
+	}
+
+	switch {
+	case config.AuthParams["connectionString"] != "":
+		meta.connectionString = config.AuthParams["connectionString"]
+	case config.TriggerMetadata["connectionStringFromEnv"] != "":
+		meta.connectionString = config.ResolvedEnv[config.TriggerMetadata["connectionStringFromEnv"]]
+	default:
+		connectionString, err := driver.connectionString(config)
+		if err != nil {
+			return nil, err
+		}
+		meta.connectionString = connectionString
+	}
+	meta.metricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(trigger.metricName(&meta)))
+	return &meta, nil
+}
+
+// sqlConnectionPool shares the database handles between the triggers connecting with the same driver and connection string,
+// a database handle is itself a pool of connections safe for concurrent use
+type sqlConnectionPool struct {
+	mu  sync.Mutex
+	dbs map[string]*sqlPoolEntry
+}
+
+type sqlPoolEntry struct {
+	db   *sql.DB
+	refs int
+}
+
+var sqlConnections = &sqlConnectionPool{dbs: map[string]*sqlPoolEntry{}}
+
+func sqlPoolKey(driverName, connectionString string) string {
+	return driverName + "\x00" + connectionString
+}
+
+// getSQLConnection returns the shared database handle of the connection string, opening it if it isn't used yet
+func getSQLConnection(driverName, connectionString string, logger logr.Logger) (*sql.DB, error) {
+	sqlConnections.mu.Lock()
+	defer sqlConnections.mu.Unlock()
+
+	key := sqlPoolKey(driverName, connectionString)
+	if entry, ok := sqlConnections.dbs[key]; ok {
+		entry.refs++
+		return entry.db, nil
+	}
+
+	db, err := sql.Open(driverName, connectionString)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Found error opening %s connection: %s", driverName, err))
+		return nil, err
+	}
+	err = db.Ping()
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Found error pinging %s: %s", driverName, err))
+		db.Close()
+		return nil, err
+	}
+	sqlConnections.dbs[key] = &sqlPoolEntry{db: db, refs: 1}
+	return db, nil
+}
+
+// releaseSQLConnection closes the shared database handle of the connection string once no trigger uses it anymore
+func releaseSQLConnection(driverName, connectionString string) error {
+	sqlConnections.mu.Lock()
+	defer sqlConnections.mu.Unlock()
+
+	key := sqlPoolKey(driverName, connectionString)
+	entry, ok := sqlConnections.dbs[key]
+	if !ok {
+		return nil
+	}
+	entry.refs--
+	if entry.refs > 0 {
+		return nil
+	}
+	delete(sqlConnections.dbs, key)
+	return entry.db.Close()
+}
+
+// querySQLValue aggregates the first column of the rows returned by the query, NULL values are skipped
+// and 0 is returned when there isn't any value
+func querySQLValue(ctx context.Context, db *sql.DB, query, aggregation string) (float64, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if len(columns) == 0 {
+		return 0, errors.New("query doesn't return any column")
+	}
+
+	var result float64
+	found := false
+	for rows.Next() {
+		var value sql.NullFloat64
+		dest := make([]any, len(columns))
+		dest[0] = &value
+		for i := 1; i < len(dest); i++ {
+			dest[i] = new(any)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		if !value.Valid {
+			continue
+		}
+
+		switch {
+		case !found:
+			result = value.Float64
+		case aggregation == sqlAggregationSum:
+			result += value.Float64
+		case aggregation == sqlAggregationMax:
+			result = math.Max(result, value.Float64)
+		}
+		found = true
+		if aggregation == sqlAggregationFirst {
+			break
+		}
+	}
+	return result, rows.Err()
+}
+
+// Close releases the shared database handle
+func (s *sqlScaler) Close(context.Context) error {
+	err := releaseSQLConnection(s.metadata.driverName, s.metadata.connectionString)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("Error closing %s connection", s.metadata.driver))
+		return err
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *sqlScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: s.metadata.metricName,
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueryValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *sqlScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if s.metadata.queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.metadata.queryTimeout)
+		defer cancel()
+	}
+
+	num, err := querySQLValue(ctx, s.connection, s.metadata.query, s.metadata.aggregation)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("could not query %s: %s", s.metadata.driver, err))
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting %s: %w", s.metadata.driver, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, num)
+
+	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationTargetQueryValue, nil
+}
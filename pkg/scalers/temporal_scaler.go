@@ -0,0 +1,296 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultTemporalNamespace          = "default"
+	defaultTemporalTargetQueueSize    = 5
+	defaultTemporalActivationQueueLen = 0
+)
+
+var temporalQueueTypes = map[string]enumspb.TaskQueueType{
+	"workflow": enumspb.TASK_QUEUE_TYPE_WORKFLOW,
+	"activity": enumspb.TASK_QUEUE_TYPE_ACTIVITY,
+}
+
+type temporalScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *temporalMetadata
+	conn       *grpc.ClientConn
+	client     workflowservice.WorkflowServiceClient
+	timeout    time.Duration
+	logger     logr.Logger
+}
+
+type temporalMetadata struct {
+	endpoint                  string
+	namespace                 string
+	taskQueue                 string
+	queueTypes                []enumspb.TaskQueueType
+	targetQueueSize           int64
+	activationTargetQueueSize int64
+
+	buildIDs          []string
+	selectAllActive   bool
+	selectUnversioned bool
+
+	apiKey        string
+	ca            string
+	cert          string
+	key           string
+	keyPassword   string
+	unsafeSsl     bool
+	tlsServerName string
+
+	triggerIndex int
+}
+
+// NewTemporalScaler creates a new temporalScaler
+func NewTemporalScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseTemporalMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing temporal metadata: %w", err)
+	}
+
+	conn, err := getTemporalConnection(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to temporal: %w", err)
+	}
+
+	return &temporalScaler{
+		metricType: metricType,
+		metadata:   meta,
+		conn:       conn,
+		client:     workflowservice.NewWorkflowServiceClient(conn),
+		timeout:    config.GlobalHTTPTimeout,
+		logger:     InitializeLogger(config, "temporal_scaler"),
+	}, nil
+}
+
+func parseTemporalMetadata(config *scalersconfig.ScalerConfig) (*temporalMetadata, error) {
+	meta := temporalMetadata{}
+
+	endpoint, err := GetFromAuthOrMeta(config, "endpoint")
+	if err != nil {
+		return nil, err
+	}
+	meta.endpoint = endpoint
+
+	meta.namespace = defaultTemporalNamespace
+	if val, ok := config.TriggerMetadata["namespace"]; ok && val != "" {
+		meta.namespace = val
+	}
+
+	if val, ok := config.TriggerMetadata["taskQueue"]; ok && val != "" {
+		meta.taskQueue = val
+	} else {
+		return nil, errors.New("no taskQueue given")
+	}
+
+	queueTypes := "workflow,activity"
+	if val, ok := config.TriggerMetadata["queueTypes"]; ok && val != "" {
+		queueTypes = val
+	}
+	for _, queueType := range splitAndTrim(queueTypes) {
+		taskQueueType, ok := temporalQueueTypes[strings.ToLower(queueType)]
+		if !ok {
+			return nil, fmt.Errorf("queueTypes must be workflow or activity, got %s", queueType)
+		}
+		meta.queueTypes = append(meta.queueTypes, taskQueueType)
+	}
+
+	meta.targetQueueSize = defaultTemporalTargetQueueSize
+	if val, ok := config.TriggerMetadata["targetQueueSize"]; ok && val != "" {
+		targetQueueSize, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetQueueSize: %w", err)
+		}
+		meta.targetQueueSize = targetQueueSize
+	}
+
+	meta.activationTargetQueueSize = defaultTemporalActivationQueueLen
+	if val, ok := config.TriggerMetadata["activationTargetQueueSize"]; ok && val != "" {
+		activationTargetQueueSize, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetQueueSize: %w", err)
+		}
+		meta.activationTargetQueueSize = activationTargetQueueSize
+	}
+
+	if val, ok := config.TriggerMetadata["buildId"]; ok && val != "" {
+		meta.buildIDs = splitAndTrim(val)
+	}
+
+	meta.selectAllActive = true
+	if val, ok := config.TriggerMetadata["selectAllActive"]; ok && val != "" {
+		selectAllActive, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing selectAllActive: %w", err)
+		}
+		meta.selectAllActive = selectAllActive
+	}
+
+	meta.selectUnversioned = true
+	if val, ok := config.TriggerMetadata["selectUnversioned"]; ok && val != "" {
+		selectUnversioned, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing selectUnversioned: %w", err)
+		}
+		meta.selectUnversioned = selectUnversioned
+	}
+
+	if len(meta.buildIDs) == 0 && !meta.selectAllActive && !meta.selectUnversioned {
+		return nil, errors.New("at least one of buildId, selectAllActive or selectUnversioned must select a version")
+	}
+
+	meta.apiKey = config.AuthParams["apiKey"]
+	meta.ca = config.AuthParams["ca"]
+	meta.cert = config.AuthParams["cert"]
+	meta.key = config.AuthParams["key"]
+	meta.keyPassword = config.AuthParams["keyPassword"]
+	if (meta.cert == "") != (meta.key == "") {
+		return nil, errors.New("both cert and key must be provided for mTLS")
+	}
+
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
+		unsafeSsl, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+		}
+		meta.unsafeSsl = unsafeSsl
+	}
+	meta.tlsServerName = config.TriggerMetadata["tlsServerName"]
+
+	meta.triggerIndex = config.TriggerIndex
+	return &meta, nil
+}
+
+// useTLS returns whether the connection must be secured, API keys are never sent in plain text
+func (m *temporalMetadata) useTLS() bool {
+	return m.apiKey != "" || m.ca != "" || m.cert != "" || m.unsafeSsl || m.tlsServerName != ""
+}
+
+// temporalAPIKeyCredentials sends the API key as a bearer token with each request
+type temporalAPIKeyCredentials struct {
+	apiKey string
+}
+
+func (c temporalAPIKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.apiKey}, nil
+}
+
+func (c temporalAPIKeyCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+func getTemporalConnection(meta *temporalMetadata) (*grpc.ClientConn, error) {
+	if !meta.useTLS() {
+		return grpc.Dial(meta.endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca, meta.unsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	if meta.tlsServerName != "" {
+		tlsConfig.ServerName = meta.tlsServerName
+	}
+	options := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if meta.apiKey != "" {
+		options = append(options, grpc.WithPerRPCCredentials(temporalAPIKeyCredentials{apiKey: meta.apiKey}))
+	}
+	// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
+	return grpc.Dial(meta.endpoint, options...)
+}
+
+func (s *temporalScaler) Close(context.Context) error {
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *temporalScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("temporal-%s-%s", s.metadata.namespace, s.metadata.taskQueue))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.targetQueueSize),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the backlog of the task queue and whether it's above the activation queue size
+func (s *temporalScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	backlog, err := s.getQueueBacklog(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting backlog of task queue %s: %w", s.metadata.taskQueue, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(backlog))
+	return []external_metrics.ExternalMetricValue{metric}, backlog > s.metadata.activationTargetQueueSize, nil
+}
+
+// getQueueBacklog sums the approximate backlog of the selected queue types over the selected worker build IDs
+func (s *temporalScaler) getQueueBacklog(ctx context.Context) (int64, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	resp, err := s.client.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+		Namespace: s.metadata.namespace,
+		TaskQueue: &taskqueuepb.TaskQueue{
+			Name: s.metadata.taskQueue,
+			Kind: enumspb.TASK_QUEUE_KIND_NORMAL,
+		},
+		ApiMode: enumspb.DESCRIBE_TASK_QUEUE_MODE_ENHANCED,
+		Versions: &taskqueuepb.TaskQueueVersionSelection{
+			BuildIds:    s.metadata.buildIDs,
+			Unversioned: s.metadata.selectUnversioned,
+			AllActive:   s.metadata.selectAllActive,
+		},
+		TaskQueueTypes: s.metadata.queueTypes,
+		ReportStats:    true,
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	var backlog int64
+	for _, versionInfo := range resp.GetVersionsInfo() {
+		for _, queueType := range s.metadata.queueTypes {
+			backlog += versionInfo.GetTypesInfo()[int32(queueType)].GetStats().GetApproximateBacklogCount()
+		}
+	}
+	return backlog, nil
+}
@@ -0,0 +1,284 @@
+package scalers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseTemporalMetadataTestData struct {
+	name       string
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testTemporalMetadata = []parseTemporalMetadataTestData{
+	{"nothing passed", map[string]string{}, map[string]string{}, true},
+	{"properly formed", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders"}, map[string]string{}, false},
+	{"endpoint in auth params", map[string]string{"taskQueue": "orders", "namespace": "payments"}, map[string]string{"endpoint": "payments.tmprl.cloud:7233"}, false},
+	{"missing taskQueue", map[string]string{"endpoint": "temporal:7233"}, map[string]string{}, true},
+	{"activity queue", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "queueTypes": "activity"}, map[string]string{}, false},
+	{"invalid queue type", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "queueTypes": "workflow,nexus"}, map[string]string{}, true},
+	{"invalid targetQueueSize", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "targetQueueSize": "a"}, map[string]string{}, true},
+	{"invalid activationTargetQueueSize", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "activationTargetQueueSize": "a"}, map[string]string{}, true},
+	{"build ids", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "buildId": "v1, v2", "selectAllActive": "false", "selectUnversioned": "false"}, map[string]string{}, false},
+	{"no version selected", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "selectAllActive": "false", "selectUnversioned": "false"}, map[string]string{}, true},
+	{"invalid selectAllActive", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "selectAllActive": "a"}, map[string]string{}, true},
+	{"invalid selectUnversioned", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "selectUnversioned": "a"}, map[string]string{}, true},
+	{"api key", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders"}, map[string]string{"apiKey": "key"}, false},
+	{"mtls", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders"}, map[string]string{"ca": "ca", "cert": "cert", "key": "key"}, false},
+	{"cert without key", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders"}, map[string]string{"cert": "cert"}, true},
+	{"invalid unsafeSsl", map[string]string{"endpoint": "temporal:7233", "taskQueue": "orders", "unsafeSsl": "a"}, map[string]string{}, true},
+}
+
+func TestTemporalParseMetadata(t *testing.T) {
+	for _, testData := range testTemporalMetadata {
+		t.Run(testData.name, func(t *testing.T) {
+			_, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestTemporalGetMetricSpecForScaling(t *testing.T) {
+	meta, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testTemporalMetadata[2].metadata, AuthParams: testTemporalMetadata[2].authParams, TriggerIndex: 1})
+	assert.NoError(t, err)
+	scaler := temporalScaler{metadata: meta, logger: logr.Discard()}
+
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-temporal-payments-orders", metricSpec[0].External.Metric.Name)
+}
+
+// testTemporalServer returns the backlog of the requested queue types for each build ID it knows
+type testTemporalServer struct {
+	workflowservice.UnimplementedWorkflowServiceServer
+	apiKey  string
+	backlog map[string]map[enumspb.TaskQueueType]int64
+}
+
+func (s *testTemporalServer) DescribeTaskQueue(ctx context.Context, request *workflowservice.DescribeTaskQueueRequest) (*workflowservice.DescribeTaskQueueResponse, error) {
+	if s.apiKey != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("authorization"); len(values) != 1 || values[0] != "Bearer "+s.apiKey {
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+	}
+	if request.Namespace != "default" || request.TaskQueue.GetName() != "orders" {
+		return nil, status.Error(codes.NotFound, "task queue not found")
+	}
+	if request.ApiMode != enumspb.DESCRIBE_TASK_QUEUE_MODE_ENHANCED || !request.ReportStats {
+		return nil, status.Error(codes.InvalidArgument, "stats are only reported in enhanced mode")
+	}
+
+	selected := map[string]bool{}
+	for _, buildID := range request.Versions.GetBuildIds() {
+		selected[buildID] = true
+	}
+	response := &workflowservice.DescribeTaskQueueResponse{VersionsInfo: map[string]*taskqueuepb.TaskQueueVersionInfo{}}
+	for buildID, backlog := range s.backlog {
+		// the unversioned workers are reported with an empty build ID, the others are active in this server
+		if !selected[buildID] && !(buildID == "" && request.Versions.GetUnversioned()) && !(buildID != "" && request.Versions.GetAllActive()) {
+			continue
+		}
+		versionInfo := &taskqueuepb.TaskQueueVersionInfo{TypesInfo: map[int32]*taskqueuepb.TaskQueueTypeInfo{}}
+		for _, queueType := range request.TaskQueueTypes {
+			versionInfo.TypesInfo[int32(queueType)] = &taskqueuepb.TaskQueueTypeInfo{Stats: &taskqueuepb.TaskQueueStats{ApproximateBacklogCount: backlog[queueType]}}
+		}
+		response.VersionsInfo[buildID] = versionInfo
+	}
+	return response, nil
+}
+
+var testTemporalBacklog = map[string]map[enumspb.TaskQueueType]int64{
+	"": {
+		enumspb.TASK_QUEUE_TYPE_WORKFLOW: 1,
+		enumspb.TASK_QUEUE_TYPE_ACTIVITY: 2,
+	},
+	"v1": {
+		enumspb.TASK_QUEUE_TYPE_WORKFLOW: 10,
+		enumspb.TASK_QUEUE_TYPE_ACTIVITY: 20,
+	},
+	"v2": {
+		enumspb.TASK_QUEUE_TYPE_WORKFLOW: 100,
+		enumspb.TASK_QUEUE_TYPE_ACTIVITY: 200,
+	},
+}
+
+func startTestTemporalServer(t *testing.T, server *testTemporalServer, options ...grpc.ServerOption) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(options...)
+	workflowservice.RegisterWorkflowServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().String()
+}
+
+func TestTemporalGetMetricsAndActivity(t *testing.T) {
+	endpoint := startTestTemporalServer(t, &testTemporalServer{backlog: testTemporalBacklog})
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		expected int64
+		active   bool
+		isError  bool
+	}{
+		{
+			name:     "all versions and queue types",
+			metadata: map[string]string{"taskQueue": "orders"},
+			expected: 333,
+			active:   true,
+		},
+		{
+			name:     "workflow queue",
+			metadata: map[string]string{"taskQueue": "orders", "queueTypes": "workflow"},
+			expected: 111,
+			active:   true,
+		},
+		{
+			name:     "build id",
+			metadata: map[string]string{"taskQueue": "orders", "queueTypes": "activity", "buildId": "v1", "selectAllActive": "false", "selectUnversioned": "false"},
+			expected: 20,
+			active:   true,
+		},
+		{
+			name:     "unversioned under activation",
+			metadata: map[string]string{"taskQueue": "orders", "selectAllActive": "false", "activationTargetQueueSize": "5"},
+			expected: 3,
+			active:   false,
+		},
+		{
+			name:     "unknown task queue",
+			metadata: map[string]string{"taskQueue": "invoices"},
+			isError:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["endpoint"] = endpoint
+			scaler, err := NewTemporalScaler(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata, GlobalHTTPTimeout: 5 * time.Second})
+			assert.NoError(t, err)
+			defer scaler.Close(context.Background())
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "temporal")
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, metrics[0].Value.Value())
+			assert.Equal(t, test.active, active)
+		})
+	}
+}
+
+func TestTemporalTLSAndAPIKey(t *testing.T) {
+	ca, caKey, caPEM := generateTestTemporalCertificate(t, nil, nil, true)
+	_, serverKey, serverPEM := generateTestTemporalCertificate(t, ca, caKey, false)
+	_, clientKey, clientPEM := generateTestTemporalCertificate(t, ca, caKey, false)
+	serverCert, err := tls.X509KeyPair(serverPEM, encodeTestTemporalKey(t, serverKey))
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	endpoint := startTestTemporalServer(t, &testTemporalServer{apiKey: "secret", backlog: testTemporalBacklog},
+		grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.VerifyClientCertIfGiven,
+			MinVersion:   tls.VersionTLS12,
+		})))
+
+	tests := []struct {
+		name       string
+		authParams map[string]string
+		isError    bool
+	}{
+		// runs first as the CA given in the other cases is added to the shared root CAs
+		{name: "untrusted server", authParams: map[string]string{"apiKey": "secret"}, isError: true},
+		{name: "api key", authParams: map[string]string{"apiKey": "secret", "ca": string(caPEM)}},
+		{name: "mtls and api key", authParams: map[string]string{"apiKey": "secret", "ca": string(caPEM), "cert": string(clientPEM), "key": string(encodeTestTemporalKey(t, clientKey))}},
+		{name: "wrong api key", authParams: map[string]string{"apiKey": "wrong", "ca": string(caPEM)}, isError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := map[string]string{"endpoint": endpoint, "taskQueue": "orders", "tlsServerName": "localhost"}
+			scaler, err := NewTemporalScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: test.authParams, GlobalHTTPTimeout: 5 * time.Second})
+			assert.NoError(t, err)
+			defer scaler.Close(context.Background())
+
+			metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), "temporal")
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(333), metrics[0].Value.Value())
+		})
+	}
+}
+
+// generateTestTemporalCertificate returns a certificate for localhost signed by the parent, or a self-signed CA without parent
+func generateTestTemporalCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func encodeTestTemporalKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
@@ -30,6 +30,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 var log = logf.Log.WithName("scalers_cache")
@@ -40,6 +41,7 @@ type ScalersCache struct {
 	ScalableObjectGeneration int64
 	Recorder                 record.EventRecorder
 	CompiledFormula          *vm.Program
+	AuthenticationKeys       []resolver.AuthenticationKey
 }
 
 type ScalerBuilder struct {
@@ -71,7 +73,7 @@ func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	return result
 }
 
-// Close closes all scalers in the cache
+// Close closes all scalers in the cache and revokes the leases of their authentication parameters
 func (c *ScalersCache) Close(ctx context.Context) {
 	scalers := c.Scalers
 	c.Scalers = nil
@@ -80,9 +82,33 @@ func (c *ScalersCache) Close(ctx context.Context) {
 		if err != nil {
 			log.Error(err, "error closing scaler", "scaler", s)
 		}
+		s.ScalerConfig.AuthParamsMetadata.Revoke()
 	}
 }
 
+// AreAuthParamsOutdated returns whether the resolved authentication parameters of any scaler in the cache
+// have expired or have changed, so the scalers have to be rebuilt
+func (c *ScalersCache) AreAuthParamsOutdated(now time.Time) bool {
+	for _, s := range c.Scalers {
+		if s.ScalerConfig.AuthParamsMetadata.IsOutdated(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesAuthentication returns whether any scaler in the cache references one of the input authentication objects
+func (c *ScalersCache) UsesAuthentication(authenticationKeys []resolver.AuthenticationKey) bool {
+	for _, used := range c.AuthenticationKeys {
+		for _, key := range authenticationKeys {
+			if used == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetMetricSpecForScaling returns metrics specs for all scalers in the cache
 func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
 	var spec []v2.MetricSpec
@@ -147,7 +173,10 @@ func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scale
 	}
 
 	sb := c.Scalers[id]
-	defer sb.Scaler.Close(ctx)
+	defer func() {
+		sb.Scaler.Close(ctx)
+		sb.ScalerConfig.AuthParamsMetadata.Revoke()
+	}()
 	ns, sConfig, err := sb.Factory()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,419 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+const (
+	// directScalingTolerance is the same tolerance HPA controller uses,
+	// changes in the usage ratio within it don't trigger scaling
+	directScalingTolerance = 0.1
+
+	defaultScaleUpStabilizationWindowSeconds   = int32(0)
+	defaultScaleDownStabilizationWindowSeconds = int32(300)
+	defaultScalingPolicyPeriodSeconds          = int32(15)
+	defaultScaleUpPodsPolicyValue              = int32(4)
+	defaultScalingPercentPolicyValue           = int32(100)
+
+	// maxScalingPolicyPeriodSeconds is the longest period allowed for HPA scaling policies
+	maxScalingPolicyPeriodSeconds = 1800
+
+	// directScalingStateExpiration is the time after which the stored state
+	// of a ScaledObject, which hasn't been scaled directly since, is removed
+	directScalingStateExpiration = time.Hour
+)
+
+// DirectScalingMetric holds the current value of a metric together with its target,
+// it is used for computing the desired replica count in direct scaling mode
+type DirectScalingMetric struct {
+	MetricName string
+	Value      float64
+	Target     autoscalingv2.MetricTarget
+}
+
+type timestampedRecommendation struct {
+	recommendation int32
+	timestamp      time.Time
+}
+
+type timestampedScaleEvent struct {
+	replicaChange int32
+	timestamp     time.Time
+}
+
+// directScalingState holds the previous recommendations and scale events of a ScaledObject,
+// they are needed to apply stabilization windows and scaling policies the same way HPA does
+type directScalingState struct {
+	recommendations []timestampedRecommendation
+	scaleUpEvents   []timestampedScaleEvent
+	scaleDownEvents []timestampedScaleEvent
+	lastUpdate      time.Time
+}
+
+// RequestDirectScale scales the target of a ScaledObject in direct scaling mode, ie. without HPA.
+// Activation, deactivation, fallback and paused replicas are handled by RequestScale,
+// the replica count of an active target is then computed from the metrics using the HPA algorithm.
+func (e *scaleExecutor) RequestDirectScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metrics []DirectScalingMetric) {
+	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
+		"scaledObject.Namespace", scaledObject.Namespace,
+		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+
+	e.RequestScale(ctx, scaledObject, isActive, isError)
+
+	// when some scaler responded with error, we don't have the complete picture
+	// so we keep the current replica count (or the fallback set by RequestScale)
+	if isError || scaledObject.NeedToBePausedByAnnotation() {
+		return
+	}
+
+	currentScale, currentReplicas, err := e.getCurrentReplicas(ctx, scaledObject)
+	if err != nil {
+		logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
+		return
+	}
+
+	// scaling from and to zero (or idle replicas) is handled by RequestScale
+	if currentReplicas == 0 {
+		return
+	}
+	if scaledObject.Spec.IdleReplicaCount != nil && !isActive && currentReplicas <= *scaledObject.Spec.IdleReplicaCount {
+		return
+	}
+	// the replica count is driven by the scale down steps during staged scale down
+	if stage := scaledObject.Status.ScalingStage; !isActive && stage != nil && stage.Stage == kedav1alpha1.ScalingStageScaleDown {
+		return
+	}
+
+	now := time.Now()
+	minReplicas := *scaledObject.GetHPAMinReplicas()
+	maxReplicas := scaledObject.GetHPAMaxReplicas()
+	desiredReplicas := getDirectScalingDesiredReplicas(currentReplicas, metrics)
+	desiredReplicas = e.normalizeDirectScalingDesiredReplicas(scaledObject.GenerateIdentifier(), scaledObject.GetHPABehavior(),
+		currentReplicas, desiredReplicas, minReplicas, maxReplicas, now)
+
+	if desiredReplicas == currentReplicas {
+		logger.V(1).Info("Scale target doesn't need to be scaled", "replicas", currentReplicas)
+		return
+	}
+
+	_, err = e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, desiredReplicas)
+	if err != nil {
+		logger.Error(err, "Error scaling the scale target", "currentReplicas", currentReplicas, "desiredReplicas", desiredReplicas)
+		e.recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetScalingFailed, fmt.Sprintf("Failed to scale target to %d replicas: %s", desiredReplicas, err))
+		return
+	}
+
+	e.recordDirectScaleEvent(scaledObject.GenerateIdentifier(), desiredReplicas-currentReplicas, now)
+	logger.Info("Successfully updated ScaleTarget", "Original Replicas Count", currentReplicas, "New Replicas Count", desiredReplicas)
+	e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetScaled, "Scaled %s %s/%s from %d to %d",
+		scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, desiredReplicas)
+}
+
+// getDirectScalingDesiredReplicas computes the replica count for each metric the same way HPA does
+// for External metrics and returns the highest one, current replicas are returned if there isn't any usable metric
+func getDirectScalingDesiredReplicas(currentReplicas int32, metrics []DirectScalingMetric) int32 {
+	desiredReplicas := int32(0)
+	found := false
+	for _, metric := range metrics {
+		var proposal int32
+		switch metric.Target.Type {
+		case autoscalingv2.AverageValueMetricType:
+			if metric.Target.AverageValue == nil {
+				continue
+			}
+			target := metric.Target.AverageValue.AsApproximateFloat64()
+			if target <= 0 {
+				continue
+			}
+			usageRatio := metric.Value / (target * float64(currentReplicas))
+			proposal = getReplicasForUsageRatio(currentReplicas, usageRatio, math.Ceil(metric.Value/target))
+		case autoscalingv2.ValueMetricType:
+			if metric.Target.Value == nil {
+				continue
+			}
+			target := metric.Target.Value.AsApproximateFloat64()
+			if target <= 0 {
+				continue
+			}
+			usageRatio := metric.Value / target
+			proposal = getReplicasForUsageRatio(currentReplicas, usageRatio, math.Ceil(usageRatio*float64(currentReplicas)))
+		default:
+			continue
+		}
+		if !found || proposal > desiredReplicas {
+			desiredReplicas = proposal
+			found = true
+		}
+	}
+	if !found {
+		return currentReplicas
+	}
+	return desiredReplicas
+}
+
+func getReplicasForUsageRatio(currentReplicas int32, usageRatio float64, proposal float64) int32 {
+	if math.Abs(1.0-usageRatio) <= directScalingTolerance {
+		return currentReplicas
+	}
+	if proposal > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(proposal)
+}
+
+// normalizeDirectScalingDesiredReplicas applies min/max replicas, stabilization windows and scaling policies
+// on the desired replica count, it follows the behavior of HPA controller including its defaults
+func (e *scaleExecutor) normalizeDirectScalingDesiredReplicas(key string, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior,
+	currentReplicas, desiredReplicas, minReplicas, maxReplicas int32, now time.Time) int32 {
+	// replica count outside of the bounds is fixed right away, like HPA does
+	if currentReplicas > maxReplicas {
+		return maxReplicas
+	}
+	if currentReplicas < minReplicas {
+		return minReplicas
+	}
+
+	scaleUpRules, scaleDownRules := getDirectScalingRules(behavior)
+
+	e.directScalingStatesLock.Lock()
+	defer e.directScalingStatesLock.Unlock()
+	state := e.getDirectScalingState(key, now)
+
+	// stabilization windows
+	upCutoff := now.Add(-time.Duration(*scaleUpRules.StabilizationWindowSeconds) * time.Second)
+	downCutoff := now.Add(-time.Duration(*scaleDownRules.StabilizationWindowSeconds) * time.Second)
+	upRecommendation := desiredReplicas
+	downRecommendation := desiredReplicas
+	recommendations := []timestampedRecommendation{}
+	for _, rec := range state.recommendations {
+		if rec.timestamp.After(upCutoff) && rec.recommendation < upRecommendation {
+			upRecommendation = rec.recommendation
+		}
+		if rec.timestamp.After(downCutoff) && rec.recommendation > downRecommendation {
+			downRecommendation = rec.recommendation
+		}
+		if rec.timestamp.After(upCutoff) || rec.timestamp.After(downCutoff) {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	state.recommendations = append(recommendations, timestampedRecommendation{recommendation: desiredReplicas, timestamp: now})
+
+	stabilizedReplicas := currentReplicas
+	if stabilizedReplicas < upRecommendation {
+		stabilizedReplicas = upRecommendation
+	}
+	if stabilizedReplicas > downRecommendation {
+		stabilizedReplicas = downRecommendation
+	}
+
+	// scaling policies
+	switch {
+	case stabilizedReplicas > currentReplicas:
+		scaleUpLimit := getScaleUpLimit(currentReplicas, state, scaleUpRules, now)
+		if scaleUpLimit < currentReplicas {
+			scaleUpLimit = currentReplicas
+		}
+		if maxReplicas > scaleUpLimit {
+			maxReplicas = scaleUpLimit
+		}
+		if stabilizedReplicas > maxReplicas {
+			return maxReplicas
+		}
+	case stabilizedReplicas < currentReplicas:
+		scaleDownLimit := getScaleDownLimit(currentReplicas, state, scaleDownRules, now)
+		if scaleDownLimit > currentReplicas {
+			scaleDownLimit = currentReplicas
+		}
+		if minReplicas < scaleDownLimit {
+			minReplicas = scaleDownLimit
+		}
+		if stabilizedReplicas < minReplicas {
+			return minReplicas
+		}
+	}
+	return stabilizedReplicas
+}
+
+// getDirectScalingState returns the state stored for the ScaledObject, it has to be called with the lock held.
+// States of ScaledObjects that haven't been scaled directly for a long time are removed.
+func (e *scaleExecutor) getDirectScalingState(key string, now time.Time) *directScalingState {
+	if e.directScalingStates == nil {
+		e.directScalingStates = map[string]*directScalingState{}
+	}
+	for k, state := range e.directScalingStates {
+		if k != key && now.Sub(state.lastUpdate) > directScalingStateExpiration {
+			delete(e.directScalingStates, k)
+		}
+	}
+	state, ok := e.directScalingStates[key]
+	if !ok {
+		state = &directScalingState{}
+		e.directScalingStates[key] = state
+	}
+	state.lastUpdate = now
+	return state
+}
+
+// recordDirectScaleEvent stores the replica change, so it is taken into account by the scaling policies
+func (e *scaleExecutor) recordDirectScaleEvent(key string, replicaChange int32, now time.Time) {
+	e.directScalingStatesLock.Lock()
+	defer e.directScalingStatesLock.Unlock()
+	state := e.getDirectScalingState(key, now)
+
+	// events older than the longest possible policy period are not needed anymore
+	cutoff := now.Add(-time.Duration(maxScalingPolicyPeriodSeconds) * time.Second)
+	if replicaChange > 0 {
+		state.scaleUpEvents = append(pruneScaleEvents(state.scaleUpEvents, cutoff), timestampedScaleEvent{replicaChange: replicaChange, timestamp: now})
+	} else {
+		state.scaleDownEvents = append(pruneScaleEvents(state.scaleDownEvents, cutoff), timestampedScaleEvent{replicaChange: -replicaChange, timestamp: now})
+	}
+}
+
+func pruneScaleEvents(events []timestampedScaleEvent, cutoff time.Time) []timestampedScaleEvent {
+	result := []timestampedScaleEvent{}
+	for _, event := range events {
+		if event.timestamp.After(cutoff) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// getDirectScalingRules returns the scale up and scale down rules from the behavior, missing fields are filled with HPA defaults
+func getDirectScalingRules(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) (*autoscalingv2.HPAScalingRules, *autoscalingv2.HPAScalingRules) {
+	maxPolicySelect := autoscalingv2.MaxChangePolicySelect
+	scaleUp := &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: new(int32),
+		SelectPolicy:               &maxPolicySelect,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PodsScalingPolicy, Value: defaultScaleUpPodsPolicyValue, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+			{Type: autoscalingv2.PercentScalingPolicy, Value: defaultScalingPercentPolicyValue, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+		},
+	}
+	*scaleUp.StabilizationWindowSeconds = defaultScaleUpStabilizationWindowSeconds
+	scaleDown := &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: new(int32),
+		SelectPolicy:               &maxPolicySelect,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PercentScalingPolicy, Value: defaultScalingPercentPolicyValue, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+		},
+	}
+	*scaleDown.StabilizationWindowSeconds = defaultScaleDownStabilizationWindowSeconds
+
+	if behavior != nil {
+		mergeScalingRules(scaleUp, behavior.ScaleUp)
+		mergeScalingRules(scaleDown, behavior.ScaleDown)
+	}
+	return scaleUp, scaleDown
+}
+
+func mergeScalingRules(rules *autoscalingv2.HPAScalingRules, override *autoscalingv2.HPAScalingRules) {
+	if override == nil {
+		return
+	}
+	if override.StabilizationWindowSeconds != nil {
+		rules.StabilizationWindowSeconds = override.StabilizationWindowSeconds
+	}
+	if override.SelectPolicy != nil {
+		rules.SelectPolicy = override.SelectPolicy
+	}
+	if override.Policies != nil {
+		rules.Policies = override.Policies
+	}
+}
+
+// getScaleUpLimit returns the maximum replica count allowed by the scale up policies
+func getScaleUpLimit(currentReplicas int32, state *directScalingState, rules *autoscalingv2.HPAScalingRules, now time.Time) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	selectMin := *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect
+	result := int32(math.MinInt32)
+	if selectMin {
+		result = math.MaxInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := getPeriodStartReplicas(currentReplicas, state, policy.PeriodSeconds, now)
+		var proposed int32
+		switch policy.Type {
+		case autoscalingv2.PodsScalingPolicy:
+			proposed = periodStartReplicas + policy.Value
+		case autoscalingv2.PercentScalingPolicy:
+			// the proposal is rounded up, otherwise the target might never scale up
+			proposed = int32(math.Ceil(float64(periodStartReplicas) * (1 + float64(policy.Value)/100)))
+		}
+		if (selectMin && proposed < result) || (!selectMin && proposed > result) {
+			result = proposed
+		}
+	}
+	return result
+}
+
+// getScaleDownLimit returns the minimum replica count allowed by the scale down policies
+func getScaleDownLimit(currentReplicas int32, state *directScalingState, rules *autoscalingv2.HPAScalingRules, now time.Time) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	selectMin := *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect
+	result := int32(math.MaxInt32)
+	if selectMin {
+		result = math.MinInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := getPeriodStartReplicas(currentReplicas, state, policy.PeriodSeconds, now)
+		var proposed int32
+		switch policy.Type {
+		case autoscalingv2.PodsScalingPolicy:
+			proposed = periodStartReplicas - policy.Value
+		case autoscalingv2.PercentScalingPolicy:
+			proposed = int32(float64(periodStartReplicas) * (1 - float64(policy.Value)/100))
+		}
+		// the smallest change for scale down is the highest replica count
+		if (selectMin && proposed > result) || (!selectMin && proposed < result) {
+			result = proposed
+		}
+	}
+	return result
+}
+
+// getPeriodStartReplicas returns the replica count at the beginning of the policy period
+func getPeriodStartReplicas(currentReplicas int32, state *directScalingState, periodSeconds int32, now time.Time) int32 {
+	cutoff := now.Add(-time.Duration(periodSeconds) * time.Second)
+	replicas := currentReplicas
+	for _, event := range state.scaleUpEvents {
+		if event.timestamp.After(cutoff) {
+			replicas -= event.replicaChange
+		}
+	}
+	for _, event := range state.scaleDownEvents {
+		if event.timestamp.After(cutoff) {
+			replicas += event.replicaChange
+		}
+	}
+	return replicas
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func averageValueMetric(value float64, target int64) DirectScalingMetric {
+	return DirectScalingMetric{
+		MetricName: "s0-metric",
+		Value:      value,
+		Target: autoscalingv2.MetricTarget{
+			Type:         autoscalingv2.AverageValueMetricType,
+			AverageValue: resource.NewQuantity(target, resource.DecimalSI),
+		},
+	}
+}
+
+func TestGetDirectScalingDesiredReplicas(t *testing.T) {
+	// AverageValue target, 50 / 5 = 10 replicas
+	assert.Equal(t, int32(10), getDirectScalingDesiredReplicas(2, []DirectScalingMetric{averageValueMetric(50, 5)}))
+
+	// usage ratio within tolerance keeps the current replica count
+	assert.Equal(t, int32(10), getDirectScalingDesiredReplicas(10, []DirectScalingMetric{averageValueMetric(52, 5)}))
+
+	// Value target, ratio 2 doubles the current replica count
+	valueMetric := DirectScalingMetric{
+		MetricName: "s1-metric",
+		Value:      20,
+		Target: autoscalingv2.MetricTarget{
+			Type:  autoscalingv2.ValueMetricType,
+			Value: resource.NewQuantity(10, resource.DecimalSI),
+		},
+	}
+	assert.Equal(t, int32(6), getDirectScalingDesiredReplicas(3, []DirectScalingMetric{valueMetric}))
+
+	// the highest proposal wins
+	assert.Equal(t, int32(10), getDirectScalingDesiredReplicas(3, []DirectScalingMetric{valueMetric, averageValueMetric(50, 5)}))
+
+	// no usable metric keeps the current replica count
+	assert.Equal(t, int32(3), getDirectScalingDesiredReplicas(3, nil))
+}
+
+func TestNormalizeDirectScalingDesiredReplicasScaleUpPolicies(t *testing.T) {
+	e := &scaleExecutor{}
+	now := time.Now()
+
+	// default scale up policies allow max(4 pods, 100%) per 15s
+	assert.Equal(t, int32(6), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 2, 20, 1, 100, now))
+	assert.Equal(t, int32(20), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 10, 20, 1, 100, now))
+
+	// max replicas is respected
+	assert.Equal(t, int32(15), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 10, 20, 1, 15, now))
+
+	// scale events within the period reduce the allowed change
+	e = &scaleExecutor{}
+	e.recordDirectScaleEvent("ns/so", 2, now.Add(-5*time.Second))
+	assert.Equal(t, int32(6), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 4, 20, 1, 100, now))
+	assert.Equal(t, int32(8), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 4, 20, 1, 100, now.Add(15*time.Second)))
+}
+
+func TestNormalizeDirectScalingDesiredReplicasScaleDownStabilization(t *testing.T) {
+	e := &scaleExecutor{}
+	now := time.Now()
+
+	// the highest recommendation within the default scale down window (300s) is used
+	assert.Equal(t, int32(10), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 10, 10, 1, 100, now))
+	assert.Equal(t, int32(10), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 10, 2, 1, 100, now.Add(time.Minute)))
+	assert.Equal(t, int32(2), e.normalizeDirectScalingDesiredReplicas("ns/so", nil, 10, 2, 1, 100, now.Add(6*time.Minute)))
+
+	// custom behavior without stabilization window and with pods policy
+	window := int32(0)
+	behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: &window,
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60},
+			},
+		},
+	}
+	e = &scaleExecutor{}
+	assert.Equal(t, int32(9), e.normalizeDirectScalingDesiredReplicas("ns/so", behavior, 10, 2, 1, 100, now))
+
+	// disabled scale down keeps the current replica count
+	disabled := autoscalingv2.DisabledPolicySelect
+	behavior.ScaleDown.SelectPolicy = &disabled
+	assert.Equal(t, int32(10), e.normalizeDirectScalingDesiredReplicas("ns/so", behavior, 10, 2, 1, 100, now))
+}
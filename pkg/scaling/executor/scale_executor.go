@@ -19,6 +19,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,10 +38,11 @@ const (
 	defaultCooldownPeriod = 5 * 60 // 5 minutes
 )
 
-// ScaleExecutor contains methods RequestJobScale and RequestScale
+// ScaleExecutor contains methods RequestJobScale, RequestScale and RequestDirectScale
 type ScaleExecutor interface {
 	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool)
+	RequestDirectScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, metrics []DirectScalingMetric)
 }
 
 type scaleExecutor struct {
@@ -49,6 +51,11 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+
+	// directScalingStates holds the recommendations and scale events used for
+	// stabilization windows and scaling policies in direct scaling mode
+	directScalingStates     map[string]*directScalingState
+	directScalingStatesLock sync.Mutex
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
@@ -81,6 +88,22 @@ func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Lo
 	return kedastatus.TransformObject(ctx, e.client, logger, object, now, transform)
 }
 
+func (e *scaleExecutor) setScalingStage(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, stage *kedav1alpha1.ScalingStageStatus) error {
+	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
+		stage, ok := target.(*kedav1alpha1.ScalingStageStatus)
+		if !ok {
+			return fmt.Errorf("transform target is not *kedav1alpha1.ScalingStageStatus type %v", target)
+		}
+		switch obj := runtimeObj.(type) {
+		case *kedav1alpha1.ScaledObject:
+			obj.Status.ScalingStage = stage
+		default:
+		}
+		return nil
+	}
+	return kedastatus.TransformObject(ctx, e.client, logger, scaledObject, stage, transform)
+}
+
 func (e *scaleExecutor) setCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string, setCondition func(kedav1alpha1.Conditions, metav1.ConditionStatus, string, string)) error {
 	type transformStruct struct {
 		status  metav1.ConditionStatus
@@ -120,6 +143,27 @@ func (e *scaleExecutor) setActiveCondition(ctx context.Context, logger logr.Logg
 	return e.setCondition(ctx, logger, object, status, reason, message, active)
 }
 
+func (e *scaleExecutor) setDegradedCondition(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, status metav1.ConditionStatus, reason string, message string) error {
+	type transformStruct struct {
+		status  metav1.ConditionStatus
+		reason  string
+		message string
+	}
+	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
+		transformObj := target.(*transformStruct)
+		if obj, ok := runtimeObj.(*kedav1alpha1.ScaledJob); ok {
+			obj.Status.Conditions.SetDegradedCondition(transformObj.status, transformObj.reason, transformObj.message)
+		}
+		return nil
+	}
+	target := transformStruct{
+		status:  status,
+		reason:  reason,
+		message: message,
+	}
+	return kedastatus.TransformObject(ctx, e.client, logger, scaledJob, &target, transform)
+}
+
 func (e *scaleExecutor) setFallbackCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string) error {
 	fallback := func(conditions kedav1alpha1.Conditions, status metav1.ConditionStatus, reason string, message string) {
 		conditions.SetFallbackCondition(status, reason, message)
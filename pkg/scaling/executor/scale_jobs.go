@@ -18,8 +18,10 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
@@ -52,6 +54,8 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		effectiveMaxScale = 0
 	}
 
+	isBackedOff, isProbing := e.checkFailureBackoff(ctx, logger, scaledJob)
+
 	if isActive {
 		logger.V(1).Info("At least one scaler is active")
 		now := metav1.Now()
@@ -60,7 +64,18 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		if err != nil {
 			logger.Error(err, "Failed to update last active time")
 		}
-		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		switch {
+		case isBackedOff:
+			logger.Info("Jobs creation is paused because of consecutive failed jobs")
+		case isProbing && runningJobCount+pendingJobCount > 0:
+			logger.Info("Jobs creation is paused until the job probing the failures finishes")
+		case isProbing:
+			// a single job probes whether the failures are over before creating jobs for the whole demand
+			logger.Info("Creating a single job after the failure backoff")
+			e.createJobs(ctx, logger, scaledJob, min(scaleTo, 1), min(effectiveMaxScale, 1))
+		default:
+			e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		}
 	} else {
 		logger.V(1).Info("No change in activity")
 	}
@@ -86,6 +101,116 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 	}
 }
 
+// checkFailureBackoff returns whether jobs creation should be paused because the most recently finished
+// jobs failed in a row, and whether the backoff is over and a single job should probe whether the failures
+// are over. It also reports the transitions through the Degraded condition and events.
+func (e *scaleExecutor) checkFailureBackoff(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) (isBackedOff bool, isProbing bool) {
+	failureBackoff := scaledJob.Spec.FailureBackoff
+	if failureBackoff == nil {
+		return false, false
+	}
+
+	consecutiveFailedJobs, lastFailureTime, err := e.getConsecutiveFailedJobs(ctx, scaledJob)
+	if err != nil {
+		logger.Error(err, "Failed to get consecutive failed jobs")
+		return false, false
+	}
+
+	degraded := scaledJob.Status.Conditions.GetDegradedCondition()
+	if consecutiveFailedJobs < failureBackoff.FailureThreshold {
+		if degraded.IsTrue() {
+			msg := "Jobs creation is resumed because a job finished successfully"
+			if err := e.setDegradedCondition(ctx, logger, scaledJob, metav1.ConditionFalse, kedav1alpha1.ScaledJobConditionJobsSucceedingReason, msg); err != nil {
+				logger.Error(err, "Error setting degraded condition when jobs are succeeding")
+			}
+			e.recorder.Event(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreationResumed, msg)
+		}
+		return false, false
+	}
+
+	delay := getFailureBackoffDelay(failureBackoff, consecutiveFailedJobs)
+	if !degraded.IsTrue() {
+		msg := fmt.Sprintf("Jobs creation is paused with exponential backoff because %d consecutive jobs failed", consecutiveFailedJobs)
+		if err := e.setDegradedCondition(ctx, logger, scaledJob, metav1.ConditionTrue, kedav1alpha1.ScaledJobConditionFailureBackoffReason, msg); err != nil {
+			logger.Error(err, "Error setting degraded condition when jobs are failing")
+		}
+		e.recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAJobsCreationBackoff, msg)
+	}
+
+	resumeTime := lastFailureTime.Add(delay)
+	logger.V(1).Info("Jobs are failing", "consecutiveFailedJobs", consecutiveFailedJobs, "backoff", delay, "resumeTime", resumeTime)
+	if time.Now().Before(resumeTime) {
+		return true, false
+	}
+	return false, true
+}
+
+// getFailureBackoffDelay returns the delay doubled for every failed job above the FailureThreshold
+// and capped by the max delay
+func getFailureBackoffDelay(failureBackoff *kedav1alpha1.FailureBackoff, consecutiveFailedJobs int32) time.Duration {
+	delay := failureBackoff.GetInitialDelay()
+	maxDelay := failureBackoff.GetMaxDelay()
+	for i := failureBackoff.FailureThreshold; i < consecutiveFailedJobs && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// getConsecutiveFailedJobs returns the number of the most recently finished jobs that failed in a row
+// and the time when the last of them finished. Only jobs kept by FailedJobsHistoryLimit can be counted.
+func (e *scaleExecutor) getConsecutiveFailedJobs(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (int32, time.Time, error) {
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+	}
+
+	jobs := &batchv1.JobList{}
+	err := e.client.List(ctx, jobs, opts...)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	var finishedJobs []batchv1.Job
+	for _, job := range jobs.Items {
+		if e.getFinishedJobConditionType(&job) != "" {
+			finishedJobs = append(finishedJobs, job)
+		}
+	}
+	sort.Slice(finishedJobs, func(i, j int) bool {
+		return e.getJobFinishedTime(&finishedJobs[i]).After(e.getJobFinishedTime(&finishedJobs[j]))
+	})
+
+	var consecutiveFailedJobs int32
+	var lastFailureTime time.Time
+	for i := range finishedJobs {
+		if e.getFinishedJobConditionType(&finishedJobs[i]) != batchv1.JobFailed {
+			break
+		}
+		if consecutiveFailedJobs == 0 {
+			lastFailureTime = e.getJobFinishedTime(&finishedJobs[i])
+		}
+		consecutiveFailedJobs++
+	}
+	return consecutiveFailedJobs, lastFailureTime, nil
+}
+
+// getJobFinishedTime returns the time of the finished condition transition,
+// failed jobs don't have CompletionTime set
+func (e *scaleExecutor) getJobFinishedTime(j *batchv1.Job) time.Time {
+	for _, c := range j.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue && !c.LastTransitionTime.IsZero() {
+			return c.LastTransitionTime.Time
+		}
+	}
+	if j.Status.CompletionTime != nil {
+		return j.Status.CompletionTime.Time
+	}
+	return time.Time{}
+}
+
 func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, runningJobCount int64, scaleTo int64, maxScale int64, pendingJobCount int64, logger logr.Logger) (int64, int64) {
 	var effectiveMaxScale int64
 	minReplicaCount := scaledJob.MinReplicaCount()
@@ -32,9 +32,11 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 )
 
@@ -356,6 +358,71 @@ type pendingJobTestData struct {
 	PendingJobCount      int64
 }
 
+func TestGetConsecutiveFailedJobs(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scaledJob := getMockScaledJobWithDefault()
+	var actualDeletedJobName = make(map[string]string)
+
+	client := getMockClient(t, ctrl, &[]mockJobParameter{
+		{Name: "name1", CompletionTime: "2020-07-29T15:37:00Z", JobConditionType: batchv1.JobFailed},
+		{Name: "name2", CompletionTime: "2020-07-29T15:36:00Z", JobConditionType: batchv1.JobComplete},
+		{Name: "name3", CompletionTime: "2020-07-29T15:38:00Z", JobConditionType: batchv1.JobFailed},
+		{Name: "name4", CompletionTime: "2020-07-29T15:35:00Z", JobConditionType: batchv1.JobFailed},
+		{Name: "name5", CompletionTime: "2020-07-29T15:39:00Z", JobConditionType: batchv1.JobFailed},
+	}, &actualDeletedJobName)
+
+	scaleExecutor := getMockScaleExecutor(client)
+
+	consecutiveFailedJobs, lastFailureTime, err := scaleExecutor.getConsecutiveFailedJobs(ctx, scaledJob)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), consecutiveFailedJobs)
+	assert.Equal(t, "2020-07-29T15:39:00Z", lastFailureTime.UTC().Format(time.RFC3339))
+}
+
+func TestGetConsecutiveFailedJobsAfterSuccessfulJob(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scaledJob := getMockScaledJobWithDefault()
+	var actualDeletedJobName = make(map[string]string)
+
+	client := getMockClient(t, ctrl, &[]mockJobParameter{
+		{Name: "name1", CompletionTime: "2020-07-29T15:37:00Z", JobConditionType: batchv1.JobFailed},
+		{Name: "name2", CompletionTime: "2020-07-29T15:38:00Z", JobConditionType: batchv1.JobComplete},
+	}, &actualDeletedJobName)
+
+	scaleExecutor := getMockScaleExecutor(client)
+
+	consecutiveFailedJobs, _, err := scaleExecutor.getConsecutiveFailedJobs(ctx, scaledJob)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), consecutiveFailedJobs)
+}
+
+func TestGetFailureBackoffDelay(t *testing.T) {
+	initialDelaySeconds := int32(10)
+	maxDelaySeconds := int32(60)
+	failureBackoff := &kedav1alpha1.FailureBackoff{
+		FailureThreshold:    3,
+		InitialDelaySeconds: &initialDelaySeconds,
+		MaxDelaySeconds:     &maxDelaySeconds,
+	}
+
+	assert.Equal(t, 10*time.Second, getFailureBackoffDelay(failureBackoff, 3))
+	assert.Equal(t, 20*time.Second, getFailureBackoffDelay(failureBackoff, 4))
+	assert.Equal(t, 40*time.Second, getFailureBackoffDelay(failureBackoff, 5))
+	assert.Equal(t, 60*time.Second, getFailureBackoffDelay(failureBackoff, 6))
+	assert.Equal(t, 60*time.Second, getFailureBackoffDelay(failureBackoff, 100))
+
+	// defaults
+	failureBackoff = &kedav1alpha1.FailureBackoff{FailureThreshold: 1}
+	assert.Equal(t, 30*time.Second, getFailureBackoffDelay(failureBackoff, 1))
+	assert.Equal(t, time.Hour, getFailureBackoffDelay(failureBackoff, 20))
+}
+
 func getMockScaleExecutor(client *mock_client.MockClient) *scaleExecutor {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
@@ -551,3 +618,164 @@ func getPodCondition(podConditionType v1.PodConditionType) v1.PodCondition {
 		Status: v1.ConditionTrue,
 	}
 }
+
+func TestRequestJobScaleDuringFailureBackoff(t *testing.T) {
+	ctx := context.Background()
+	scaledJob := getMockScaledJobWithFailureBackoff()
+	now := time.Now()
+	client := getFakeClientWithJobs(scaledJob,
+		getFinishedJob("failed1", batchv1.JobFailed, now.Add(-3*time.Second)),
+		getFinishedJob("failed2", batchv1.JobFailed, now.Add(-2*time.Second)),
+		getFinishedJob("failed3", batchv1.JobFailed, now.Add(-1*time.Second)),
+	)
+	recorder := record.NewFakeRecorder(10)
+	scaleExecutor := getFakeScaleExecutor(client, recorder)
+
+	scaleExecutor.RequestJobScale(ctx, scaledJob, true, 5, 5)
+
+	assert.Equal(t, 0, getUnfinishedJobCount(t, client))
+	degraded := getScaledJobDegradedCondition(t, client, scaledJob)
+	assert.True(t, degraded.IsTrue())
+	assert.Equal(t, kedav1alpha1.ScaledJobConditionFailureBackoffReason, degraded.Reason)
+	assert.Contains(t, getRecordedEvents(recorder), eventreason.KEDAJobsCreationBackoff)
+}
+
+func TestRequestJobScaleProbesWithSingleJobAfterFailureBackoff(t *testing.T) {
+	ctx := context.Background()
+	scaledJob := getMockScaledJobWithFailureBackoff()
+	past := time.Now().Add(-time.Hour)
+	client := getFakeClientWithJobs(scaledJob,
+		getFinishedJob("failed1", batchv1.JobFailed, past.Add(-2*time.Second)),
+		getFinishedJob("failed2", batchv1.JobFailed, past.Add(-1*time.Second)),
+		getFinishedJob("failed3", batchv1.JobFailed, past),
+	)
+	recorder := record.NewFakeRecorder(10)
+	scaleExecutor := getFakeScaleExecutor(client, recorder)
+
+	scaleExecutor.RequestJobScale(ctx, scaledJob, true, 5, 5)
+
+	assert.Equal(t, 1, getUnfinishedJobCount(t, client))
+	degraded := getScaledJobDegradedCondition(t, client, scaledJob)
+	assert.True(t, degraded.IsTrue())
+
+	// the probing job is still running, no other job is created
+	scaleExecutor.RequestJobScale(ctx, scaledJob, true, 5, 5)
+
+	assert.Equal(t, 1, getUnfinishedJobCount(t, client))
+}
+
+func TestRequestJobScaleResumesAfterSuccessfulJob(t *testing.T) {
+	ctx := context.Background()
+	scaledJob := getMockScaledJobWithFailureBackoff()
+	scaledJob.Status.Conditions.SetDegradedCondition(metav1.ConditionTrue, kedav1alpha1.ScaledJobConditionFailureBackoffReason, "failing")
+	now := time.Now()
+	client := getFakeClientWithJobs(scaledJob,
+		getFinishedJob("failed1", batchv1.JobFailed, now.Add(-4*time.Second)),
+		getFinishedJob("failed2", batchv1.JobFailed, now.Add(-3*time.Second)),
+		getFinishedJob("failed3", batchv1.JobFailed, now.Add(-2*time.Second)),
+		getFinishedJob("succeeded", batchv1.JobComplete, now.Add(-1*time.Second)),
+	)
+	recorder := record.NewFakeRecorder(10)
+	scaleExecutor := getFakeScaleExecutor(client, recorder)
+
+	scaleExecutor.RequestJobScale(ctx, scaledJob, true, 5, 5)
+
+	assert.Equal(t, 5, getUnfinishedJobCount(t, client))
+	degraded := getScaledJobDegradedCondition(t, client, scaledJob)
+	assert.True(t, degraded.IsFalse())
+	assert.Equal(t, kedav1alpha1.ScaledJobConditionJobsSucceedingReason, degraded.Reason)
+	assert.Contains(t, getRecordedEvents(recorder), eventreason.KEDAJobsCreationResumed)
+}
+
+func getFakeScaleExecutor(client runtimeclient.Client, recorder record.EventRecorder) *scaleExecutor {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	return &scaleExecutor{
+		client:           client,
+		scaleClient:      nil,
+		reconcilerScheme: scheme,
+		logger:           logf.Log.WithName("scaleexecutor"),
+		recorder:         recorder,
+	}
+}
+
+func getFakeClientWithJobs(scaledJob *kedav1alpha1.ScaledJob, jobs ...*batchv1.Job) runtimeclient.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaledJob).WithStatusSubresource(scaledJob)
+	for _, job := range jobs {
+		job.Namespace = scaledJob.Namespace
+		job.Labels = map[string]string{"scaledjob.keda.sh/name": scaledJob.Name}
+		builder = builder.WithObjects(job)
+	}
+	return builder.Build()
+}
+
+func getMockScaledJobWithFailureBackoff() *kedav1alpha1.ScaledJob {
+	initialDelaySeconds := int32(60)
+	return &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "failing-consumer",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledJobSpec{
+			JobTargetRef: &batchv1.JobSpec{},
+			FailureBackoff: &kedav1alpha1.FailureBackoff{
+				FailureThreshold:    3,
+				InitialDelaySeconds: &initialDelaySeconds,
+			},
+		},
+		Status: kedav1alpha1.ScaledJobStatus{
+			Conditions: *kedav1alpha1.GetInitializedConditions(),
+		},
+	}
+}
+
+func getFinishedJob(name string, jobConditionType batchv1.JobConditionType, finishedTime time.Time) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:               jobConditionType,
+					Status:             v1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(finishedTime),
+				},
+			},
+		},
+	}
+}
+
+func getUnfinishedJobCount(t *testing.T, client runtimeclient.Client) int {
+	jobs := &batchv1.JobList{}
+	assert.NoError(t, client.List(context.Background(), jobs))
+	var count int
+	for _, job := range jobs.Items {
+		if len(job.Status.Conditions) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func getScaledJobDegradedCondition(t *testing.T, client runtimeclient.Client, scaledJob *kedav1alpha1.ScaledJob) kedav1alpha1.Condition {
+	current := &kedav1alpha1.ScaledJob{}
+	assert.NoError(t, client.Get(context.Background(), runtimeclient.ObjectKeyFromObject(scaledJob), current))
+	return current.Status.Conditions.GetDegradedCondition()
+}
+
+func getRecordedEvents(recorder *record.FakeRecorder) string {
+	var events string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events += event + "\n"
+		default:
+			return events
+		}
+	}
+}
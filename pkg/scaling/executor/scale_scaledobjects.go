@@ -37,37 +37,10 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
 		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
-	// Get the current replica count. As a special case, Deployments and StatefulSets fetch directly from the object so they can use the informer cache
-	// to reduce API calls. Everything else uses the scale subresource.
-	var currentScale *autoscalingv1.Scale
-	var currentReplicas int32
-	targetName := scaledObject.Spec.ScaleTargetRef.Name
-	targetGVKR := scaledObject.Status.ScaleTargetGVKR
-	switch {
-	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
-		deployment := &appsv1.Deployment{}
-		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, deployment)
-		if err != nil {
-			logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
-			return
-		}
-		currentReplicas = *deployment.Spec.Replicas
-	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
-		statefulSet := &appsv1.StatefulSet{}
-		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, statefulSet)
-		if err != nil {
-			logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
-			return
-		}
-		currentReplicas = *statefulSet.Spec.Replicas
-	default:
-		var err error
-		currentScale, err = e.getScaleTargetScale(ctx, scaledObject)
-		if err != nil {
-			logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
-			return
-		}
-		currentReplicas = currentScale.Spec.Replicas
+	currentScale, currentReplicas, err := e.getCurrentReplicas(ctx, scaledObject)
+	if err != nil {
+		logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
+		return
 	}
 	// if the ScaledObject's triggers aren't in the error state,
 	// but ScaledObject.Status.ReadyCondition is set not set to 'true' -> set it back to 'true'
@@ -115,10 +88,11 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		return
 	}
 
-	// if scaledObject.Spec.MinReplicaCount is not set, then set the default value (0)
+	// if scaledObject.Spec.MinReplicaCount is not set, then set the default value (0),
+	// minReplicaCount can be overridden by an active replica count schedule
 	minReplicas := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		minReplicas = *scaledObject.Spec.MinReplicaCount
+	if minReplicaCount := scaledObject.GetMinReplicaCount(); minReplicaCount != nil {
+		minReplicas = *minReplicaCount
 	}
 
 	if isActive {
@@ -155,6 +129,14 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				logger.Error(err, "Error updating last active time")
 				return
 			}
+
+			// the target is scaled up from the activation replica count by now, or triggers became active again
+			// during staged scale down and the scale down is interrupted
+			if scaledObject.Status.ScalingStage != nil {
+				if err := e.setScalingStage(ctx, logger, scaledObject, nil); err != nil {
+					logger.Error(err, "Error clearing scaling stage")
+				}
+			}
 		}
 	} else {
 		// isActive == false
@@ -190,7 +172,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is no minimum configured or minimum is set to ZERO
 
 			// Try to scale the deployment down, HPA will handle other scale in operations
-			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale, currentReplicas)
 		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
 			// there are no active triggers
 			// AND
@@ -199,17 +181,23 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// Idle Replicas mode is disabled
 
 			// ScaleTarget replicas count to correct value
-			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *scaledObject.Spec.MinReplicaCount)
+			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, minReplicas)
 			if err == nil {
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
 					"Original Replicas Count", currentReplicas,
-					"New Replicas Count", *scaledObject.Spec.MinReplicaCount)
+					"New Replicas Count", minReplicas)
 			}
 		default:
 			// there are no active triggers
 			// AND
 			// nothing needs to be done (eg. deployment is scaled down)
 			logger.V(1).Info("ScaleTarget no change")
+
+			if scaledObject.Status.ScalingStage != nil {
+				if err := e.setScalingStage(ctx, logger, scaledObject, nil); err != nil {
+					logger.Error(err, "Error clearing scaling stage")
+				}
+			}
 		}
 	}
 
@@ -229,6 +217,36 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	}
 }
 
+// getCurrentReplicas returns the current replica count of the scale target. As a special case, Deployments and StatefulSets
+// fetch directly from the object so they can use the informer cache to reduce API calls. Everything else uses the scale subresource,
+// which is returned as well, so it can be reused for the update.
+func (e *scaleExecutor) getCurrentReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, int32, error) {
+	targetName := scaledObject.Spec.ScaleTargetRef.Name
+	targetGVKR := scaledObject.Status.ScaleTargetGVKR
+	switch {
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
+		deployment := &appsv1.Deployment{}
+		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, deployment)
+		if err != nil {
+			return nil, 0, err
+		}
+		return nil, *deployment.Spec.Replicas, nil
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, statefulSet)
+		if err != nil {
+			return nil, 0, err
+		}
+		return nil, *statefulSet.Spec.Replicas, nil
+	default:
+		currentScale, err := e.getScaleTargetScale(ctx, scaledObject)
+		if err != nil {
+			return nil, 0, err
+		}
+		return currentScale, currentScale.Spec.Replicas, nil
+	}
+}
+
 func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentScale *autoscalingv1.Scale, logger logr.Logger, currentReplicas int32) {
 	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, scaledObject.Spec.Fallback.Replicas)
 	if err == nil {
@@ -243,7 +261,7 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 
 // An object will be scaled down to 0 only if it's passed its cooldown period
 // or if LastActiveTime is nil
-func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, currentReplicas int32) {
 	var cooldownPeriod time.Duration
 
 	if scaledObject.Spec.CooldownPeriod != nil {
@@ -258,6 +276,13 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(time.Now()) {
 		// or last time a trigger was active was > cooldown period, so scale in.
 
+		// with staged scale down, the target is kept on each of the steps first
+		if staging := scaledObject.GetZeroScalingStaging(); staging != nil && len(staging.ScaleDownSteps) > 0 {
+			if !e.scaleDownStepByStep(ctx, logger, scaledObject, scale, currentReplicas, staging.ScaleDownSteps) {
+				return
+			}
+		}
+
 		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
 
 		currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, scaleToReplicas)
@@ -272,6 +297,11 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 
 			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
 				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
+			if scaledObject.Status.ScalingStage != nil {
+				if err := e.setScalingStage(ctx, logger, scaledObject, nil); err != nil {
+					logger.Error(err, "Error clearing scaling stage")
+				}
+			}
 			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerNotActive", "Scaling is not performed because triggers are not active"); err != nil {
 				logger.Error(err, "Error in setting active condition")
 				return
@@ -295,11 +325,63 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 	}
 }
 
+// scaleDownStepByStep keeps the target on each of the scale down steps for its duration, one after another.
+// It returns true once the last step is over and the target can be scaled to zero (or idle) replicas.
+func (e *scaleExecutor) scaleDownStepByStep(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, currentReplicas int32, steps []kedav1alpha1.ScaleDownStep) bool {
+	step := int32(0)
+	if stage := scaledObject.Status.ScalingStage; stage != nil && stage.Stage == kedav1alpha1.ScalingStageScaleDown {
+		if int(stage.Step) >= len(steps) {
+			return true
+		}
+		duration := time.Second * time.Duration(steps[stage.Step].DurationSeconds)
+		if stage.StartTime.Add(duration).After(time.Now()) {
+			logger.V(1).Info("ScaleTarget on scale down step", "step", stage.Step, "replicas", stage.Replicas, "startTime", stage.StartTime)
+			return false
+		}
+		step = stage.Step + 1
+		if int(step) >= len(steps) {
+			return true
+		}
+	}
+
+	// the target is never scaled up during scale down, the step only keeps the lower replica count for its duration
+	replicas := steps[step].Replicas
+	if replicas < currentReplicas {
+		_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, replicas)
+		if err != nil {
+			logger.Error(err, "Error scaling ScaleTarget to scale down step", "step", step, "replicas", replicas)
+			e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetDeactivationFailed,
+				"Failed to scale %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
+			return false
+		}
+		logger.Info("Successfully set ScaleTarget replicas count to scale down step", "step", step, "Original Replicas Count", currentReplicas, "New Replicas Count", replicas)
+		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetScaleDownStep,
+			"Scaled %s %s/%s from %d to %d (scale down step %d)", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, step)
+	} else {
+		replicas = currentReplicas
+	}
+
+	stage := &kedav1alpha1.ScalingStageStatus{
+		Stage:     kedav1alpha1.ScalingStageScaleDown,
+		Step:      step,
+		Replicas:  replicas,
+		StartTime: metav1.Now(),
+	}
+	if err := e.setScalingStage(ctx, logger, scaledObject, stage); err != nil {
+		logger.Error(err, "Error setting scaling stage")
+	}
+	return false
+}
+
 func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
 	var replicas int32
-	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
-		replicas = *scaledObject.Spec.MinReplicaCount
-	} else {
+	staging := scaledObject.GetZeroScalingStaging()
+	switch minReplicaCount := scaledObject.GetMinReplicaCount(); {
+	case staging != nil && staging.ActivationReplicaCount != nil:
+		replicas = *staging.ActivationReplicaCount
+	case minReplicaCount != nil && *minReplicaCount > 0:
+		replicas = *minReplicaCount
+	default:
 		replicas = 1
 	}
 
@@ -316,6 +398,21 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 			logger.Error(err, "Error in Updating lastScaleTime and lastActiveTime on the scaledObject")
 			return
 		}
+
+		if staging != nil {
+			stage := &kedav1alpha1.ScalingStageStatus{
+				Stage:     kedav1alpha1.ScalingStageActivation,
+				Replicas:  replicas,
+				StartTime: metav1.Now(),
+			}
+			if err := e.setScalingStage(ctx, logger, scaledObject, stage); err != nil {
+				logger.Error(err, "Error setting scaling stage")
+			}
+		} else if scaledObject.Status.ScalingStage != nil {
+			if err := e.setScalingStage(ctx, logger, scaledObject, nil); err != nil {
+				logger.Error(err, "Error clearing scaling stage")
+			}
+		}
 	} else {
 		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetActivationFailed, "Failed to scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 	}
@@ -350,11 +447,12 @@ func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool
 		return true, *scaledObject.Spec.IdleReplicaCount
 	}
 
-	if scaledObject.Spec.MinReplicaCount == nil {
+	minReplicaCount := scaledObject.GetMinReplicaCount()
+	if minReplicaCount == nil {
 		return false, 0
 	}
 
-	return false, *scaledObject.Spec.MinReplicaCount
+	return false, *minReplicaCount
 }
 
 // GetPausedReplicaCount returns the paused replica count of the ScaledObject.
@@ -0,0 +1,310 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// AuthCacheTTLEnvVar defines for how long resolved authentication parameters are cached, 0 disables the cache
+	AuthCacheTTLEnvVar  = "KEDA_AUTH_CACHE_TTL"
+	defaultAuthCacheTTL = 5 * time.Minute
+)
+
+var authCache = newAuthResolutionCache(getAuthCacheTTL())
+
+// AuthenticationKey identifies a TriggerAuthentication (Kind/Namespace/Name) or a ClusterTriggerAuthentication (Kind/Name)
+type AuthenticationKey string
+
+// GetAuthenticationKey returns the key of the authentication object referenced from a trigger in the input namespace
+func GetAuthenticationKey(triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) AuthenticationKey {
+	kind := triggerAuthRef.Kind
+	if kind == "" {
+		kind = "TriggerAuthentication"
+	}
+	if kind == "ClusterTriggerAuthentication" {
+		return AuthenticationKey(fmt.Sprintf("%s/%s", kind, triggerAuthRef.Name))
+	}
+	return AuthenticationKey(fmt.Sprintf("%s/%s/%s", kind, namespace, triggerAuthRef.Name))
+}
+
+// authCacheKey identifies the parameters resolved from an authentication object for a scale target. A ClusterTriggerAuthentication
+// is referenced from several namespaces and parameters may be resolved with the identity of the workload (eg: Vault JWT authentication),
+// so the namespace and the service account of the scale target are part of the key
+type authCacheKey struct {
+	authentication AuthenticationKey
+	namespace      string
+	serviceAccount string
+	generation     int64
+}
+
+type authCacheEntry struct {
+	params      map[string]string
+	podIdentity kedav1alpha1.AuthPodIdentity
+	metadata    scalersconfig.AuthParamsMetadata
+	secrets     []types.NamespacedName
+	expiration  time.Time
+}
+
+// authParams returns a copy of the cached authentication parameters, as scalers are allowed to modify them
+func (e *authCacheEntry) authParams() map[string]string {
+	params := make(map[string]string, len(e.params))
+	for k, v := range e.params {
+		params[k] = v
+	}
+	return params
+}
+
+// authResolutionCache stores resolved authentication parameters shared by all the scalers referencing
+// the same TriggerAuthentication or ClusterTriggerAuthentication. Entries are bounded by a TTL and
+// by the expiration of the resolved parameters, and are invalidated when a referenced Secret changes.
+type authResolutionCache struct {
+	lock  sync.RWMutex
+	ttl   time.Duration
+	items map[authCacheKey]*authCacheEntry
+}
+
+func newAuthResolutionCache(ttl time.Duration) *authResolutionCache {
+	return &authResolutionCache{
+		ttl:   ttl,
+		items: make(map[authCacheKey]*authCacheEntry),
+	}
+}
+
+func getAuthCacheTTL() time.Duration {
+	ttl, err := util.ResolveOsEnvDuration(AuthCacheTTLEnvVar)
+	if err != nil {
+		log.Error(err, "invalid auth cache TTL, using default", "Env Var", AuthCacheTTLEnvVar, "default", defaultAuthCacheTTL)
+		return defaultAuthCacheTTL
+	}
+	if ttl == nil {
+		return defaultAuthCacheTTL
+	}
+	return *ttl
+}
+
+func (c *authResolutionCache) get(key authCacheKey, now time.Time) (*authCacheEntry, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	entry, ok := c.items[key]
+	if !ok || !now.Before(entry.expiration) || entry.metadata.IsOutdated(now) {
+		return nil, false
+	}
+	return entry, true
+}
+
+func (c *authResolutionCache) set(key authCacheKey, params map[string]string, podIdentity kedav1alpha1.AuthPodIdentity,
+	metadata scalersconfig.AuthParamsMetadata, secrets []types.NamespacedName, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	expiration := now.Add(c.ttl)
+	if !metadata.Expiration.IsZero() && metadata.Expiration.Before(expiration) {
+		expiration = metadata.Expiration
+	}
+	entry := &authCacheEntry{
+		podIdentity: podIdentity,
+		metadata:    metadata,
+		secrets:     secrets,
+		expiration:  expiration,
+	}
+	entry.params = entry.authParams()
+	for k, v := range params {
+		entry.params[k] = v
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// drop expired entries and entries of other generations of the same object
+	for k, e := range c.items {
+		if (k.authentication == key.authentication && k.generation != key.generation) || !now.Before(e.expiration) {
+			delete(c.items, k)
+		}
+	}
+	c.items[key] = entry
+}
+
+// invalidateSecret removes the entries referencing the secret and returns the keys of their authentication objects
+func (c *authResolutionCache) invalidateSecret(namespace, name string) []AuthenticationKey {
+	secret := types.NamespacedName{Namespace: namespace, Name: name}
+	return c.invalidate(func(s types.NamespacedName) bool {
+		return s == secret
+	})
+}
+
+func (c *authResolutionCache) invalidateNamespace(namespace string) []AuthenticationKey {
+	return c.invalidate(func(s types.NamespacedName) bool {
+		return s.Namespace == namespace
+	})
+}
+
+// invalidate removes the entries resolved from any Secret matching the input function
+func (c *authResolutionCache) invalidate(matches func(types.NamespacedName) bool) []AuthenticationKey {
+	var invalidated []AuthenticationKey
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, entry := range c.items {
+		for _, s := range entry.secrets {
+			if matches(s) {
+				if !slices.Contains(invalidated, key.authentication) {
+					invalidated = append(invalidated, key.authentication)
+				}
+				delete(c.items, key)
+				break
+			}
+		}
+	}
+	return invalidated
+}
+
+func (c *authResolutionCache) isSecretReferenced(namespace, name string) bool {
+	secret := types.NamespacedName{Namespace: namespace, Name: name}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	for _, entry := range c.items {
+		for _, s := range entry.secrets {
+			if s == secret {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// InvalidateAuthCacheForSecret removes cached authentication parameters and secret provider values resolved from the input Secret,
+// it returns the keys of the affected authentication objects
+func InvalidateAuthCacheForSecret(namespace, name string) []AuthenticationKey {
+	secret := types.NamespacedName{Namespace: namespace, Name: name}
+	secretProviderCache.invalidate(func(s types.NamespacedName) bool {
+		return s == secret
+	})
+	return authCache.invalidateSecret(namespace, name)
+}
+
+// InvalidateAuthCacheForNamespace removes cached authentication parameters and secret provider values resolved from any Secret in the input namespace,
+// it returns the keys of the affected authentication objects
+func InvalidateAuthCacheForNamespace(namespace string) []AuthenticationKey {
+	secretProviderCache.invalidate(func(s types.NamespacedName) bool {
+		return s.Namespace == namespace
+	})
+	return authCache.invalidateNamespace(namespace)
+}
+
+// IsSecretReferencedByAuthCache returns whether cached authentication parameters or secret provider values have been resolved from the input Secret
+func IsSecretReferencedByAuthCache(namespace, name string) bool {
+	return authCache.isSecretReferenced(namespace, name) || secretProviderCache.isSecretReferenced(types.NamespacedName{Namespace: namespace, Name: name})
+}
+
+// isAuthSpecCacheable returns whether resolved parameters of the spec can be shared, ie. they don't depend on the scale target
+// and they are only read from sources whose changes invalidate the cache (ConfigMaps aren't watched)
+func isAuthSpecCacheable(spec *kedav1alpha1.TriggerAuthenticationSpec) bool {
+	if spec.Env != nil || spec.ConfigMapTargetRef != nil {
+		return false
+	}
+	if spec.AwsSecretManager != nil && spec.AwsSecretManager.PodIdentity != nil && spec.AwsSecretManager.PodIdentity.IsWorkloadIdentityOwner() {
+		return false
+	}
+	if spec.ServiceAccountToken != nil && spec.ServiceAccountToken.IsWorkloadIdentityOwner() {
+		return false
+	}
+	if spec.PodIdentity != nil && spec.PodIdentity.Provider == kedav1alpha1.PodIdentityProviderOIDC && spec.PodIdentity.IsWorkloadIdentityOwner() {
+		return false
+	}
+	return true
+}
+
+// getReferencedSecrets returns the Secrets the authentication parameters of the spec are resolved from
+func getReferencedSecrets(logger logr.Logger, spec *kedav1alpha1.TriggerAuthenticationSpec, triggerNamespace string) []types.NamespacedName {
+	namespace := triggerNamespace
+	restricted := isSecretAccessRestricted(logger)
+	if restricted {
+		namespace = kedaNamespace
+	}
+
+	var names []string
+	var secrets []types.NamespacedName
+	for _, e := range spec.SecretTargetRef {
+		// secrets in other namespaces can only be referenced through a TriggerAuthenticationGrant
+		if e.Namespace != "" && e.Namespace != namespace && !restricted {
+			secrets = append(secrets, types.NamespacedName{Namespace: e.Namespace, Name: e.Name})
+			continue
+		}
+		names = append(names, e.Name)
+	}
+	if spec.HashiCorpVault != nil && spec.HashiCorpVault.Credential != nil {
+		credential := spec.HashiCorpVault.Credential
+		if credential.AppRole != nil {
+			names = append(names, credential.AppRole.RoleID.ValueFrom.SecretKeyRef.Name)
+			if credential.AppRole.SecretID != nil {
+				names = append(names, credential.AppRole.SecretID.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+		if credential.Cert != nil {
+			names = append(names, credential.Cert.Cert.ValueFrom.SecretKeyRef.Name, credential.Cert.Key.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	if spec.AzureKeyVault != nil && spec.AzureKeyVault.Credentials != nil && spec.AzureKeyVault.Credentials.ClientSecret != nil {
+		names = append(names, spec.AzureKeyVault.Credentials.ClientSecret.ValueFrom.SecretKeyRef.Name)
+	}
+	if spec.GCPSecretManager != nil && spec.GCPSecretManager.Credentials != nil {
+		names = append(names, spec.GCPSecretManager.Credentials.ClientSecret.ValueFrom.SecretKeyRef.Name)
+	}
+	if spec.AwsSecretManager != nil && spec.AwsSecretManager.Credentials != nil {
+		credentials := spec.AwsSecretManager.Credentials
+		for _, value := range []*kedav1alpha1.AwsSecretManagerValue{credentials.AccessKey, credentials.AccessSecretKey, credentials.AccessToken} {
+			if value != nil {
+				names = append(names, value.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	if spec.ExternalSecretProvider != nil && spec.ExternalSecretProvider.TLS != nil {
+		tls := spec.ExternalSecretProvider.TLS
+		for _, value := range []*kedav1alpha1.ValueFromSecret{tls.CACert, tls.ClientCert, tls.ClientKey} {
+			if value != nil {
+				names = append(names, value.SecretKeyRef.Name)
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		secrets = append(secrets, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	return secrets
+}
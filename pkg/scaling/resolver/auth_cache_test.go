@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+func TestGetAuthenticationKey(t *testing.T) {
+	assert.Equal(t, AuthenticationKey("TriggerAuthentication/ns/auth"), GetAuthenticationKey(&kedav1alpha1.AuthenticationRef{Name: "auth"}, "ns"))
+	assert.Equal(t, AuthenticationKey("TriggerAuthentication/ns/auth"), GetAuthenticationKey(&kedav1alpha1.AuthenticationRef{Name: "auth", Kind: "TriggerAuthentication"}, "ns"))
+	assert.Equal(t, AuthenticationKey("ClusterTriggerAuthentication/auth"), GetAuthenticationKey(&kedav1alpha1.AuthenticationRef{Name: "auth", Kind: "ClusterTriggerAuthentication"}, "ns"))
+}
+
+func TestAuthResolutionCache(t *testing.T) {
+	now := time.Now()
+	key := authCacheKey{authentication: "TriggerAuthentication/ns/auth", generation: 1}
+	secrets := []types.NamespacedName{{Namespace: "ns", Name: "secret"}}
+	cache := newAuthResolutionCache(time.Minute)
+
+	_, ok := cache.get(key, now)
+	assert.False(t, ok)
+
+	cache.set(key, map[string]string{"password": "value"}, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, scalersconfig.AuthParamsMetadata{}, secrets, now)
+	entry, ok := cache.get(key, now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"password": "value"}, entry.authParams())
+
+	// returned parameters must not alter the cached ones
+	entry.authParams()["password"] = "changed"
+	entry, _ = cache.get(key, now)
+	assert.Equal(t, "value", entry.authParams()["password"])
+
+	// TTL expiration
+	_, ok = cache.get(key, now.Add(time.Minute))
+	assert.False(t, ok)
+
+	// a new generation replaces the previous one
+	newKey := authCacheKey{authentication: key.authentication, generation: 2}
+	cache.set(newKey, map[string]string{}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{}, secrets, now)
+	_, ok = cache.get(key, now)
+	assert.False(t, ok)
+	_, ok = cache.get(newKey, now)
+	assert.True(t, ok)
+
+	// expiration of the resolved parameters bounds the TTL
+	cache.set(newKey, map[string]string{}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{Expiration: now.Add(10 * time.Second)}, secrets, now)
+	_, ok = cache.get(newKey, now.Add(10*time.Second))
+	assert.False(t, ok)
+
+	// secret invalidation
+	cache.set(newKey, map[string]string{}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{}, secrets, now)
+	assert.False(t, cache.isSecretReferenced("other-ns", "secret"))
+	assert.Empty(t, cache.invalidateSecret("other-ns", "secret"))
+	assert.True(t, cache.isSecretReferenced("ns", "secret"))
+	assert.Equal(t, []AuthenticationKey{key.authentication}, cache.invalidateSecret("ns", "secret"))
+	_, ok = cache.get(newKey, now)
+	assert.False(t, ok)
+	assert.False(t, cache.isSecretReferenced("ns", "secret"))
+}
+
+func TestAuthResolutionCacheKeyedByScaleTarget(t *testing.T) {
+	now := time.Now()
+	authentication := AuthenticationKey("ClusterTriggerAuthentication/auth")
+	secrets := []types.NamespacedName{{Namespace: "keda", Name: "secret"}}
+	cache := newAuthResolutionCache(time.Minute)
+
+	first := authCacheKey{authentication: authentication, namespace: "ns1", serviceAccount: "default", generation: 1}
+	cache.set(first, map[string]string{"token": "ns1"}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{}, secrets, now)
+
+	// parameters resolved for a scale target aren't shared with scale targets of other namespaces or identities
+	for _, key := range []authCacheKey{
+		{authentication: authentication, namespace: "ns2", serviceAccount: "default", generation: 1},
+		{authentication: authentication, namespace: "ns1", serviceAccount: "workload", generation: 1},
+	} {
+		_, ok := cache.get(key, now)
+		assert.False(t, ok)
+	}
+
+	second := authCacheKey{authentication: authentication, namespace: "ns2", serviceAccount: "default", generation: 1}
+	cache.set(second, map[string]string{"token": "ns2"}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{}, secrets, now)
+	entry, ok := cache.get(first, now)
+	assert.True(t, ok)
+	assert.Equal(t, "ns1", entry.authParams()["token"])
+	entry, ok = cache.get(second, now)
+	assert.True(t, ok)
+	assert.Equal(t, "ns2", entry.authParams()["token"])
+
+	// the object is reported once when all its entries are invalidated
+	assert.Equal(t, []AuthenticationKey{authentication}, cache.invalidateSecret("keda", "secret"))
+}
+
+func TestIsAuthSpecCacheable(t *testing.T) {
+	assert.True(t, isAuthSpecCacheable(&kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: "secret", Key: "host"}},
+	}))
+	// ConfigMaps aren't watched, so values read from them aren't cached
+	assert.False(t, isAuthSpecCacheable(&kedav1alpha1.TriggerAuthenticationSpec{
+		ConfigMapTargetRef: []kedav1alpha1.AuthConfigMapTargetRef{{Parameter: "host", Name: "configmap", Key: "host"}},
+	}))
+	assert.False(t, isAuthSpecCacheable(&kedav1alpha1.TriggerAuthenticationSpec{
+		Env: []kedav1alpha1.AuthEnvironment{{Parameter: "host", Name: "HOST"}},
+	}))
+}
+
+func TestAuthResolutionCacheDisabled(t *testing.T) {
+	now := time.Now()
+	key := authCacheKey{authentication: "TriggerAuthentication/ns/auth"}
+	cache := newAuthResolutionCache(0)
+
+	cache.set(key, map[string]string{}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{}, nil, now)
+	_, ok := cache.get(key, now)
+	assert.False(t, ok)
+}
+
+func TestResolveAuthRefUsesAuthCache(t *testing.T) {
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+	authCache = newAuthResolutionCache(defaultAuthCacheTTL)
+	defer func() { authCache = newAuthResolutionCache(defaultAuthCacheTTL) }()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: secretName},
+		Data:       map[string][]byte{secretKey: []byte(secretData)},
+	}
+	triggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: triggerAuthenticationName},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: secretName, Key: secretKey}},
+		},
+	}
+	ref := &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName}
+	ctx := context.Background()
+	logger := logf.Log.WithName("test")
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(secret, triggerAuth).Build()
+
+	params, _, _, err := resolveAuthRef(ctx, kubeClient, logger, ref, nil, namespace, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, secretData, params["host"])
+
+	// the cached value is returned until the secret is invalidated
+	secret.Data[secretKey] = []byte("updated")
+	assert.NoError(t, kubeClient.Update(ctx, secret))
+	params, _, _, err = resolveAuthRef(ctx, kubeClient, logger, ref, nil, namespace, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, secretData, params["host"])
+
+	assert.True(t, IsSecretReferencedByAuthCache(namespace, secretName))
+	assert.Equal(t, []AuthenticationKey{GetAuthenticationKey(ref, namespace)}, InvalidateAuthCacheForSecret(namespace, secretName))
+	params, _, _, err = resolveAuthRef(ctx, kubeClient, logger, ref, nil, namespace, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", params["host"])
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// Names of the TriggerAuthenticationSpec sources reported in the TriggerAuthentication status
+const (
+	authSourceEnv                 = "env"
+	authSourceSecretTargetRef     = "secretTargetRef"
+	authSourceFilePath            = "filePath"
+	authSourceServiceAccountToken = "serviceAccountToken"
+	authSourcePodIdentity         = "podIdentity"
+	authSourceHashiCorpVault      = "hashiCorpVault"
+	authSourceAzureKeyVault       = "azureKeyVault"
+	authSourceGCPSecretManager    = "gcpSecretManager"
+	authSourceAwsSecretManager    = "awsSecretManager"
+
+	authSourceExternalSecretProvider = "externalSecretProvider"
+)
+
+// authSourceErrors collects the errors that occurred while resolving each source of a TriggerAuthenticationSpec
+type authSourceErrors map[string]error
+
+func (e authSourceErrors) add(source string, err error) {
+	e[source] = errors.Join(e[source], err)
+}
+
+// toStatus returns the collected errors sorted by source, so repeated resolutions produce the same status
+func (e authSourceErrors) toStatus(now metav1.Time) []kedav1alpha1.AuthenticationSourceError {
+	if len(e) == 0 {
+		return nil
+	}
+	sourceErrors := make([]kedav1alpha1.AuthenticationSourceError, 0, len(e))
+	for source, err := range e {
+		sourceErrors = append(sourceErrors, kedav1alpha1.AuthenticationSourceError{Source: source, Message: err.Error(), Time: now})
+	}
+	sort.Slice(sourceErrors, func(i, j int) bool {
+		return sourceErrors[i].Source < sourceErrors[j].Source
+	})
+	return sourceErrors
+}
+
+// reportAuthResolution records the outcome of the resolution in the status of the referenced TriggerAuthentication or ClusterTriggerAuthentication,
+// failing to update the status doesn't affect the resolution itself. The status is only patched when the outcome changes
+// or the last successful resolution time is due for a refresh
+func reportAuthResolution(ctx context.Context, client client.Client, logger logr.Logger, triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string, sourceErrors authSourceErrors) {
+	now := metav1.Now()
+	_ = kedastatus.UpdateTriggerAuthenticationStatus(ctx, logger, client, namespace, triggerAuthRef,
+		func(triggerAuthenticationStatus *kedav1alpha1.TriggerAuthenticationStatus) *kedav1alpha1.TriggerAuthenticationStatus {
+			if !triggerAuthenticationStatus.SetResolutionResult(sourceErrors.toStatus(now), now) {
+				return nil
+			}
+			return triggerAuthenticationStatus
+		})
+}
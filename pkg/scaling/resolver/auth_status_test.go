@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestAuthSourceErrorsToStatus(t *testing.T) {
+	now := metav1.Now()
+	sourceErrors := authSourceErrors{}
+	assert.Nil(t, sourceErrors.toStatus(now))
+
+	sourceErrors.add(authSourceSecretTargetRef, errors.New("first"))
+	sourceErrors.add(authSourceHashiCorpVault, errors.New("vault"))
+	sourceErrors.add(authSourceSecretTargetRef, errors.New("second"))
+
+	status := sourceErrors.toStatus(now)
+	assert.Equal(t, []kedav1alpha1.AuthenticationSourceError{
+		{Source: authSourceHashiCorpVault, Message: "vault", Time: now},
+		{Source: authSourceSecretTargetRef, Message: "first\nsecond", Time: now},
+	}, status)
+}
+
+func TestResolveAuthRefReportsStatus(t *testing.T) {
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+	authCache = newAuthResolutionCache(defaultAuthCacheTTL)
+	defer func() { authCache = newAuthResolutionCache(defaultAuthCacheTTL) }()
+
+	triggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: triggerAuthenticationName},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: secretName, Key: secretKey}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: secretName},
+		Data:       map[string][]byte{"other": []byte(secretData)},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(triggerAuth, secret).WithStatusSubresource(triggerAuth).Build()
+	ref := &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName}
+	key := types.NamespacedName{Namespace: namespace, Name: triggerAuthenticationName}
+
+	params, _, _, err := resolveAuthRef(context.Background(), kubeClient, logf.Log.WithName("test"), ref, nil, namespace, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", params["host"])
+
+	result := &kedav1alpha1.TriggerAuthentication{}
+	assert.NoError(t, kubeClient.Get(context.Background(), key, result))
+	ready := result.Status.Conditions.GetReadyCondition()
+	assert.True(t, ready.IsFalse())
+	assert.Len(t, result.Status.SourceErrors, 1)
+	assert.Equal(t, authSourceSecretTargetRef, result.Status.SourceErrors[0].Source)
+	assert.Contains(t, result.Status.SourceErrors[0].Message, secretKey)
+	assert.Nil(t, result.Status.LastSuccessfulResolutionTime)
+
+	secret.Data = map[string][]byte{secretKey: []byte(secretData)}
+	assert.NoError(t, kubeClient.Update(context.Background(), secret))
+	InvalidateAuthCacheForSecret(namespace, secretName)
+
+	params, _, _, err = resolveAuthRef(context.Background(), kubeClient, logf.Log.WithName("test"), ref, nil, namespace, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, secretData, params["host"])
+
+	assert.NoError(t, kubeClient.Get(context.Background(), key, result))
+	ready = result.Status.Conditions.GetReadyCondition()
+	assert.True(t, ready.IsTrue())
+	assert.Empty(t, result.Status.SourceErrors)
+	assert.NotNil(t, result.Status.LastSuccessfulResolutionTime)
+
+	// resolving again with the same outcome doesn't patch the status
+	resourceVersion := result.ResourceVersion
+	InvalidateAuthCacheForSecret(namespace, secretName)
+	_, _, _, err = resolveAuthRef(context.Background(), kubeClient, logf.Log.WithName("test"), ref, nil, namespace, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, kubeClient.Get(context.Background(), key, result))
+	assert.Equal(t, resourceVersion, result.ResourceVersion)
+}
@@ -111,3 +111,33 @@ func (ash *AwsSecretManagerHandler) Initialize(ctx context.Context, client clien
 func (ash *AwsSecretManagerHandler) Stop() {
 	awsutils.ClearAwsConfig(ash.awsMetadata)
 }
+
+// awsSecretManagerSecretProvider reads the secrets of TriggerAuthenticationSpec.AwsSecretManager
+type awsSecretManagerSecretProvider struct{}
+
+// Config implements SecretProvider
+func (awsSecretManagerSecretProvider) Config(spec *kedav1alpha1.TriggerAuthenticationSpec) (any, bool) {
+	return spec.AwsSecretManager, spec.AwsSecretManager != nil && len(spec.AwsSecretManager.Secrets) > 0
+}
+
+// ResolveSecrets implements SecretProvider, secrets that can't be read don't prevent the others from being used
+func (awsSecretManagerSecretProvider) ResolveSecrets(ctx context.Context, providerCtx SecretProviderContext, spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error) {
+	result := &SecretProviderResult{Values: make(map[string]string, len(spec.AwsSecretManager.Secrets))}
+	awsSecretManagerHandler := NewAwsSecretManagerHandler(spec.AwsSecretManager)
+	err := awsSecretManagerHandler.Initialize(ctx, providerCtx.Client, providerCtx.Logger, providerCtx.TriggerNamespace, providerCtx.SecretsLister, providerCtx.PodSpec)
+	defer awsSecretManagerHandler.Stop()
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("error authenticating to Aws Secret Manager: %w", err))
+		return result, nil
+	}
+
+	for _, secret := range spec.AwsSecretManager.Secrets {
+		res, err := awsSecretManagerHandler.Read(ctx, providerCtx.Logger, secret.Name, secret.VersionID, secret.VersionStage)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("error trying to read secret %s from Aws Secret Manager: %w", secret.Name, err))
+			continue
+		}
+		result.Values[secret.Parameter] = res
+	}
+	return result, nil
+}
@@ -142,3 +142,29 @@ func (vh *AzureKeyVaultHandler) getAuthConfig(ctx context.Context, client client
 		return nil, fmt.Errorf("key vault does not support pod identity provider - %s", podIdentity.Provider)
 	}
 }
+
+// azureKeyVaultSecretProvider reads the secrets of TriggerAuthenticationSpec.AzureKeyVault
+type azureKeyVaultSecretProvider struct{}
+
+// Config implements SecretProvider
+func (azureKeyVaultSecretProvider) Config(spec *kedav1alpha1.TriggerAuthenticationSpec) (any, bool) {
+	return spec.AzureKeyVault, spec.AzureKeyVault != nil && len(spec.AzureKeyVault.Secrets) > 0
+}
+
+// ResolveSecrets implements SecretProvider
+func (azureKeyVaultSecretProvider) ResolveSecrets(ctx context.Context, providerCtx SecretProviderContext, spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error) {
+	vaultHandler := NewAzureKeyVaultHandler(spec.AzureKeyVault)
+	if err := vaultHandler.Initialize(ctx, providerCtx.Client, providerCtx.Logger, providerCtx.TriggerNamespace, providerCtx.SecretsLister); err != nil {
+		return nil, fmt.Errorf("error authenticating to Azure Key Vault: %w", err)
+	}
+
+	result := &SecretProviderResult{Values: make(map[string]string, len(spec.AzureKeyVault.Secrets))}
+	for _, secret := range spec.AzureKeyVault.Secrets {
+		res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
+		if err != nil {
+			return nil, fmt.Errorf("error trying to read secret %s version %s from Azure Key Vault: %w", secret.Name, secret.Version, err)
+		}
+		result.Values[secret.Parameter] = res
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	pb "github.com/kedacore/keda/v2/pkg/scaling/resolver/externalsecretprovider"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// externalSecretProvider reads the secrets of TriggerAuthenticationSpec.ExternalSecretProvider from a gRPC server
+// implementing the ExternalSecretProvider API defined in externalsecretprovider/externalsecretprovider.proto
+type externalSecretProvider struct{}
+
+// Config implements SecretProvider
+func (externalSecretProvider) Config(spec *kedav1alpha1.TriggerAuthenticationSpec) (any, bool) {
+	return spec.ExternalSecretProvider, spec.ExternalSecretProvider != nil && len(spec.ExternalSecretProvider.Secrets) > 0
+}
+
+// ResolveSecrets implements SecretProvider
+func (externalSecretProvider) ResolveSecrets(ctx context.Context, providerCtx SecretProviderContext, spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error) {
+	provider := spec.ExternalSecretProvider
+	conn, err := dialExternalSecretProvider(ctx, providerCtx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to external secret provider %s: %w", provider.Address, err)
+	}
+	defer conn.Close()
+
+	request := &pb.GetSecretsRequest{
+		Namespace: providerCtx.Namespace,
+		Metadata:  provider.Metadata,
+		Secrets:   make([]*pb.SecretRef, 0, len(provider.Secrets)),
+	}
+	for _, secret := range provider.Secrets {
+		request.Secrets = append(request.Secrets, &pb.SecretRef{Name: secret.Name, Version: secret.Version})
+	}
+	response, err := pb.NewExternalSecretProviderClient(conn).GetSecrets(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error reading secrets from external secret provider %s: %w", provider.Address, err)
+	}
+	// secrets are returned in the order they are requested, as the same secret can be requested in several versions
+	if len(response.Secrets) != len(provider.Secrets) {
+		return nil, fmt.Errorf("external secret provider %s returned %d secrets, expected %d", provider.Address, len(response.Secrets), len(provider.Secrets))
+	}
+
+	result := &SecretProviderResult{Values: make(map[string]string, len(provider.Secrets))}
+	for i, secret := range provider.Secrets {
+		if response.Secrets[i].Name != secret.Name {
+			return nil, fmt.Errorf("external secret provider %s returned secret %s, expected %s", provider.Address, response.Secrets[i].Name, secret.Name)
+		}
+		result.Values[secret.Parameter] = response.Secrets[i].Value
+	}
+	if response.TtlSeconds > 0 {
+		result.ExpireAt = time.Now().Add(time.Duration(response.TtlSeconds) * time.Second)
+	}
+	return result, nil
+}
+
+// dialExternalSecretProvider connects to the gRPC server, using TLS when a CA or a client certificate is configured
+func dialExternalSecretProvider(ctx context.Context, providerCtx SecretProviderContext, provider *kedav1alpha1.ExternalSecretProvider) (*grpc.ClientConn, error) {
+	if provider.TLS == nil {
+		return grpc.DialContext(ctx, provider.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	var values [3]string
+	for i, valueFrom := range []*kedav1alpha1.ValueFromSecret{provider.TLS.CACert, provider.TLS.ClientCert, provider.TLS.ClientKey} {
+		if valueFrom == nil {
+			continue
+		}
+		value, err := resolveAuthSecretValue(ctx, providerCtx.Client, providerCtx.Logger, valueFrom.SecretKeyRef.Name, providerCtx.TriggerNamespace,
+			valueFrom.SecretKeyRef.Key, providerCtx.SecretsLister)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	caCert, clientCert, clientKey := values[0], values[1], values[2]
+
+	tlsConfig, err := util.NewTLSConfig(clientCert, clientKey, caCert, false)
+	if err != nil {
+		return nil, err
+	}
+	// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
+	return grpc.DialContext(ctx, provider.Address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
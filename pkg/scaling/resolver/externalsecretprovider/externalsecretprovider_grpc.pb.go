@@ -0,0 +1,110 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.2
+// source: externalsecretprovider.proto
+
+package externalsecretprovider
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ExternalSecretProvider_GetSecrets_FullMethodName = "/externalsecretprovider.ExternalSecretProvider/GetSecrets"
+)
+
+// ExternalSecretProviderClient is the client API for ExternalSecretProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExternalSecretProviderClient interface {
+	GetSecrets(ctx context.Context, in *GetSecretsRequest, opts ...grpc.CallOption) (*GetSecretsResponse, error)
+}
+
+type externalSecretProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExternalSecretProviderClient(cc grpc.ClientConnInterface) ExternalSecretProviderClient {
+	return &externalSecretProviderClient{cc}
+}
+
+func (c *externalSecretProviderClient) GetSecrets(ctx context.Context, in *GetSecretsRequest, opts ...grpc.CallOption) (*GetSecretsResponse, error) {
+	out := new(GetSecretsResponse)
+	err := c.cc.Invoke(ctx, ExternalSecretProvider_GetSecrets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExternalSecretProviderServer is the server API for ExternalSecretProvider service.
+// All implementations must embed UnimplementedExternalSecretProviderServer
+// for forward compatibility
+type ExternalSecretProviderServer interface {
+	GetSecrets(context.Context, *GetSecretsRequest) (*GetSecretsResponse, error)
+	mustEmbedUnimplementedExternalSecretProviderServer()
+}
+
+// UnimplementedExternalSecretProviderServer must be embedded to have forward compatible implementations.
+type UnimplementedExternalSecretProviderServer struct {
+}
+
+func (UnimplementedExternalSecretProviderServer) GetSecrets(context.Context, *GetSecretsRequest) (*GetSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecrets not implemented")
+}
+func (UnimplementedExternalSecretProviderServer) mustEmbedUnimplementedExternalSecretProviderServer() {
+}
+
+// UnsafeExternalSecretProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExternalSecretProviderServer will
+// result in compilation errors.
+type UnsafeExternalSecretProviderServer interface {
+	mustEmbedUnimplementedExternalSecretProviderServer()
+}
+
+func RegisterExternalSecretProviderServer(s grpc.ServiceRegistrar, srv ExternalSecretProviderServer) {
+	s.RegisterService(&ExternalSecretProvider_ServiceDesc, srv)
+}
+
+func _ExternalSecretProvider_GetSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalSecretProviderServer).GetSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalSecretProvider_GetSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalSecretProviderServer).GetSecrets(ctx, req.(*GetSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExternalSecretProvider_ServiceDesc is the grpc.ServiceDesc for ExternalSecretProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExternalSecretProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "externalsecretprovider.ExternalSecretProvider",
+	HandlerType: (*ExternalSecretProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSecrets",
+			Handler:    _ExternalSecretProvider_GetSecrets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "externalsecretprovider.proto",
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// getAuthFileRoot returns the directory the files of the referenced authentication object are read from. Files of a
+// ClusterTriggerAuthentication are located under the root directory, while files of a TriggerAuthentication are scoped
+// to the subdirectory of its namespace. When secret access is restricted, only ClusterTriggerAuthentication can read files.
+func getAuthFileRoot(logger logr.Logger, root string, triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) (string, error) {
+	if triggerAuthRef.Kind == "ClusterTriggerAuthentication" {
+		return root, nil
+	}
+	if isSecretAccessRestricted(logger) {
+		return "", fmt.Errorf("filePath of TriggerAuthentication isn't allowed when secret access is restricted, please use ClusterTriggerAuthentication instead")
+	}
+	return util.GetNamespacedAuthFilePathRoot(root, namespace), nil
+}
+
+// resolveAuthFile reads the value of an authentication parameter from a file located under the root directory.
+// If a key is specified, the file is parsed as JSON and the value is extracted using the (dot-separated) key.
+// The path of the file and its modification time are returned too, so changes of the file can be detected.
+func resolveAuthFile(root string, filePath kedav1alpha1.AuthFilePath) (string, string, time.Time, error) {
+	path, err := util.ResolvePathWithinRoot(root, filePath.Path)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	// symlinks are allowed (eg: Secrets Store CSI driver uses them for atomic updates) but only within the root
+	realRoot, err := filepath.EvalSymlinks(filepath.Clean(root))
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	if !util.IsPathWithinRoot(realRoot, realPath) {
+		return "", "", time.Time{}, fmt.Errorf("path %s is outside of the allowed root %s", filePath.Path, root)
+	}
+
+	info, err := os.Stat(realPath)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	if info.IsDir() {
+		return "", "", time.Time{}, fmt.Errorf("path %s is a directory", filePath.Path)
+	}
+
+	content, err := os.ReadFile(realPath)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	if filePath.Key == "" {
+		return string(content), path, info.ModTime(), nil
+	}
+
+	value, err := getJSONValueByKey(content, filePath.Key)
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("error extracting key %s from file %s: %w", filePath.Key, filePath.Path, err)
+	}
+	return value, path, info.ModTime(), nil
+}
+
+// getJSONValueByKey extracts the value of a dot-separated key from a JSON document,
+// non string values are returned JSON encoded
+func getJSONValueByKey(content []byte, key string) (string, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return "", err
+	}
+
+	value, err := util.GetValueByPath(data, key)
+	if err != nil {
+		return "", err
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
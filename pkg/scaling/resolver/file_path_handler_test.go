@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+func TestResolveAuthFile(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "secrets-store")
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "db"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "password"), []byte("keda"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "db", "credentials.json"), []byte(`{"user":"keda","connection":{"port":5432}}`), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "outside"), []byte("secret"), 0o600))
+	assert.NoError(t, os.Symlink(filepath.Join(dir, "outside"), filepath.Join(root, "escape")))
+	assert.NoError(t, os.Symlink(filepath.Join(root, "password"), filepath.Join(root, "link")))
+
+	tests := []struct {
+		name     string
+		filePath kedav1alpha1.AuthFilePath
+		expected string
+		isError  bool
+	}{
+		{name: "plain file", filePath: kedav1alpha1.AuthFilePath{Parameter: "password", Path: "password"}, expected: "keda"},
+		{name: "json key", filePath: kedav1alpha1.AuthFilePath{Parameter: "user", Path: "db/credentials.json", Key: "user"}, expected: "keda"},
+		{name: "nested json key", filePath: kedav1alpha1.AuthFilePath{Parameter: "port", Path: "db/credentials.json", Key: "connection.port"}, expected: "5432"},
+		{name: "missing json key", filePath: kedav1alpha1.AuthFilePath{Parameter: "user", Path: "db/credentials.json", Key: "password"}, isError: true},
+		{name: "symlink within root", filePath: kedav1alpha1.AuthFilePath{Parameter: "password", Path: "link"}, expected: "keda"},
+		{name: "symlink outside root", filePath: kedav1alpha1.AuthFilePath{Parameter: "password", Path: "escape"}, isError: true},
+		{name: "path outside root", filePath: kedav1alpha1.AuthFilePath{Parameter: "password", Path: "../outside"}, isError: true},
+		{name: "directory", filePath: kedav1alpha1.AuthFilePath{Parameter: "password", Path: "db"}, isError: true},
+		{name: "missing file", filePath: kedav1alpha1.AuthFilePath{Parameter: "password", Path: "missing"}, isError: true},
+	}
+
+	for _, test := range tests {
+		value, _, _, err := resolveAuthFile(root, test.filePath)
+		if test.isError {
+			assert.Errorf(t, err, "test %s: expected error but got success", test.name)
+			continue
+		}
+		assert.NoErrorf(t, err, "test %s: expected success but got error", test.name)
+		assert.Equalf(t, test.expected, value, "test %s", test.name)
+	}
+}
+
+func TestResolveAuthFileDetectsChanges(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "password")
+	assert.NoError(t, os.WriteFile(file, []byte("keda"), 0o600))
+
+	_, path, modTime, err := resolveAuthFile(root, kedav1alpha1.AuthFilePath{Parameter: "password", Path: "password"})
+	assert.NoError(t, err)
+
+	metadata := scalersconfig.AuthParamsMetadata{Files: map[string]time.Time{path: modTime}}
+	assert.False(t, metadata.IsOutdated(time.Now()))
+
+	assert.NoError(t, os.Chtimes(file, time.Now(), modTime.Add(time.Minute)))
+	assert.True(t, metadata.IsOutdated(time.Now()))
+
+	assert.NoError(t, os.Remove(file))
+	assert.True(t, metadata.IsOutdated(time.Now()))
+}
+
+func TestGetAuthFileRoot(t *testing.T) {
+	defer func(restricted string) { restrictSecretAccess = restricted }(restrictSecretAccess)
+	logger := logf.Log.WithName("test")
+	root := "/mnt/secrets-store"
+
+	restrictSecretAccess = ""
+	path, err := getAuthFileRoot(logger, root, &kedav1alpha1.AuthenticationRef{Name: "ta"}, "default")
+	assert.NoError(t, err)
+	assert.Equal(t, "/mnt/secrets-store/default", path)
+
+	path, err = getAuthFileRoot(logger, root, &kedav1alpha1.AuthenticationRef{Name: "cta", Kind: "ClusterTriggerAuthentication"}, "default")
+	assert.NoError(t, err)
+	assert.Equal(t, root, path)
+
+	// TriggerAuthentications can't read files when secret access is restricted
+	restrictSecretAccess = "true"
+	_, err = getAuthFileRoot(logger, root, &kedav1alpha1.AuthenticationRef{Name: "ta"}, "default")
+	assert.Error(t, err)
+
+	path, err = getAuthFileRoot(logger, root, &kedav1alpha1.AuthenticationRef{Name: "cta", Kind: "ClusterTriggerAuthentication"}, "default")
+	assert.NoError(t, err)
+	assert.Equal(t, root, path)
+}
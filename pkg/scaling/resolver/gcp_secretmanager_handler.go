@@ -141,3 +141,35 @@ func (vh *GCPSecretManagerHandler) Read(ctx context.Context, secretID, secretVer
 
 	return string(result.Payload.Data), nil
 }
+
+// gcpSecretManagerSecretProvider reads the secrets of TriggerAuthenticationSpec.GCPSecretManager
+type gcpSecretManagerSecretProvider struct{}
+
+// Config implements SecretProvider
+func (gcpSecretManagerSecretProvider) Config(spec *kedav1alpha1.TriggerAuthenticationSpec) (any, bool) {
+	return spec.GCPSecretManager, spec.GCPSecretManager != nil && len(spec.GCPSecretManager.Secrets) > 0
+}
+
+// ResolveSecrets implements SecretProvider, secrets that can't be read don't prevent the others from being used
+func (gcpSecretManagerSecretProvider) ResolveSecrets(ctx context.Context, providerCtx SecretProviderContext, spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error) {
+	result := &SecretProviderResult{Values: make(map[string]string, len(spec.GCPSecretManager.Secrets))}
+	secretManagerHandler := NewGCPSecretManagerHandler(spec.GCPSecretManager)
+	if err := secretManagerHandler.Initialize(ctx, providerCtx.Client, providerCtx.Logger, providerCtx.TriggerNamespace, providerCtx.SecretsLister); err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("error authenticating to GCP Secret Manager: %w", err))
+		return result, nil
+	}
+
+	for _, secret := range spec.GCPSecretManager.Secrets {
+		version := "latest"
+		if secret.Version != "" {
+			version = secret.Version
+		}
+		res, err := secretManagerHandler.Read(ctx, secret.ID, version)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("error trying to read secret from GCP Secret Manager: %w", err))
+			continue
+		}
+		result.Values[secret.Parameter] = res
+	}
+	return result, nil
+}
@@ -17,35 +17,92 @@ limitations under the License.
 package resolver
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/go-logr/logr"
 	vaultapi "github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
 )
 
+const (
+	vaultDefaultJWTAudience = "vault"
+	vaultAWSDefaultRegion   = "us-east-1"
+	vaultAWSIAMServerIDKey  = "X-Vault-AWS-IAM-Server-ID"
+	vaultAWSSTSRequestBody  = "Action=GetCallerIdentity&Version=2011-06-15"
+
+	// vaultLeaseRefreshRatio is the fraction of a lease duration after which the secret is read again,
+	// so the new credentials are in place before the lease expires
+	vaultLeaseRefreshRatio = 2.0 / 3.0
+)
+
+// default mount paths of the Vault authentication methods
+var vaultDefaultMounts = map[kedav1alpha1.VaultAuthentication]string{
+	kedav1alpha1.VaultAuthenticationAppRole: "approle",
+	kedav1alpha1.VaultAuthenticationJWT:     "jwt",
+	kedav1alpha1.VaultAuthenticationAWS:     "aws",
+	kedav1alpha1.VaultAuthenticationCert:    "cert",
+}
+
 // HashicorpVaultHandler is specification of Hashi Corp Vault
 type HashicorpVaultHandler struct {
-	vault  *kedav1alpha1.HashiCorpVault
-	client *vaultapi.Client
-	stopCh chan struct{}
+	vault    *kedav1alpha1.HashiCorpVault
+	client   *vaultapi.Client
+	logger   logr.Logger
+	stopCh   chan struct{}
+	stopOnce sync.Once
+
+	kubeClient       client.Client
+	triggerNamespace string
+	secretsLister    corev1listers.SecretLister
+	podSpec          *corev1.PodSpec
+	awsMetadata      *awsutils.AuthorizationMetadata
+
+	leaseLock       sync.Mutex
+	leaseIDs        []string
+	leaseExpiration time.Time
 }
 
 // NewHashicorpVaultHandler creates a HashicorpVaultHandler object
 func NewHashicorpVaultHandler(v *kedav1alpha1.HashiCorpVault) *HashicorpVaultHandler {
 	return &HashicorpVaultHandler{
-		vault: v,
+		vault:  v,
+		stopCh: make(chan struct{}),
 	}
 }
 
 // Initialize the Vault client
-func (vh *HashicorpVaultHandler) Initialize(logger logr.Logger) error {
+func (vh *HashicorpVaultHandler) Initialize(ctx context.Context, kubeClient client.Client, logger logr.Logger, triggerNamespace string, secretsLister corev1listers.SecretLister, podSpec *corev1.PodSpec) error {
+	vh.kubeClient = kubeClient
+	vh.triggerNamespace = triggerNamespace
+	vh.secretsLister = secretsLister
+	vh.podSpec = podSpec
+	vh.logger = logger
+
 	config := vaultapi.DefaultConfig()
+	if vh.vault.Authentication == kedav1alpha1.VaultAuthenticationCert {
+		if err := vh.configureClientCertificate(ctx, logger, config); err != nil {
+			return err
+		}
+	}
+
 	client, err := vaultapi.NewClient(config)
 	if err != nil {
 		return err
@@ -60,7 +117,7 @@ func (vh *HashicorpVaultHandler) Initialize(logger logr.Logger) error {
 		client.SetNamespace(vh.vault.Namespace)
 	}
 
-	token, err := vh.token(client)
+	token, err := vh.token(ctx, logger, client)
 	if err != nil {
 		return err
 	}
@@ -75,18 +132,17 @@ func (vh *HashicorpVaultHandler) Initialize(logger logr.Logger) error {
 		return err
 	}
 
+	vh.client = client
+
 	if renew, ok := lookup.Data["renewable"].(bool); ok && renew {
-		vh.stopCh = make(chan struct{})
 		go vh.renewToken(logger)
 	}
 
-	vh.client = client
-
 	return nil
 }
 
 // token Extract a vault token from the Authentication method
-func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error) {
+func (vh *HashicorpVaultHandler) token(ctx context.Context, logger logr.Logger, client *vaultapi.Client) (string, error) {
 	var token string
 
 	switch vh.vault.Authentication {
@@ -133,6 +189,60 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 		}
 
 		token = secret.Auth.ClientToken
+	case kedav1alpha1.VaultAuthenticationAppRole:
+		if vh.vault.Credential == nil || vh.vault.Credential.AppRole == nil {
+			return token, errors.New("approle credential not in config")
+		}
+
+		appRole := vh.vault.Credential.AppRole
+		roleID := vh.resolveCredentialValue(ctx, logger, appRole.RoleID)
+		if roleID == "" {
+			return token, errors.New("could not get approle role id")
+		}
+
+		data := map[string]interface{}{"role_id": roleID}
+		if appRole.SecretID != nil {
+			secretID := vh.resolveCredentialValue(ctx, logger, *appRole.SecretID)
+			if secretID == "" {
+				return token, errors.New("could not get approle secret id")
+			}
+			data["secret_id"] = secretID
+		}
+
+		return vh.login(client, data)
+	case kedav1alpha1.VaultAuthenticationJWT:
+		if len(vh.vault.Role) == 0 {
+			return token, errors.New("jwt role not in config")
+		}
+
+		if vh.vault.Credential == nil || vh.vault.Credential.JWT == nil {
+			return token, errors.New("jwt credential not in config")
+		}
+
+		jwt, err := vh.requestServiceAccountToken(ctx, vh.vault.Credential.JWT)
+		if err != nil {
+			return token, err
+		}
+
+		return vh.login(client, map[string]interface{}{"jwt": jwt, "role": vh.vault.Role})
+	case kedav1alpha1.VaultAuthenticationAWS:
+		if len(vh.vault.Role) == 0 {
+			return token, errors.New("aws role not in config")
+		}
+
+		data, err := vh.awsLoginData(ctx)
+		if err != nil {
+			return token, err
+		}
+
+		return vh.login(client, data)
+	case kedav1alpha1.VaultAuthenticationCert:
+		data := map[string]interface{}{}
+		if len(vh.vault.Role) > 0 {
+			data["name"] = vh.vault.Role
+		}
+
+		return vh.login(client, data)
 	default:
 		return token, fmt.Errorf("vault auth method %s is not supported", vh.vault.Authentication)
 	}
@@ -140,6 +250,141 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 	return token, nil
 }
 
+// login authenticates against the mount of the configured auth method and returns the client token
+func (vh *HashicorpVaultHandler) login(client *vaultapi.Client, data map[string]interface{}) (string, error) {
+	mount := vh.vault.Mount
+	if len(mount) == 0 {
+		mount = vaultDefaultMounts[vh.vault.Authentication]
+	}
+
+	secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", mount), data)
+	if err != nil {
+		return "", err
+	}
+
+	if secret == nil || secret.Auth == nil || len(secret.Auth.ClientToken) == 0 {
+		return "", fmt.Errorf("vault %s login didn't return a client token", vh.vault.Authentication)
+	}
+
+	return secret.Auth.ClientToken, nil
+}
+
+// resolveCredentialValue reads a credential value from a secret in the trigger namespace
+func (vh *HashicorpVaultHandler) resolveCredentialValue(ctx context.Context, logger logr.Logger, value kedav1alpha1.VaultCredentialValue) string {
+	return resolveAuthSecret(ctx, vh.kubeClient, logger, value.ValueFrom.SecretKeyRef.Name, vh.triggerNamespace, value.ValueFrom.SecretKeyRef.Key, vh.secretsLister)
+}
+
+// requestServiceAccountToken requests a service account token with the configured audience using the TokenRequest API.
+// Only the ServiceAccount of the scale target can be used, so a TriggerAuthentication can't log in to Vault as any other
+// ServiceAccount of the namespace.
+func (vh *HashicorpVaultHandler) requestServiceAccountToken(ctx context.Context, jwt *kedav1alpha1.VaultJWTCredential) (string, error) {
+	workloadServiceAccountName, err := getWorkloadServiceAccountName(vh.podSpec)
+	if err != nil {
+		return "", fmt.Errorf("jwt authentication: %w", err)
+	}
+	if jwt.ServiceAccountName != workloadServiceAccountName {
+		return "", fmt.Errorf("jwt authentication can only use the service account of the scale target %s, got %s", workloadServiceAccountName, jwt.ServiceAccountName)
+	}
+
+	audience := jwt.Audience
+	if len(audience) == 0 {
+		audience = vaultDefaultJWTAudience
+	}
+
+	status, err := requestServiceAccountToken(ctx, vh.kubeClient, jwt.ServiceAccountName, vh.triggerNamespace, []string{audience}, jwt.ExpirationSeconds)
+	if err != nil {
+		return "", err
+	}
+	return status.Token, nil
+}
+
+// awsLoginData builds the login payload of the aws auth method from a signed sts:GetCallerIdentity request
+func (vh *HashicorpVaultHandler) awsLoginData(ctx context.Context) (map[string]interface{}, error) {
+	awsCredential := &kedav1alpha1.VaultAWSCredential{}
+	if vh.vault.Credential != nil && vh.vault.Credential.AWS != nil {
+		awsCredential = vh.vault.Credential.AWS
+	}
+
+	region := awsCredential.Region
+	endpoint := "https://sts.amazonaws.com/"
+	if len(region) == 0 {
+		region = vaultAWSDefaultRegion
+	} else {
+		endpoint = fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+	}
+
+	vh.awsMetadata = &awsutils.AuthorizationMetadata{
+		AwsRoleArn:       awsCredential.RoleArn,
+		UsingPodIdentity: true,
+		TriggerUniqueKey: fmt.Sprintf("hashicorp-vault-%s", vh.triggerNamespace),
+	}
+	config, err := awsutils.GetAwsConfig(ctx, region, *vh.awsMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving aws credentials: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(vaultAWSSTSRequestBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if len(awsCredential.ServerID) > 0 {
+		req.Header.Set(vaultAWSIAMServerIDKey, awsCredential.ServerID)
+	}
+
+	payloadHash := sha256.Sum256([]byte(vaultAWSSTSRequestBody))
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), "sts", region, time.Now()); err != nil {
+		return nil, fmt.Errorf("error signing sts request: %w", err)
+	}
+
+	headers, err := json.Marshal(req.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"role":                    vh.vault.Role,
+		"iam_http_request_method": req.Method,
+		"iam_request_url":         base64.StdEncoding.EncodeToString([]byte(endpoint)),
+		"iam_request_body":        base64.StdEncoding.EncodeToString([]byte(vaultAWSSTSRequestBody)),
+		"iam_request_headers":     base64.StdEncoding.EncodeToString(headers),
+	}, nil
+}
+
+// configureClientCertificate loads the client certificate of the cert auth method into the Vault client config
+func (vh *HashicorpVaultHandler) configureClientCertificate(ctx context.Context, logger logr.Logger, config *vaultapi.Config) error {
+	if vh.vault.Credential == nil || vh.vault.Credential.Cert == nil {
+		return errors.New("cert credential not in config")
+	}
+
+	cert := vh.resolveCredentialValue(ctx, logger, vh.vault.Credential.Cert.Cert)
+	key := vh.resolveCredentialValue(ctx, logger, vh.vault.Credential.Cert.Key)
+	if cert == "" || key == "" {
+		return errors.New("could not get client certificate and key")
+	}
+
+	keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return fmt.Errorf("error parsing client certificate: %w", err)
+	}
+
+	transport, ok := config.HttpClient.Transport.(*http.Transport)
+	if !ok {
+		return errors.New("unexpected vault client transport")
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{keyPair}
+
+	return nil
+}
+
 // renewToken takes charge of renewing the vault token
 func (vh *HashicorpVaultHandler) renewToken(logger logr.Logger) {
 	secret, err := vh.client.Auth().Token().RenewSelf(0)
@@ -154,13 +399,11 @@ func (vh *HashicorpVaultHandler) renewToken(logger logr.Logger) {
 	})
 	if err != nil {
 		logger.Error(err, "Vault renew token: cannot create the renewer")
+		return
 	}
 
 	go renewer.Renew()
-	defer func() {
-		renewer.Stop()
-		close(vh.stopCh)
-	}()
+	defer renewer.Stop()
 
 RenewWatcherLoop:
 	for {
@@ -186,11 +429,25 @@ func (vh *HashicorpVaultHandler) Write(path string, data map[string]interface{})
 	return vh.client.Logical().Write(path, data)
 }
 
-// Stop is responsible for stopping the renewal token process
+// Stop is responsible for stopping the renewal token and leases processes and for revoking the leases of the read secrets
 func (vh *HashicorpVaultHandler) Stop() {
-	if vh.stopCh != nil {
-		vh.stopCh <- struct{}{}
-	}
+	vh.stopOnce.Do(func() {
+		close(vh.stopCh)
+
+		vh.leaseLock.Lock()
+		leaseIDs := vh.leaseIDs
+		vh.leaseIDs = nil
+		vh.leaseLock.Unlock()
+		for _, leaseID := range leaseIDs {
+			if err := vh.client.Sys().Revoke(leaseID); err != nil {
+				vh.logger.Error(err, "Vault revoke lease: cannot revoke the lease", "leaseID", leaseID)
+			}
+		}
+
+		if vh.awsMetadata != nil {
+			awsutils.ClearAwsConfig(*vh.awsMetadata)
+		}
+	})
 }
 
 // getPkiRequest format the pkiData in a format that the vault sdk understands.
@@ -328,6 +585,7 @@ func (vh *HashicorpVaultHandler) ResolveSecrets(secrets []kedav1alpha1.VaultSecr
 			continue
 		}
 		vaultSecrets[group] = vaultSecret
+		vh.trackLease(vaultSecret)
 	}
 	// For each secret in each group, fetch the value and add to out
 	out := make([]kedav1alpha1.VaultSecret, 0)
@@ -350,3 +608,124 @@ func (vh *HashicorpVaultHandler) ResolveSecrets(secrets []kedav1alpha1.VaultSecr
 	}
 	return out, nil
 }
+
+// trackLease records the lease of a dynamic secret so it is revoked on Stop. Renewable leases are renewed as their
+// TTL elapses, the secrets of the other ones have to be read again after a part of the lease duration
+func (vh *HashicorpVaultHandler) trackLease(vaultSecret *vaultapi.Secret) {
+	if vaultSecret == nil || vaultSecret.LeaseID == "" {
+		return
+	}
+
+	vh.leaseLock.Lock()
+	vh.leaseIDs = append(vh.leaseIDs, vaultSecret.LeaseID)
+	vh.leaseLock.Unlock()
+
+	if vaultSecret.Renewable {
+		go vh.renewLease(vaultSecret)
+		return
+	}
+	if vaultSecret.LeaseDuration > 0 {
+		refreshAfter := time.Duration(float64(vaultSecret.LeaseDuration)*vaultLeaseRefreshRatio) * time.Second
+		vh.expireLeasesAt(time.Now().Add(refreshAfter))
+	}
+}
+
+// renewLease takes charge of renewing the lease of a dynamic secret, the renewer waits for a part of the lease TTL
+// between renewals. Once the lease can't be renewed anymore (eg: its max TTL is reached), the secrets have to be read again
+func (vh *HashicorpVaultHandler) renewLease(vaultSecret *vaultapi.Secret) {
+	renewer, err := vh.client.NewLifetimeWatcher(&vaultapi.LifetimeWatcherInput{
+		Secret: vaultSecret,
+	})
+	if err != nil {
+		vh.logger.Error(err, "Vault renew lease: cannot create the renewer", "leaseID", vaultSecret.LeaseID)
+		vh.expireLeasesAt(time.Now())
+		return
+	}
+
+	go renewer.Start()
+	defer renewer.Stop()
+
+	for {
+		select {
+		case <-vh.stopCh:
+			return
+		case renewal := <-renewer.RenewCh():
+			vh.logger.V(1).Info("Vault lease renewed", "leaseID", vaultSecret.LeaseID, "leaseDuration", renewal.Secret.LeaseDuration)
+		case err := <-renewer.DoneCh():
+			if err != nil {
+				vh.logger.Error(err, "error renewing lease", "leaseID", vaultSecret.LeaseID)
+			}
+			vh.expireLeasesAt(time.Now())
+			return
+		}
+	}
+}
+
+// expireLeasesAt sets the time at which the secrets have to be read again, only the earliest one is kept
+func (vh *HashicorpVaultHandler) expireLeasesAt(expiration time.Time) {
+	vh.leaseLock.Lock()
+	defer vh.leaseLock.Unlock()
+	if vh.leaseExpiration.IsZero() || expiration.Before(vh.leaseExpiration) {
+		vh.leaseExpiration = expiration
+	}
+}
+
+// hasLeases returns whether any of the read secrets has a lease
+func (vh *HashicorpVaultHandler) hasLeases() bool {
+	vh.leaseLock.Lock()
+	defer vh.leaseLock.Unlock()
+	return len(vh.leaseIDs) > 0
+}
+
+// Expiration implements scalersconfig.AuthParamsLease, it returns the time at which the read secrets have to be read again.
+// Zero value means that their leases are still renewed or that none of them has a lease.
+func (vh *HashicorpVaultHandler) Expiration() time.Time {
+	vh.leaseLock.Lock()
+	defer vh.leaseLock.Unlock()
+	return vh.leaseExpiration
+}
+
+// Revoke implements scalersconfig.AuthParamsLease
+func (vh *HashicorpVaultHandler) Revoke() {
+	vh.Stop()
+}
+
+// hashiCorpVaultSecretProvider reads the secrets of TriggerAuthenticationSpec.HashiCorpVault
+type hashiCorpVaultSecretProvider struct{}
+
+// Config implements SecretProvider
+func (hashiCorpVaultSecretProvider) Config(spec *kedav1alpha1.TriggerAuthenticationSpec) (any, bool) {
+	return spec.HashiCorpVault, spec.HashiCorpVault != nil && len(spec.HashiCorpVault.Secrets) > 0
+}
+
+// ResolveSecrets implements SecretProvider
+func (hashiCorpVaultSecretProvider) ResolveSecrets(ctx context.Context, providerCtx SecretProviderContext, spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error) {
+	hashiCorpVault, err := resolveHashiCorpVaultInlineSecrets(ctx, providerCtx.AuthNamespace, spec.HashiCorpVault)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving Vault credential: %w", err)
+	}
+	vault := NewHashicorpVaultHandler(hashiCorpVault)
+	err = vault.Initialize(ctx, providerCtx.Client, providerCtx.Logger, providerCtx.TriggerNamespace, providerCtx.SecretsLister, providerCtx.PodSpec)
+	if err != nil {
+		vault.Stop()
+		return nil, fmt.Errorf("error authenticating to Vault: %w", err)
+	}
+
+	secrets, err := vault.ResolveSecrets(spec.HashiCorpVault.Secrets)
+	if err != nil {
+		vault.Stop()
+		return nil, fmt.Errorf("could not get secrets from vault: %w", err)
+	}
+
+	result := &SecretProviderResult{Values: make(map[string]string, len(secrets))}
+	for _, e := range secrets {
+		result.Values[e.Parameter] = e.Value
+	}
+	// the handler keeps renewing the token and the leases of dynamic secrets until the scaler using them is closed
+	if vault.hasLeases() {
+		result.Lease = vault
+	} else {
+		vault.Stop()
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/kms"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+var requireEncryptedInlineSecrets, _ = util.GetRequireEncryptedInlineSecrets()
+
+// resolveInlineSecret decrypts a kms envelope bound to the namespace and field path, other values are returned unchanged
+// unless they are set inline in a resource while KEDA requires inline secrets to be encrypted
+func resolveInlineSecret(ctx context.Context, binding kms.Binding, value string, inline bool) (string, error) {
+	if kms.IsEnvelope(value) {
+		decrypted, err := kms.Decrypt(ctx, binding, value)
+		if err != nil {
+			return "", fmt.Errorf("error decrypting %s: %w", binding.FieldPath, err)
+		}
+		return decrypted, nil
+	}
+	if inline && value != "" && requireEncryptedInlineSecrets {
+		return "", fmt.Errorf("%s has to be encrypted when %s is enabled", binding.FieldPath, util.RequireEncryptedInlineSecretsEnvVar)
+	}
+	return value, nil
+}
+
+// resolveHashiCorpVaultInlineSecrets returns the HashiCorpVault spec with the inline token decrypted, the token is bound
+// to the namespace of the TriggerAuthentication, which is empty for a ClusterTriggerAuthentication.
+// The input spec is copied instead of modified as it's shared through the informer cache
+func resolveHashiCorpVaultInlineSecrets(ctx context.Context, authNamespace string, vault *kedav1alpha1.HashiCorpVault) (*kedav1alpha1.HashiCorpVault, error) {
+	if vault.Credential == nil || vault.Credential.Token == "" {
+		return vault, nil
+	}
+	binding := kms.Binding{Namespace: authNamespace, FieldPath: kms.HashiCorpVaultTokenFieldPath}
+	token, err := resolveInlineSecret(ctx, binding, vault.Credential.Token, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := vault.DeepCopy()
+	resolved.Credential.Token = token
+	return resolved, nil
+}
+
+// getAuthenticationNamespace returns the namespace of the referenced TriggerAuthentication,
+// a ClusterTriggerAuthentication is cluster scoped so its namespace is empty
+func getAuthenticationNamespace(triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) string {
+	if triggerAuthRef.Kind == "ClusterTriggerAuthentication" {
+		return ""
+	}
+	return namespace
+}
+
+// isLiteralContainerEnv returns whether the environment variable is set with a literal value in the container spec
+func isLiteralContainerEnv(podSpec *corev1.PodSpec, containerName, name string) bool {
+	container, err := getContainer(podSpec, containerName)
+	if err != nil {
+		return false
+	}
+	for _, env := range container.Env {
+		if env.Name == name {
+			return env.ValueFrom == nil
+		}
+	}
+	return false
+}
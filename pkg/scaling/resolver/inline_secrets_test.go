@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/kms"
+)
+
+func registerTestKMSProvider(t *testing.T) kms.Provider {
+	keyfile := filepath.Join(t.TempDir(), "keyfile")
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	if err := os.WriteFile(keyfile, []byte("test:"+key), 0600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := kms.NewAESKeyfileProvider(keyfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kms.RegisterProvider(provider)
+	return provider
+}
+
+func TestResolveAuthRefWithEncryptedEnv(t *testing.T) {
+	provider := registerTestKMSProvider(t)
+	encrypted, err := kms.Encrypt(context.Background(), provider, "", kms.Binding{Namespace: namespace, FieldPath: kms.EnvFieldPath(envKey)}, []byte(secretData))
+	assert.NoError(t, err)
+	otherNamespaceEncrypted, err := kms.Encrypt(context.Background(), provider, "", kms.Binding{Namespace: "other", FieldPath: kms.EnvFieldPath(envKey)}, []byte(secretData))
+	assert.NoError(t, err)
+
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+
+	triggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: triggerAuthenticationName},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			Env: []kedav1alpha1.AuthEnvironment{{Parameter: "host", Name: envKey}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		value          string
+		requireEncrypt bool
+		expectedValue  string
+		expectedErrors int
+	}{
+		{
+			name:           "plaintext value",
+			value:          secretData,
+			expectedValue:  secretData,
+			expectedErrors: 0,
+		},
+		{
+			name:           "encrypted value",
+			value:          encrypted,
+			expectedValue:  secretData,
+			expectedErrors: 0,
+		},
+		{
+			name:           "encrypted value when encryption is required",
+			value:          encrypted,
+			requireEncrypt: true,
+			expectedValue:  secretData,
+			expectedErrors: 0,
+		},
+		{
+			name:           "plaintext value when encryption is required",
+			value:          secretData,
+			requireEncrypt: true,
+			expectedValue:  "",
+			expectedErrors: 1,
+		},
+		{
+			name:           "value encrypted for another namespace",
+			value:          otherNamespaceEncrypted,
+			expectedValue:  "",
+			expectedErrors: 1,
+		},
+		{
+			name:           "invalid encrypted value",
+			value:          kms.EnvelopePrefix + "invalid",
+			expectedValue:  "",
+			expectedErrors: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authCache = newAuthResolutionCache(defaultAuthCacheTTL)
+			defer func() { authCache = newAuthResolutionCache(defaultAuthCacheTTL) }()
+			requireEncryptedInlineSecretsBackup := requireEncryptedInlineSecrets
+			requireEncryptedInlineSecrets = test.requireEncrypt
+			defer func() { requireEncryptedInlineSecrets = requireEncryptedInlineSecretsBackup }()
+
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Env: []corev1.EnvVar{{Name: envKey, Value: test.value}},
+				}},
+			}
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(triggerAuth.DeepCopy()).WithStatusSubresource(triggerAuth).Build()
+
+			params, _, _, err := resolveAuthRef(context.Background(), kubeClient, logf.Log.WithName("test"),
+				&kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName}, podSpec, namespace, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, params["host"])
+
+			result := &kedav1alpha1.TriggerAuthentication{}
+			assert.NoError(t, kubeClient.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: triggerAuthenticationName}, result))
+			assert.Len(t, result.Status.SourceErrors, test.expectedErrors)
+		})
+	}
+}
+
+func TestResolveHashiCorpVaultInlineSecrets(t *testing.T) {
+	provider := registerTestKMSProvider(t)
+	encrypted, err := kms.Encrypt(context.Background(), provider, "", kms.Binding{Namespace: namespace, FieldPath: kms.HashiCorpVaultTokenFieldPath}, []byte("vault-token"))
+	assert.NoError(t, err)
+
+	vault := &kedav1alpha1.HashiCorpVault{Credential: &kedav1alpha1.Credential{Token: encrypted}}
+	resolved, err := resolveHashiCorpVaultInlineSecrets(context.Background(), namespace, vault)
+	assert.NoError(t, err)
+	assert.Equal(t, "vault-token", resolved.Credential.Token)
+	// the spec shared through the informer cache isn't modified
+	assert.Equal(t, encrypted, vault.Credential.Token)
+
+	// the token is bound to the namespace of the TriggerAuthentication
+	_, err = resolveHashiCorpVaultInlineSecrets(context.Background(), "other", vault)
+	assert.Error(t, err)
+	_, err = resolveHashiCorpVaultInlineSecrets(context.Background(), getAuthenticationNamespace(&kedav1alpha1.AuthenticationRef{Kind: "ClusterTriggerAuthentication"}, namespace), vault)
+	assert.Error(t, err)
+
+	requireEncryptedInlineSecretsBackup := requireEncryptedInlineSecrets
+	requireEncryptedInlineSecrets = true
+	defer func() { requireEncryptedInlineSecrets = requireEncryptedInlineSecretsBackup }()
+	_, err = resolveHashiCorpVaultInlineSecrets(context.Background(), namespace, &kedav1alpha1.HashiCorpVault{Credential: &kedav1alpha1.Credential{Token: "vault-token"}})
+	assert.Error(t, err)
+}
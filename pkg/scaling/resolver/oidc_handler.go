@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	oidcTokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	oidcTokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+	oidcTokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+	oidcTokenExchangeTimeout   = 30 * time.Second
+)
+
+var oidcHTTPClient = util.CreateHTTPClient(oidcTokenExchangeTimeout, false)
+
+// oidcTokenExchangeResponse is the response of a token exchange endpoint, https://www.rfc-editor.org/rfc/rfc8693#section-2.2
+type oidcTokenExchangeResponse struct {
+	AccessToken      string `json:"access_token"`
+	IssuedTokenType  string `json:"issued_token_type"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// resolveOIDCAccessToken exchanges a ServiceAccount token for an access token as configured in the oidc pod identity,
+// it returns the access token and the time at which it has to be exchanged again
+func resolveOIDCAccessToken(ctx context.Context, kubeClient client.Client, podIdentity kedav1alpha1.AuthPodIdentity,
+	podSpec *corev1.PodSpec, namespace string) (string, time.Time, error) {
+	if podIdentity.OIDC == nil || podIdentity.OIDC.TokenURL == "" {
+		return "", time.Time{}, errors.New("tokenUrl of oidc pod identity is required")
+	}
+
+	identityOwner := "keda"
+	if podIdentity.IsWorkloadIdentityOwner() {
+		identityOwner = "workload"
+	}
+	serviceAccountToken := &kedav1alpha1.AuthServiceAccountToken{
+		Audience:      podIdentity.OIDC.ServiceAccountTokenAudience,
+		IdentityOwner: &identityOwner,
+	}
+	subjectToken, refreshAt, err := resolveServiceAccountToken(ctx, kubeClient, serviceAccountToken, podSpec, namespace)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	response, err := exchangeOIDCToken(ctx, oidcHTTPClient, podIdentity.OIDC, subjectToken)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if response.ExpiresIn > 0 {
+		lifetime := time.Duration(response.ExpiresIn) * time.Second
+		refreshAt = now.Add(time.Duration(float64(lifetime) * serviceAccountTokenRefreshRatio))
+	}
+	return response.AccessToken, refreshAt, nil
+}
+
+// exchangeOIDCToken exchanges the subject token for an access token at the token exchange endpoint
+func exchangeOIDCToken(ctx context.Context, httpClient *http.Client, oidc *kedav1alpha1.AuthPodIdentityOIDC, subjectToken string) (*oidcTokenExchangeResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", oidcTokenExchangeGrantType)
+	form.Set("subject_token", subjectToken)
+	form.Set("subject_token_type", oidcTokenTypeJWT)
+	form.Set("requested_token_type", oidcTokenTypeAccessToken)
+	if oidc.ClientID != "" {
+		form.Set("client_id", oidc.ClientID)
+	}
+	if oidc.Audience != "" {
+		form.Set("audience", oidc.Audience)
+	}
+	if oidc.Resource != "" {
+		form.Set("resource", oidc.Resource)
+	}
+	if len(oidc.Scopes) > 0 {
+		form.Set("scope", strings.Join(oidc.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oidc.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging token at %s: %w", oidc.TokenURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &oidcTokenExchangeResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error parsing token exchange response, status code %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if response.Error != "" {
+			return nil, fmt.Errorf("token exchange failed with status code %d: %s %s", resp.StatusCode, response.Error, response.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token exchange failed with status code %d", resp.StatusCode)
+	}
+	if response.AccessToken == "" {
+		return nil, errors.New("empty access token returned by token exchange")
+	}
+
+	return response, nil
+}
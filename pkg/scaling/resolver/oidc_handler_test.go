@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+)
+
+const oidcTestAccessToken = "exchanged-access-token"
+
+// newFakeSTS returns a token exchange endpoint which only accepts vaultTestJWT as subject token
+func newFakeSTS(t *testing.T, expiresIn int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.PostForm.Get("grant_type") != oidcTokenExchangeGrantType,
+			r.PostForm.Get("subject_token_type") != oidcTokenTypeJWT,
+			r.PostForm.Get("requested_token_type") != oidcTokenTypeAccessToken:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_request"}`))
+		case r.PostForm.Get("subject_token") != vaultTestJWT:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"subject token is invalid"}`))
+		case r.PostForm.Get("audience") != "prometheus", r.PostForm.Get("scope") != "metrics:read openid":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_target"}`))
+		default:
+			_, _ = fmt.Fprintf(w, `{"access_token":%q,"issued_token_type":%q,"token_type":"Bearer","expires_in":%d}`,
+				oidcTestAccessToken, oidcTokenTypeAccessToken, expiresIn)
+		}
+	}))
+}
+
+func TestResolveOIDCAccessToken(t *testing.T) {
+	workload := "workload"
+	keda := "keda"
+
+	tests := []struct {
+		name                   string
+		expiresIn              int
+		podIdentity            func(tokenURL string) kedav1alpha1.AuthPodIdentity
+		podSpec                *corev1.PodSpec
+		expectedServiceAccount types.NamespacedName
+		expectedAudiences      []string
+		expectedRefresh        time.Duration
+		isError                bool
+	}{
+		{
+			name:      "keda identity owner",
+			expiresIn: 900,
+			podIdentity: func(tokenURL string) kedav1alpha1.AuthPodIdentity {
+				return kedav1alpha1.AuthPodIdentity{
+					Provider:      kedav1alpha1.PodIdentityProviderOIDC,
+					IdentityOwner: &keda,
+					OIDC:          &kedav1alpha1.AuthPodIdentityOIDC{TokenURL: tokenURL, ServiceAccountTokenAudience: "sts", Audience: "prometheus", Scopes: []string{"metrics:read", "openid"}},
+				}
+			},
+			expectedServiceAccount: types.NamespacedName{Namespace: kedaServiceAccountNamespace, Name: kedaServiceAccountName},
+			expectedAudiences:      []string{"sts"},
+			expectedRefresh:        10 * time.Minute,
+		},
+		{
+			name:      "workload identity owner",
+			expiresIn: 3600,
+			podIdentity: func(tokenURL string) kedav1alpha1.AuthPodIdentity {
+				return kedav1alpha1.AuthPodIdentity{
+					Provider:      kedav1alpha1.PodIdentityProviderOIDC,
+					IdentityOwner: &workload,
+					OIDC:          &kedav1alpha1.AuthPodIdentityOIDC{TokenURL: tokenURL, ServiceAccountTokenAudience: "sts", ClientID: "keda", Audience: "prometheus", Scopes: []string{"metrics:read", "openid"}},
+				}
+			},
+			podSpec:                &corev1.PodSpec{ServiceAccountName: "workload-sa"},
+			expectedServiceAccount: types.NamespacedName{Namespace: namespace, Name: "workload-sa"},
+			expectedAudiences:      []string{"sts"},
+			expectedRefresh:        40 * time.Minute,
+		},
+		{
+			name:      "workload identity owner by default",
+			expiresIn: 3600,
+			podIdentity: func(tokenURL string) kedav1alpha1.AuthPodIdentity {
+				return kedav1alpha1.AuthPodIdentity{
+					Provider: kedav1alpha1.PodIdentityProviderOIDC,
+					OIDC:     &kedav1alpha1.AuthPodIdentityOIDC{TokenURL: tokenURL, ServiceAccountTokenAudience: "sts", Audience: "prometheus", Scopes: []string{"metrics:read", "openid"}},
+				}
+			},
+			podSpec:                &corev1.PodSpec{},
+			expectedServiceAccount: types.NamespacedName{Namespace: namespace, Name: defaultServiceAccountName},
+			expectedAudiences:      []string{"sts"},
+			expectedRefresh:        40 * time.Minute,
+		},
+		{
+			name:      "kube-apiserver audience",
+			expiresIn: 900,
+			podIdentity: func(tokenURL string) kedav1alpha1.AuthPodIdentity {
+				return kedav1alpha1.AuthPodIdentity{
+					Provider: kedav1alpha1.PodIdentityProviderOIDC,
+					OIDC:     &kedav1alpha1.AuthPodIdentityOIDC{TokenURL: tokenURL, ServiceAccountTokenAudience: "https://kubernetes.default.svc", Audience: "prometheus"},
+				}
+			},
+			podSpec: &corev1.PodSpec{},
+			isError: true,
+		},
+		{
+			name:      "token rejected by sts",
+			expiresIn: 900,
+			podIdentity: func(tokenURL string) kedav1alpha1.AuthPodIdentity {
+				return kedav1alpha1.AuthPodIdentity{
+					Provider: kedav1alpha1.PodIdentityProviderOIDC,
+					OIDC:     &kedav1alpha1.AuthPodIdentityOIDC{TokenURL: tokenURL, ServiceAccountTokenAudience: "sts", Audience: "unknown"},
+				}
+			},
+			podSpec: &corev1.PodSpec{},
+			isError: true,
+		},
+		{
+			name: "missing token url",
+			podIdentity: func(string) kedav1alpha1.AuthPodIdentity {
+				return kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderOIDC}
+			},
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sts := newFakeSTS(t, test.expiresIn)
+			defer sts.Close()
+			kubeClient := &tokenRequestClient{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+
+			before := time.Now()
+			token, refreshAt, err := resolveOIDCAccessToken(context.Background(), kubeClient, test.podIdentity(sts.URL), test.podSpec, namespace)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, oidcTestAccessToken, token)
+			assert.Equal(t, test.expectedServiceAccount, kubeClient.serviceAccount)
+			assert.Equal(t, test.expectedAudiences, kubeClient.audiences)
+			assert.WithinDuration(t, before.Add(test.expectedRefresh), refreshAt, 5*time.Second)
+		})
+	}
+}
+
+func TestResolveAuthRefWithOIDCPodIdentity(t *testing.T) {
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+	authCache = newAuthResolutionCache(defaultAuthCacheTTL)
+	defer func() { authCache = newAuthResolutionCache(defaultAuthCacheTTL) }()
+
+	sts := newFakeSTS(t, 900)
+	defer sts.Close()
+
+	triggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: triggerAuthenticationName},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			PodIdentity: &kedav1alpha1.AuthPodIdentity{
+				Provider: kedav1alpha1.PodIdentityProviderOIDC,
+				OIDC:     &kedav1alpha1.AuthPodIdentityOIDC{TokenURL: sts.URL, ServiceAccountTokenAudience: "sts", Audience: "prometheus", Scopes: []string{"metrics:read", "openid"}},
+			},
+		},
+	}
+	kubeClient := &tokenRequestClient{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(triggerAuth).Build()}
+
+	params, podIdentity, metadata, err := resolveAuthRef(context.Background(), kubeClient, logf.Log.WithName("test"),
+		&kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName}, &corev1.PodSpec{}, namespace, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, kedav1alpha1.PodIdentityProviderOIDC, podIdentity.Provider)
+	assert.Equal(t, oidcTestAccessToken, params[authentication.OIDCAccessTokenKey])
+	assert.False(t, metadata.Expiration.IsZero())
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,6 +36,9 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/kms"
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -50,6 +54,7 @@ const (
 var (
 	kedaNamespace, _     = util.GetClusterObjectNamespace()
 	restrictSecretAccess = util.GetRestrictSecretAccess()
+	authFilePathRoot     = util.GetAuthFilePathRoot()
 	log                  = logf.Log.WithName("scale_resolvers")
 )
 
@@ -150,46 +155,48 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, sc
 // ResolveContainerEnv resolves all environment variables in a container.
 // It returns either map of env variable key and value or error if there is any.
 func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.Logger, podSpec *corev1.PodSpec, containerName, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
+	container, err := getContainer(podSpec, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveEnv(ctx, client, logger, container, namespace, secretsLister)
+}
+
+// getContainer returns the container with the name or the first container when the name is empty
+func getContainer(podSpec *corev1.PodSpec, containerName string) (*corev1.Container, error) {
 	if len(podSpec.Containers) < 1 {
 		return nil, fmt.Errorf("target object doesn't have containers")
 	}
 
-	var container corev1.Container
-	if containerName != "" {
-		containerWithNameFound := boolFalse
-		for _, c := range podSpec.Containers {
-			if c.Name == containerName {
-				container = c
-				containerWithNameFound = boolTrue
-				break
-			}
-		}
-
-		if !containerWithNameFound {
-			return nil, fmt.Errorf("couldn't find container with name %s on Target object", containerName)
+	if containerName == "" {
+		return &podSpec.Containers[0], nil
+	}
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == containerName {
+			return &podSpec.Containers[i], nil
 		}
-	} else {
-		container = podSpec.Containers[0]
 	}
-
-	return resolveEnv(ctx, client, logger, &container, namespace, secretsLister)
+	return nil, fmt.Errorf("couldn't find container with name %s on Target object", containerName)
 }
 
 // ResolveAuthRefAndPodIdentity provides authentication parameters and pod identity needed authenticate scaler with the environment.
+// The returned metadata describes when the resolved authentication parameters have to be resolved again.
 func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, logger logr.Logger,
 	triggerAuthRef *kedav1alpha1.AuthenticationRef, podTemplateSpec *corev1.PodTemplateSpec,
-	namespace string, secretsLister corev1listers.SecretLister) (map[string]string, kedav1alpha1.AuthPodIdentity, error) {
+	namespace string, secretsLister corev1listers.SecretLister) (map[string]string, kedav1alpha1.AuthPodIdentity, scalersconfig.AuthParamsMetadata, error) {
 	if podTemplateSpec != nil {
-		authParams, podIdentity, err := resolveAuthRef(ctx, client, logger, triggerAuthRef, &podTemplateSpec.Spec, namespace, secretsLister)
+		authParams, podIdentity, metadata, err := resolveAuthRef(ctx, client, logger, triggerAuthRef, &podTemplateSpec.Spec, namespace, secretsLister)
 
 		if err != nil {
-			return authParams, podIdentity, err
+			return authParams, podIdentity, metadata, err
 		}
 		switch podIdentity.Provider {
 		case kedav1alpha1.PodIdentityProviderAws:
 			if podIdentity.RoleArn != nil {
 				if podIdentity.IsWorkloadIdentityOwner() {
-					return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+					metadata.Revoke()
+					return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, scalersconfig.AuthParamsMetadata{},
 						fmt.Errorf("roleArn can't be set if KEDA isn't identity owner, current value: '%s'", *podIdentity.IdentityOwner)
 				}
 				authParams["awsRoleArn"] = *podIdentity.RoleArn
@@ -197,7 +204,8 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 			if podIdentity.IsWorkloadIdentityOwner() {
 				value, err := resolveServiceAccountAnnotation(ctx, client, podTemplateSpec.Spec.ServiceAccountName, namespace, kedav1alpha1.PodIdentityAnnotationEKS, true)
 				if err != nil {
-					return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+					metadata.Revoke()
+					return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, scalersconfig.AuthParamsMetadata{},
 						fmt.Errorf("error getting service account: '%s', error: %w", podTemplateSpec.Spec.ServiceAccountName, err)
 				}
 				authParams["awsRoleArn"] = value
@@ -205,7 +213,8 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 		case kedav1alpha1.PodIdentityProviderAwsEKS:
 			value, err := resolveServiceAccountAnnotation(ctx, client, podTemplateSpec.Spec.ServiceAccountName, namespace, kedav1alpha1.PodIdentityAnnotationEKS, false)
 			if err != nil {
-				return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+				metadata.Revoke()
+				return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, scalersconfig.AuthParamsMetadata{},
 					fmt.Errorf("error getting service account: '%s', error: %w", podTemplateSpec.Spec.ServiceAccountName, err)
 			}
 			authParams["awsRoleArn"] = value
@@ -221,163 +230,201 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 				logger.Info("WARNING: Azure AD Pod Identity has been archived (https://github.com/Azure/aad-pod-identity#-announcement) and will be removed from KEDA on v2.15")
 			}
 			if podIdentity.IdentityID != nil && *podIdentity.IdentityID == "" {
-				return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, fmt.Errorf("IdentityID of PodIdentity should not be empty")
+				metadata.Revoke()
+				return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, scalersconfig.AuthParamsMetadata{}, fmt.Errorf("IdentityID of PodIdentity should not be empty")
 			}
 		default:
 		}
-		return authParams, podIdentity, nil
+		return authParams, podIdentity, metadata, nil
 	}
 
 	return resolveAuthRef(ctx, client, logger, triggerAuthRef, nil, namespace, secretsLister)
 }
 
 // resolveAuthRef provides authentication parameters needed authenticate scaler with the environment.
-// based on authentication method defined in TriggerAuthentication, authParams and podIdentity is returned
+// based on authentication method defined in TriggerAuthentication, authParams, podIdentity and
+// the metadata describing when authParams have to be resolved again (eg: Vault dynamic secrets leases) is returned.
+// Resolved authentication parameters are shared across scalers referencing the same authentication object
+// through the auth resolution cache, unless they depend on the scale target.
 func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logger,
 	triggerAuthRef *kedav1alpha1.AuthenticationRef, podSpec *corev1.PodSpec,
-	namespace string, secretsLister corev1listers.SecretLister) (map[string]string, kedav1alpha1.AuthPodIdentity, error) {
-	result := make(map[string]string)
-	podIdentity := kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}
-	var err error
-
+	namespace string, secretsLister corev1listers.SecretLister) (map[string]string, kedav1alpha1.AuthPodIdentity, scalersconfig.AuthParamsMetadata, error) {
 	if namespace != "" && triggerAuthRef != nil && triggerAuthRef.Name != "" {
-		triggerAuthSpec, triggerNamespace, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
+		triggerAuthSpec, triggerNamespace, key, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
 		if err != nil {
 			logger.Error(err, "error getting triggerAuth", "triggerAuthRef.Name", triggerAuthRef.Name)
 		} else {
-			if triggerAuthSpec.PodIdentity != nil {
-				podIdentity = *triggerAuthSpec.PodIdentity
+			if podSpec != nil {
+				key.serviceAccount = podSpec.ServiceAccountName
 			}
-			if triggerAuthSpec.Env != nil {
-				for _, e := range triggerAuthSpec.Env {
-					if podSpec == nil {
-						result[e.Parameter] = ""
-						continue
-					}
-					env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace, secretsLister)
-					if err != nil {
-						result[e.Parameter] = ""
-					} else {
-						result[e.Parameter] = env[e.Name]
-					}
+			cacheable := isAuthSpecCacheable(triggerAuthSpec)
+			if cacheable {
+				if entry, ok := authCache.get(key, time.Now()); ok {
+					return entry.authParams(), entry.podIdentity, entry.metadata, nil
 				}
 			}
-			if triggerAuthSpec.ConfigMapTargetRef != nil {
-				for _, e := range triggerAuthSpec.ConfigMapTargetRef {
-					result[e.Parameter] = resolveAuthConfigMap(ctx, client, logger, e.Name, triggerNamespace, e.Key)
-				}
+
+			sourceErrors := authSourceErrors{}
+			result, podIdentity, metadata, err := resolveAuthSpec(ctx, client, logger, triggerAuthRef, triggerAuthSpec, triggerNamespace, podSpec, namespace, secretsLister, sourceErrors)
+			reportAuthResolution(ctx, client, logger, triggerAuthRef, namespace, sourceErrors)
+			if err != nil {
+				metadata.Revoke()
 			}
-			if triggerAuthSpec.SecretTargetRef != nil {
-				for _, e := range triggerAuthSpec.SecretTargetRef {
-					result[e.Parameter] = resolveAuthSecret(ctx, client, logger, e.Name, triggerNamespace, e.Key, secretsLister)
-				}
+			if err == nil && cacheable && len(metadata.Leases) == 0 {
+				authCache.set(key, result, podIdentity, metadata, getReferencedSecrets(logger, triggerAuthSpec, triggerNamespace), time.Now())
 			}
-			if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
-				vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
-				err := vault.Initialize(logger)
-				defer vault.Stop()
-				if err != nil {
-					logger.Error(err, "error authenticating to Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
-					return result, podIdentity, err
-				}
-
-				secrets, err := vault.ResolveSecrets(triggerAuthSpec.HashiCorpVault.Secrets)
-				if err != nil {
-					logger.Error(err, "could not get secrets from vault",
-						"triggerAuthRef.Name", triggerAuthRef.Name,
-					)
-					return result, podIdentity, err
-				}
+			return result, podIdentity, metadata, err
+		}
+	}
 
-				for _, e := range secrets {
-					result[e.Parameter] = e.Value
-				}
-			}
-			if triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0 {
-				vaultHandler := NewAzureKeyVaultHandler(triggerAuthSpec.AzureKeyVault)
-				err := vaultHandler.Initialize(ctx, client, logger, triggerNamespace, secretsLister)
-				if err != nil {
-					logger.Error(err, "error authenticating to Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
-					return result, podIdentity, err
-				}
+	return make(map[string]string), kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, scalersconfig.AuthParamsMetadata{}, nil
+}
 
-				for _, secret := range triggerAuthSpec.AzureKeyVault.Secrets {
-					res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
-					if err != nil {
-						logger.Error(err, "error trying to read secret from Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
-							"secret.Name", secret.Name, "secret.Version", secret.Version)
-						return result, podIdentity, err
-					}
+// resolveAuthSpec resolves the authentication parameters defined in the spec of a TriggerAuthentication or ClusterTriggerAuthentication
+func resolveAuthSpec(ctx context.Context, client client.Client, logger logr.Logger,
+	triggerAuthRef *kedav1alpha1.AuthenticationRef, triggerAuthSpec *kedav1alpha1.TriggerAuthenticationSpec, triggerNamespace string,
+	podSpec *corev1.PodSpec, namespace string, secretsLister corev1listers.SecretLister, sourceErrors authSourceErrors) (map[string]string, kedav1alpha1.AuthPodIdentity, scalersconfig.AuthParamsMetadata, error) {
+	result := make(map[string]string)
+	podIdentity := kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}
+	metadata := scalersconfig.AuthParamsMetadata{}
+	var err error
 
-					result[secret.Parameter] = res
-				}
+	if triggerAuthSpec.PodIdentity != nil {
+		podIdentity = *triggerAuthSpec.PodIdentity
+	}
+	if podIdentity.Provider == kedav1alpha1.PodIdentityProviderOIDC {
+		err := validateKedaIdentityOwner(triggerAuthRef, podIdentity.IsWorkloadIdentityOwner())
+		var token string
+		var refreshAt time.Time
+		if err == nil {
+			token, refreshAt, err = resolveOIDCAccessToken(ctx, client, podIdentity, podSpec, namespace)
+		}
+		if err != nil {
+			logger.Error(err, "error exchanging token for oidc pod identity", "triggerAuthRef.Name", triggerAuthRef.Name)
+			sourceErrors.add(authSourcePodIdentity, err)
+			return result, podIdentity, metadata, err
+		}
+		result[authentication.OIDCAccessTokenKey] = token
+		metadata.ExpireAt(refreshAt)
+	}
+	if triggerAuthSpec.Env != nil {
+		for _, e := range triggerAuthSpec.Env {
+			if podSpec == nil {
+				result[e.Parameter] = ""
+				continue
 			}
-			if triggerAuthSpec.GCPSecretManager != nil && len(triggerAuthSpec.GCPSecretManager.Secrets) > 0 {
-				secretManagerHandler := NewGCPSecretManagerHandler(triggerAuthSpec.GCPSecretManager)
-				err := secretManagerHandler.Initialize(ctx, client, logger, triggerNamespace, secretsLister)
-				if err != nil {
-					logger.Error(err, "error authenticating to GCP Secret Manager", "triggerAuthRef.Name", triggerAuthRef.Name)
-				} else {
-					for _, secret := range triggerAuthSpec.GCPSecretManager.Secrets {
-						version := "latest"
-						if secret.Version != "" {
-							version = secret.Version
-						}
-						res, err := secretManagerHandler.Read(ctx, secret.ID, version)
-						if err != nil {
-							logger.Error(err, "error trying to read secret from GCP Secret Manager", "triggerAuthRef.Name", triggerAuthRef.Name,
-								"secret.Name", secret.ID, "secret.Version", secret.Version)
-						} else {
-							result[secret.Parameter] = res
-						}
-					}
-				}
+			env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace, secretsLister)
+			if err != nil {
+				result[e.Parameter] = ""
+				continue
 			}
-			if triggerAuthSpec.AwsSecretManager != nil && len(triggerAuthSpec.AwsSecretManager.Secrets) > 0 {
-				awsSecretManagerHandler := NewAwsSecretManagerHandler(triggerAuthSpec.AwsSecretManager)
-				err := awsSecretManagerHandler.Initialize(ctx, client, logger, triggerNamespace, secretsLister, podSpec)
-				defer awsSecretManagerHandler.Stop()
-				if err != nil {
-					logger.Error(err, "error authenticating to Aws Secret Manager", "triggerAuthRef.Name", triggerAuthRef.Name)
-				} else {
-					for _, secret := range triggerAuthSpec.AwsSecretManager.Secrets {
-						res, err := awsSecretManagerHandler.Read(ctx, logger, secret.Name, secret.VersionID, secret.VersionStage)
-						if err != nil {
-							logger.Error(err, "error trying to read secret from Aws Secret Manager", "triggerAuthRef.Name", triggerAuthRef.Name,
-								"secret.Name", secret.Name, "secret.Version", secret.VersionID, "secret.VersionStage", secret.VersionStage)
-						} else {
-							result[secret.Parameter] = res
-						}
-					}
-				}
+			binding := kms.Binding{Namespace: namespace, FieldPath: kms.EnvFieldPath(e.Name)}
+			value, err := resolveInlineSecret(ctx, binding, env[e.Name], isLiteralContainerEnv(podSpec, e.ContainerName, e.Name))
+			if err != nil {
+				logger.Error(err, "error resolving environment variable", "triggerAuthRef.Name", triggerAuthRef.Name, "env.Name", e.Name)
+				sourceErrors.add(authSourceEnv, err)
+			}
+			result[e.Parameter] = value
+		}
+	}
+	if triggerAuthSpec.ConfigMapTargetRef != nil {
+		for _, e := range triggerAuthSpec.ConfigMapTargetRef {
+			result[e.Parameter] = resolveAuthConfigMap(ctx, client, logger, e.Name, triggerNamespace, e.Key)
+		}
+	}
+	if triggerAuthSpec.SecretTargetRef != nil {
+		for _, e := range triggerAuthSpec.SecretTargetRef {
+			secretNamespace, err := resolveSecretTargetRefNamespace(ctx, client, logger, e, triggerNamespace)
+			if err != nil {
+				logger.Error(err, "error referencing secret", "triggerAuthRef.Name", triggerAuthRef.Name, "Secret.Namespace", e.Namespace, "Secret.Name", e.Name)
+				sourceErrors.add(authSourceSecretTargetRef, err)
+				result[e.Parameter] = ""
+				continue
+			}
+			value, err := resolveAuthSecretValue(ctx, client, logger, e.Name, secretNamespace, e.Key, secretsLister)
+			if err != nil {
+				sourceErrors.add(authSourceSecretTargetRef, err)
+			}
+			result[e.Parameter] = value
+		}
+	}
+	if triggerAuthSpec.FilePath != nil {
+		metadata.Files = make(map[string]time.Time, len(triggerAuthSpec.FilePath))
+		root, rootErr := getAuthFileRoot(logger, authFilePathRoot, triggerAuthRef, namespace)
+		for _, e := range triggerAuthSpec.FilePath {
+			if rootErr != nil {
+				logger.Error(rootErr, "error reading authentication file", "triggerAuthRef.Name", triggerAuthRef.Name, "path", e.Path)
+				sourceErrors.add(authSourceFilePath, rootErr)
+				result[e.Parameter] = ""
+				continue
+			}
+			value, path, modTime, err := resolveAuthFile(root, e)
+			if err != nil {
+				logger.Error(err, "error reading authentication file", "triggerAuthRef.Name", triggerAuthRef.Name, "path", e.Path)
+				sourceErrors.add(authSourceFilePath, err)
+				result[e.Parameter] = ""
+				continue
 			}
+			result[e.Parameter] = value
+			metadata.Files[path] = modTime
 		}
 	}
+	if triggerAuthSpec.ServiceAccountToken != nil {
+		err := validateKedaIdentityOwner(triggerAuthRef, triggerAuthSpec.ServiceAccountToken.IsWorkloadIdentityOwner())
+		var token string
+		var refreshAt time.Time
+		if err == nil {
+			token, refreshAt, err = resolveServiceAccountToken(ctx, client, triggerAuthSpec.ServiceAccountToken, podSpec, namespace)
+		}
+		if err != nil {
+			logger.Error(err, "error requesting service account token", "triggerAuthRef.Name", triggerAuthRef.Name)
+			sourceErrors.add(authSourceServiceAccountToken, err)
+			return result, podIdentity, metadata, err
+		}
+		result[ServiceAccountTokenParameter] = token
+		metadata.ExpireAt(refreshAt)
+	}
+	providerCtx := SecretProviderContext{
+		Client:           client,
+		Logger:           logger,
+		SecretsLister:    secretsLister,
+		TriggerNamespace: triggerNamespace,
+		AuthNamespace:    getAuthenticationNamespace(triggerAuthRef, namespace),
+		Namespace:        namespace,
+		PodSpec:          podSpec,
+	}
+	if err := resolveSecretProviders(ctx, providerCtx, triggerAuthRef, triggerAuthSpec, result, &metadata, sourceErrors); err != nil {
+		return result, podIdentity, metadata, err
+	}
 
-	return result, podIdentity, err
+	return result, podIdentity, metadata, err
 }
 
-func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, error) {
+// getTriggerAuthSpec returns the spec of the TriggerAuthentication or ClusterTriggerAuthentication referenced by triggerAuthRef,
+// the namespace where the referenced resources (eg: secrets) are located and the key of the object in the auth resolution cache
+func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, authCacheKey, error) {
 	if triggerAuthRef.Kind == "" || triggerAuthRef.Kind == "TriggerAuthentication" {
 		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
 		err := client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name, Namespace: namespace}, triggerAuth)
 		if err != nil {
-			return nil, "", err
+			return nil, "", authCacheKey{}, err
 		}
-		return &triggerAuth.Spec, namespace, nil
+		key := authCacheKey{authentication: GetAuthenticationKey(triggerAuthRef, namespace), namespace: namespace, generation: triggerAuth.Generation}
+		return &triggerAuth.Spec, namespace, key, nil
 	} else if triggerAuthRef.Kind == "ClusterTriggerAuthentication" {
 		clusterNamespace, err := util.GetClusterObjectNamespace()
 		if err != nil {
-			return nil, "", err
+			return nil, "", authCacheKey{}, err
 		}
 		triggerAuth := &kedav1alpha1.ClusterTriggerAuthentication{}
 		err = client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name}, triggerAuth)
 		if err != nil {
-			return nil, "", err
+			return nil, "", authCacheKey{}, err
 		}
-		return &triggerAuth.Spec, clusterNamespace, nil
+		key := authCacheKey{authentication: GetAuthenticationKey(triggerAuthRef, namespace), namespace: namespace, generation: triggerAuth.Generation}
+		return &triggerAuth.Spec, clusterNamespace, key, nil
 	}
-	return nil, "", fmt.Errorf("unknown trigger auth kind %s", triggerAuthRef.Kind)
+	return nil, "", authCacheKey{}, fmt.Errorf("unknown trigger auth kind %s", triggerAuthRef.Kind)
 }
 
 func resolveEnv(ctx context.Context, client client.Client, logger logr.Logger, container *corev1.Container, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
@@ -580,9 +627,16 @@ func resolveAuthConfigMap(ctx context.Context, client client.Client, logger logr
 }
 
 func resolveAuthSecret(ctx context.Context, client client.Client, logger logr.Logger, name, namespace, key string, secretsLister corev1listers.SecretLister) string {
+	value, _ := resolveAuthSecretValue(ctx, client, logger, name, namespace, key, secretsLister)
+	return value
+}
+
+// resolveAuthSecretValue returns the value of the key in the secret or an error if the secret can't be read or doesn't contain the key
+func resolveAuthSecretValue(ctx context.Context, client client.Client, logger logr.Logger, name, namespace, key string, secretsLister corev1listers.SecretLister) (string, error) {
 	if name == "" || namespace == "" || key == "" {
-		logger.Error(fmt.Errorf("error trying to get secret"), "name, namespace and key are required", "Secret.Namespace", namespace, "Secret.Name", name, "key", key)
-		return ""
+		err := fmt.Errorf("error trying to get secret")
+		logger.Error(err, "name, namespace and key are required", "Secret.Namespace", namespace, "Secret.Name", name, "key", key)
+		return "", fmt.Errorf("name, namespace and key are required to get secret %q", name)
 	}
 
 	secret := &corev1.Secret{}
@@ -594,15 +648,14 @@ func resolveAuthSecret(ctx context.Context, client client.Client, logger logr.Lo
 	}
 	if err != nil {
 		logger.Error(err, "error trying to get secret from namespace", "Secret.Namespace", namespace, "Secret.Name", name)
-		return ""
+		return "", err
 	}
-	result := secret.Data[key]
-
-	if result == nil {
-		return ""
+	result, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %s/%s", key, namespace, name)
 	}
 
-	return string(result)
+	return string(result), nil
 }
 
 // resolveServiceAccountAnnotation retrieves the value of a specific annotation
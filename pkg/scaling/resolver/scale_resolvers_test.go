@@ -615,7 +615,9 @@ func TestResolveAuthRef(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
 			os.Setenv("KEDA_CLUSTER_OBJECT_NAMESPACE", clusterNamespace) // Inject test cluster namespace.
-			gotMap, gotPodIdentity, err := resolveAuthRef(
+			// fake objects share the same generation, don't reuse parameters resolved by a previous case
+			authCache = newAuthResolutionCache(defaultAuthCacheTTL)
+			gotMap, gotPodIdentity, _, err := resolveAuthRef(
 				ctx,
 				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build(),
 				logf.Log.WithName("test"),
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// resolveSecretTargetRefNamespace returns the namespace of the secret referenced by secretTargetRef.
+// A secret outside of triggerNamespace is only returned when a TriggerAuthenticationGrant in the namespace
+// of the secret allows triggerNamespace to reference it
+func resolveSecretTargetRefNamespace(ctx context.Context, kubeClient client.Client, logger logr.Logger, ref kedav1alpha1.AuthSecretTargetRef, triggerNamespace string) (string, error) {
+	if ref.Namespace == "" || ref.Namespace == triggerNamespace {
+		return triggerNamespace, nil
+	}
+	if isSecretAccessRestricted(logger) {
+		return "", fmt.Errorf("secret %s/%s can't be referenced because secret access is restricted to KEDA namespace", ref.Namespace, ref.Name)
+	}
+
+	grants := &kedav1alpha1.TriggerAuthenticationGrantList{}
+	if err := kubeClient.List(ctx, grants, client.InNamespace(ref.Namespace)); err != nil {
+		return "", fmt.Errorf("error listing TriggerAuthenticationGrants in namespace %s: %w", ref.Namespace, err)
+	}
+	if !kedav1alpha1.IsSecretReferenceGranted(grants.Items, triggerNamespace, ref.Name) {
+		return "", fmt.Errorf("secret %s/%s isn't granted to namespace %s by any TriggerAuthenticationGrant", ref.Namespace, ref.Name, triggerNamespace)
+	}
+	return ref.Namespace, nil
+}
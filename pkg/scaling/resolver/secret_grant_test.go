@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const sharedSecretNamespace = "shared-namespace"
+
+func TestResolveAuthRefWithGrantedSecret(t *testing.T) {
+	restrictSecretAccessBackup := restrictSecretAccess
+	restrictSecretAccess = ""
+	defer func() { restrictSecretAccess = restrictSecretAccessBackup }()
+
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+
+	triggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: triggerAuthenticationName},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: secretName, Key: secretKey, Namespace: sharedSecretNamespace}},
+		},
+	}
+	sharedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: sharedSecretNamespace, Name: secretName},
+		Data:       map[string][]byte{secretKey: []byte(secretData)},
+	}
+
+	tests := []struct {
+		name           string
+		grant          *kedav1alpha1.TriggerAuthenticationGrant
+		expectedValue  string
+		expectedErrors int
+	}{
+		{
+			name:           "no grant",
+			expectedValue:  "",
+			expectedErrors: 1,
+		},
+		{
+			name:           "grant for another namespace",
+			grant:          createTriggerAuthenticationGrant("other-namespace", secretName),
+			expectedValue:  "",
+			expectedErrors: 1,
+		},
+		{
+			name:           "grant for another secret",
+			grant:          createTriggerAuthenticationGrant(namespace, "other-secret"),
+			expectedValue:  "",
+			expectedErrors: 1,
+		},
+		{
+			name:           "granted secret",
+			grant:          createTriggerAuthenticationGrant(namespace, secretName),
+			expectedValue:  secretData,
+			expectedErrors: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authCache = newAuthResolutionCache(defaultAuthCacheTTL)
+			defer func() { authCache = newAuthResolutionCache(defaultAuthCacheTTL) }()
+
+			objects := []runtime.Object{triggerAuth.DeepCopy(), sharedSecret.DeepCopy()}
+			if test.grant != nil {
+				objects = append(objects, test.grant)
+			}
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objects...).WithStatusSubresource(triggerAuth).Build()
+
+			params, _, _, err := resolveAuthRef(context.Background(), kubeClient, logf.Log.WithName("test"),
+				&kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName}, nil, namespace, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, params["host"])
+
+			result := &kedav1alpha1.TriggerAuthentication{}
+			assert.NoError(t, kubeClient.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: triggerAuthenticationName}, result))
+			assert.Len(t, result.Status.SourceErrors, test.expectedErrors)
+		})
+	}
+}
+
+func TestGetReferencedSecretsWithGrantedSecret(t *testing.T) {
+	restrictSecretAccessBackup := restrictSecretAccess
+	restrictSecretAccess = ""
+	defer func() { restrictSecretAccess = restrictSecretAccessBackup }()
+
+	spec := &kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+			{Parameter: "host", Name: secretName, Key: secretKey, Namespace: sharedSecretNamespace},
+			{Parameter: "password", Name: secretName, Key: secretKey},
+		},
+	}
+	secrets := getReferencedSecrets(logf.Log.WithName("test"), spec, namespace)
+	assert.ElementsMatch(t, []types.NamespacedName{
+		{Namespace: sharedSecretNamespace, Name: secretName},
+		{Namespace: namespace, Name: secretName},
+	}, secrets)
+}
+
+func TestAuthResolutionCacheInvalidateNamespace(t *testing.T) {
+	cache := newAuthResolutionCache(defaultAuthCacheTTL)
+	now := time.Now()
+
+	granted := authCacheKey{authentication: AuthenticationKey("TriggerAuthentication/" + namespace + "/granted")}
+	local := authCacheKey{authentication: AuthenticationKey("TriggerAuthentication/" + namespace + "/local")}
+	cache.set(granted, map[string]string{}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{},
+		[]types.NamespacedName{{Namespace: sharedSecretNamespace, Name: secretName}}, now)
+	cache.set(local, map[string]string{}, kedav1alpha1.AuthPodIdentity{}, scalersconfig.AuthParamsMetadata{},
+		[]types.NamespacedName{{Namespace: namespace, Name: secretName}}, now)
+
+	assert.Equal(t, []AuthenticationKey{granted.authentication}, cache.invalidateNamespace(sharedSecretNamespace))
+	_, ok := cache.get(granted, now)
+	assert.False(t, ok)
+	_, ok = cache.get(local, now)
+	assert.True(t, ok)
+}
+
+func createTriggerAuthenticationGrant(fromNamespace, name string) *kedav1alpha1.TriggerAuthenticationGrant {
+	return &kedav1alpha1.TriggerAuthenticationGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: sharedSecretNamespace, Name: "grant"},
+		Spec: kedav1alpha1.TriggerAuthenticationGrantSpec{
+			From: []kedav1alpha1.TriggerAuthenticationGrantFrom{{Namespace: fromNamespace}},
+			To:   []kedav1alpha1.TriggerAuthenticationGrantTo{{Name: name}},
+		},
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// SecretProviderTimeoutEnvVar defines how long a secret provider can take to read the secrets of a TriggerAuthentication
+	SecretProviderTimeoutEnvVar  = "KEDA_SECRET_PROVIDER_TIMEOUT"
+	defaultSecretProviderTimeout = 30 * time.Second
+
+	secretProviderResultSuccess = "success"
+	secretProviderResultError   = "error"
+	secretProviderResultCached  = "cached"
+)
+
+// SecretProviderContext holds what a SecretProvider may need to authenticate to its secret store
+type SecretProviderContext struct {
+	Client        client.Client
+	Logger        logr.Logger
+	SecretsLister corev1listers.SecretLister
+	// TriggerNamespace is the namespace of the Secrets referenced by the TriggerAuthenticationSpec
+	TriggerNamespace string
+	// AuthNamespace is the namespace of the TriggerAuthentication, empty for a ClusterTriggerAuthentication
+	AuthNamespace string
+	// Namespace is the namespace of the scale target
+	Namespace string
+	// PodSpec is the pod template of the scale target, nil when the target isn't a workload
+	PodSpec *corev1.PodSpec
+}
+
+// SecretProviderResult holds the secrets read by a SecretProvider
+type SecretProviderResult struct {
+	// Values are the secrets keyed by the parameter they are exposed as
+	Values map[string]string
+	// ExpireAt is when the secrets have to be read again, zero value means that they don't expire
+	ExpireAt time.Time
+	// Lease keeps the secrets valid until it is revoked, nil when the secrets aren't leased.
+	// Leased secrets are owned by a single scaler, so they are never cached
+	Lease scalersconfig.AuthParamsLease
+	// Errors are the secrets that couldn't be read, without preventing the others from being used
+	Errors []error
+}
+
+// SecretProvider reads the secrets requested by a TriggerAuthenticationSpec from a secret store
+type SecretProvider interface {
+	// Config returns the section of the spec configuring the provider and whether the spec reads any secret from it,
+	// the section identifies the secrets in the cache shared by the TriggerAuthentications
+	Config(spec *kedav1alpha1.TriggerAuthenticationSpec) (any, bool)
+	// ResolveSecrets reads the secrets requested by the spec, returning an error prevents all the authentication parameters from being used
+	ResolveSecrets(ctx context.Context, providerCtx SecretProviderContext, spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error)
+}
+
+type secretProviderEntry struct {
+	name     string
+	provider SecretProvider
+}
+
+// secretProviders are resolved in registration order, so the parameters of the latest provider win on conflicts
+var (
+	secretProviders     []secretProviderEntry
+	secretProvidersLock sync.RWMutex
+
+	secretProviderCache   = newSecretProviderCache(getAuthCacheTTL())
+	secretProviderTimeout = getSecretProviderTimeout()
+)
+
+func init() {
+	for _, entry := range []secretProviderEntry{
+		{authSourceHashiCorpVault, hashiCorpVaultSecretProvider{}},
+		{authSourceAzureKeyVault, azureKeyVaultSecretProvider{}},
+		{authSourceGCPSecretManager, gcpSecretManagerSecretProvider{}},
+		{authSourceAwsSecretManager, awsSecretManagerSecretProvider{}},
+		{authSourceExternalSecretProvider, externalSecretProvider{}},
+	} {
+		if err := RegisterSecretProvider(entry.name, entry.provider); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterSecretProvider adds a provider resolved for every TriggerAuthenticationSpec, the name is used in logs,
+// metrics and the TriggerAuthentication status
+func RegisterSecretProvider(name string, provider SecretProvider) error {
+	secretProvidersLock.Lock()
+	defer secretProvidersLock.Unlock()
+	for _, entry := range secretProviders {
+		if entry.name == name {
+			return fmt.Errorf("secret provider %s is already registered", name)
+		}
+	}
+	secretProviders = append(secretProviders, secretProviderEntry{name: name, provider: provider})
+	return nil
+}
+
+func getSecretProviderTimeout() time.Duration {
+	timeout, err := util.ResolveOsEnvDuration(SecretProviderTimeoutEnvVar)
+	if err != nil {
+		log.Error(err, "invalid secret provider timeout, using default", "Env Var", SecretProviderTimeoutEnvVar, "default", defaultSecretProviderTimeout)
+		return defaultSecretProviderTimeout
+	}
+	if timeout == nil || *timeout <= 0 {
+		return defaultSecretProviderTimeout
+	}
+	return *timeout
+}
+
+// resolveSecretProviders reads the secrets of every registered provider the spec uses, merging them into result
+func resolveSecretProviders(ctx context.Context, providerCtx SecretProviderContext, triggerAuthRef *kedav1alpha1.AuthenticationRef,
+	spec *kedav1alpha1.TriggerAuthenticationSpec, result map[string]string, metadata *scalersconfig.AuthParamsMetadata, sourceErrors authSourceErrors) error {
+	secretProvidersLock.RLock()
+	providers := make([]secretProviderEntry, len(secretProviders))
+	copy(providers, secretProviders)
+	secretProvidersLock.RUnlock()
+
+	for _, entry := range providers {
+		config, ok := entry.provider.Config(spec)
+		if !ok {
+			continue
+		}
+		providerResult, err := resolveSecretProvider(ctx, providerCtx, entry, config, spec)
+		if err != nil {
+			providerCtx.Logger.Error(err, "error reading secrets", "provider", entry.name, "triggerAuthRef.Name", triggerAuthRef.Name)
+			sourceErrors.add(entry.name, err)
+			return err
+		}
+		for _, err := range providerResult.Errors {
+			providerCtx.Logger.Error(err, "error reading secret", "provider", entry.name, "triggerAuthRef.Name", triggerAuthRef.Name)
+			sourceErrors.add(entry.name, err)
+		}
+		for parameter, value := range providerResult.Values {
+			result[parameter] = value
+		}
+		metadata.ExpireAt(providerResult.ExpireAt)
+		if providerResult.Lease != nil {
+			metadata.Leases = append(metadata.Leases, providerResult.Lease)
+		}
+	}
+	return nil
+}
+
+// resolveSecretProvider reads the secrets of a provider from the cache or, with a timeout, from the provider itself
+func resolveSecretProvider(ctx context.Context, providerCtx SecretProviderContext, entry secretProviderEntry, config any,
+	spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error) {
+	key, err := getSecretProviderCacheKey(entry.name, providerCtx, config)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if cached, ok := secretProviderCache.get(key, now); ok {
+		metricscollector.RecordSecretProviderRequest(providerCtx.Namespace, entry.name, secretProviderResultCached)
+		return cached, nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, secretProviderTimeout)
+	defer cancel()
+	start := time.Now()
+	providerResult, err := entry.provider.ResolveSecrets(timeoutCtx, providerCtx, spec)
+	metricscollector.RecordSecretProviderLatency(providerCtx.Namespace, entry.name, time.Since(start).Seconds())
+	if err != nil {
+		metricscollector.RecordSecretProviderRequest(providerCtx.Namespace, entry.name, secretProviderResultError)
+		return nil, err
+	}
+	if len(providerResult.Errors) > 0 {
+		metricscollector.RecordSecretProviderRequest(providerCtx.Namespace, entry.name, secretProviderResultError)
+		return providerResult, nil
+	}
+	metricscollector.RecordSecretProviderRequest(providerCtx.Namespace, entry.name, secretProviderResultSuccess)
+	if providerResult.Lease == nil {
+		secretProviderCache.set(key, providerResult, getReferencedSecrets(providerCtx.Logger, spec, providerCtx.TriggerNamespace), now)
+	}
+	return providerResult, nil
+}
+
+// getSecretProviderCacheKey identifies the secrets read with the same configuration and identity, so
+// TriggerAuthentications and ClusterTriggerAuthentications reading the same secrets share the cached values
+func getSecretProviderCacheKey(name string, providerCtx SecretProviderContext, config any) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("error computing %s cache key: %w", name, err)
+	}
+	serviceAccountName := ""
+	if providerCtx.PodSpec != nil {
+		serviceAccountName = providerCtx.PodSpec.ServiceAccountName
+	}
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf("%s/%s/%s/%s/%s", name, providerCtx.TriggerNamespace, providerCtx.Namespace, serviceAccountName, hex.EncodeToString(hash[:])), nil
+}
+
+type secretProviderCacheEntry struct {
+	result *SecretProviderResult
+	// secrets are the Secrets the provider credentials are read from, a change to any of them invalidates the entry
+	secrets    []types.NamespacedName
+	expiration time.Time
+}
+
+// secretProviderCacheStore stores the secrets read by the providers, entries are bounded by a TTL and by the expiration of the secrets,
+// and are invalidated when a Secret the provider credentials are read from changes
+type secretProviderCacheStore struct {
+	lock  sync.Mutex
+	ttl   time.Duration
+	items map[string]secretProviderCacheEntry
+}
+
+func newSecretProviderCache(ttl time.Duration) *secretProviderCacheStore {
+	return &secretProviderCacheStore{
+		ttl:   ttl,
+		items: make(map[string]secretProviderCacheEntry),
+	}
+}
+
+// get returns a copy of the cached secrets, as the values are merged into authentication parameters that scalers are allowed to modify.
+// An expired entry is evicted, so the secrets it holds don't stay in memory until the same key is read again
+func (c *secretProviderCacheStore) get(key string, now time.Time) (*SecretProviderResult, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiration) {
+		delete(c.items, key)
+		return nil, false
+	}
+	values := make(map[string]string, len(entry.result.Values))
+	for k, v := range entry.result.Values {
+		values[k] = v
+	}
+	return &SecretProviderResult{Values: values, ExpireAt: entry.result.ExpireAt}, true
+}
+
+func (c *secretProviderCacheStore) set(key string, result *SecretProviderResult, secrets []types.NamespacedName, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	expiration := now.Add(c.ttl)
+	if !result.ExpireAt.IsZero() && result.ExpireAt.Before(expiration) {
+		expiration = result.ExpireAt
+	}
+	values := make(map[string]string, len(result.Values))
+	for k, v := range result.Values {
+		values[k] = v
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// drop expired entries, as entries of removed TriggerAuthentications are never read again
+	for k, e := range c.items {
+		if !now.Before(e.expiration) {
+			delete(c.items, k)
+		}
+	}
+	c.items[key] = secretProviderCacheEntry{
+		result:     &SecretProviderResult{Values: values, ExpireAt: result.ExpireAt},
+		secrets:    secrets,
+		expiration: expiration,
+	}
+}
+
+// invalidate removes the entries whose provider credentials are read from any Secret matching the input function
+func (c *secretProviderCacheStore) invalidate(matches func(types.NamespacedName) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, entry := range c.items {
+		if slices.ContainsFunc(entry.secrets, matches) {
+			delete(c.items, key)
+		}
+	}
+}
+
+func (c *secretProviderCacheStore) isSecretReferenced(secret types.NamespacedName) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, entry := range c.items {
+		if slices.Contains(entry.secrets, secret) {
+			return true
+		}
+	}
+	return false
+}
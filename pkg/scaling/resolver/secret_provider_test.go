@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	pb "github.com/kedacore/keda/v2/pkg/scaling/resolver/externalsecretprovider"
+)
+
+// fakeSecretProvider reads the secrets of the spec env, so tests don't depend on a real secret store
+type fakeSecretProvider struct {
+	calls  int
+	delay  time.Duration
+	err    error
+	errors []error
+}
+
+func (p *fakeSecretProvider) Config(spec *kedav1alpha1.TriggerAuthenticationSpec) (any, bool) {
+	return spec.Env, len(spec.Env) > 0
+}
+
+func (p *fakeSecretProvider) ResolveSecrets(ctx context.Context, _ SecretProviderContext, spec *kedav1alpha1.TriggerAuthenticationSpec) (*SecretProviderResult, error) {
+	p.calls++
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	result := &SecretProviderResult{Values: map[string]string{}, Errors: p.errors}
+	for _, e := range spec.Env {
+		result.Values[e.Parameter] = e.Name
+	}
+	return result, nil
+}
+
+func TestRegisterSecretProvider(t *testing.T) {
+	assert.Error(t, RegisterSecretProvider(authSourceHashiCorpVault, &fakeSecretProvider{}))
+}
+
+func TestResolveSecretProvider(t *testing.T) {
+	spec := &kedav1alpha1.TriggerAuthenticationSpec{Env: []kedav1alpha1.AuthEnvironment{{Parameter: "host", Name: secretData}}}
+	providerCtx := SecretProviderContext{Logger: logf.Log.WithName("test"), TriggerNamespace: namespace, Namespace: namespace}
+
+	tests := []struct {
+		name          string
+		provider      *fakeSecretProvider
+		isError       bool
+		expectedCalls int
+	}{
+		{
+			name:          "secrets are cached",
+			provider:      &fakeSecretProvider{},
+			expectedCalls: 1,
+		},
+		{
+			name:          "secrets with errors aren't cached",
+			provider:      &fakeSecretProvider{errors: []error{errors.New("secret not found")}},
+			expectedCalls: 2,
+		},
+		{
+			name:          "provider error",
+			provider:      &fakeSecretProvider{err: errors.New("unauthorized")},
+			isError:       true,
+			expectedCalls: 2,
+		},
+		{
+			name:          "provider timeout",
+			provider:      &fakeSecretProvider{delay: time.Minute},
+			isError:       true,
+			expectedCalls: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secretProviderCache = newSecretProviderCache(defaultAuthCacheTTL)
+			defer func() { secretProviderCache = newSecretProviderCache(defaultAuthCacheTTL) }()
+			secretProviderTimeout = 100 * time.Millisecond
+			defer func() { secretProviderTimeout = defaultSecretProviderTimeout }()
+
+			entry := secretProviderEntry{name: "fake", provider: test.provider}
+			config, ok := test.provider.Config(spec)
+			assert.True(t, ok)
+			for i := 0; i < 2; i++ {
+				result, err := resolveSecretProvider(context.Background(), providerCtx, entry, config, spec)
+				if test.isError {
+					assert.Error(t, err)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, secretData, result.Values["host"])
+				// cached values can be modified by the caller without affecting the cache
+				result.Values["host"] = ""
+			}
+			assert.Equal(t, test.expectedCalls, test.provider.calls)
+		})
+	}
+}
+
+func TestSecretProviderCacheExpiration(t *testing.T) {
+	cache := newSecretProviderCache(time.Minute)
+	now := time.Now()
+
+	cache.set("expiring", &SecretProviderResult{Values: map[string]string{"host": secretData}, ExpireAt: now.Add(time.Second)}, nil, now)
+	cache.set("default", &SecretProviderResult{Values: map[string]string{"host": secretData}}, nil, now)
+
+	_, ok := cache.get("expiring", now.Add(2*time.Second))
+	assert.False(t, ok)
+	assert.NotContains(t, cache.items, "expiring")
+	_, ok = cache.get("default", now.Add(2*time.Second))
+	assert.True(t, ok)
+	_, ok = cache.get("default", now.Add(2*time.Minute))
+	assert.False(t, ok)
+
+	// expired entries are dropped when other entries are stored
+	cache.set("stale", &SecretProviderResult{Values: map[string]string{"host": secretData}, ExpireAt: now.Add(time.Second)}, nil, now)
+	cache.set("fresh", &SecretProviderResult{Values: map[string]string{"host": secretData}}, nil, now.Add(2*time.Second))
+	assert.NotContains(t, cache.items, "stale")
+	assert.Contains(t, cache.items, "fresh")
+}
+
+func TestSecretProviderCacheInvalidation(t *testing.T) {
+	secretProviderCache = newSecretProviderCache(time.Minute)
+	defer func() { secretProviderCache = newSecretProviderCache(defaultAuthCacheTTL) }()
+	now := time.Now()
+	result := &SecretProviderResult{Values: map[string]string{"host": secretData}}
+
+	secretProviderCache.set("vault", result, []types.NamespacedName{{Namespace: namespace, Name: "vault-approle"}}, now)
+	secretProviderCache.set("azure", result, []types.NamespacedName{{Namespace: namespace, Name: "azure-client"}}, now)
+	secretProviderCache.set("gcp", result, []types.NamespacedName{{Namespace: "other", Name: "gcp-credentials"}}, now)
+
+	assert.True(t, IsSecretReferencedByAuthCache(namespace, "vault-approle"))
+	InvalidateAuthCacheForSecret(namespace, "vault-approle")
+	assert.False(t, IsSecretReferencedByAuthCache(namespace, "vault-approle"))
+	_, ok := secretProviderCache.get("vault", now)
+	assert.False(t, ok)
+	_, ok = secretProviderCache.get("azure", now)
+	assert.True(t, ok)
+
+	InvalidateAuthCacheForNamespace(namespace)
+	_, ok = secretProviderCache.get("azure", now)
+	assert.False(t, ok)
+	_, ok = secretProviderCache.get("gcp", now)
+	assert.True(t, ok)
+}
+
+type testExternalSecretProviderServer struct {
+	pb.UnimplementedExternalSecretProviderServer
+	secrets map[string]string
+}
+
+func (s *testExternalSecretProviderServer) GetSecrets(_ context.Context, request *pb.GetSecretsRequest) (*pb.GetSecretsResponse, error) {
+	response := &pb.GetSecretsResponse{TtlSeconds: 60}
+	for _, secret := range request.Secrets {
+		value, ok := s.secrets[request.Metadata["store"]+"/"+secret.Name+"@"+secret.Version]
+		if !ok {
+			continue
+		}
+		response.Secrets = append(response.Secrets, &pb.SecretValue{Name: secret.Name, Version: secret.Version, Value: value})
+	}
+	return response, nil
+}
+
+func TestExternalSecretProvider(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	pb.RegisterExternalSecretProviderServer(server, &testExternalSecretProviderServer{
+		secrets: map[string]string{"prod/db@": "current-password", "prod/db@1": "old-password"},
+	})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	tests := []struct {
+		name     string
+		provider *kedav1alpha1.ExternalSecretProvider
+		expected map[string]string
+		isError  bool
+	}{
+		{
+			name: "secrets",
+			provider: &kedav1alpha1.ExternalSecretProvider{
+				Address:  listener.Addr().String(),
+				Metadata: map[string]string{"store": "prod"},
+				Secrets: []kedav1alpha1.ExternalSecretProviderSecret{
+					{Parameter: "password", Name: "db"},
+					{Parameter: "oldPassword", Name: "db", Version: "1"},
+				},
+			},
+			expected: map[string]string{"password": "current-password", "oldPassword": "old-password"},
+		},
+		{
+			name: "missing secret",
+			provider: &kedav1alpha1.ExternalSecretProvider{
+				Address:  listener.Addr().String(),
+				Metadata: map[string]string{"store": "dev"},
+				Secrets:  []kedav1alpha1.ExternalSecretProviderSecret{{Parameter: "password", Name: "db"}},
+			},
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secretProviderCache = newSecretProviderCache(defaultAuthCacheTTL)
+			defer func() { secretProviderCache = newSecretProviderCache(defaultAuthCacheTTL) }()
+
+			spec := &kedav1alpha1.TriggerAuthenticationSpec{ExternalSecretProvider: test.provider}
+			_, ok := externalSecretProvider{}.Config(spec)
+			assert.True(t, ok)
+
+			result, err := externalSecretProvider{}.ResolveSecrets(context.Background(), SecretProviderContext{Logger: logf.Log.WithName("test")}, spec)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result.Values)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), result.ExpireAt, 5*time.Second)
+
+			metadata := scalersconfig.AuthParamsMetadata{}
+			params := map[string]string{}
+			err = resolveSecretProviders(context.Background(), SecretProviderContext{Logger: logf.Log.WithName("test")},
+				&kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName}, spec, params, &metadata, authSourceErrors{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, params)
+			assert.False(t, metadata.Expiration.IsZero())
+		})
+	}
+}
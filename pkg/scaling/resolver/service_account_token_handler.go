@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// ServiceAccountTokenParameter is the authentication parameter the requested ServiceAccount token is exposed as
+	ServiceAccountTokenParameter = "serviceAccountToken"
+
+	// serviceAccountTokenRefreshRatio is the fraction of a token lifetime after which a new token is requested
+	serviceAccountTokenRefreshRatio = 2.0 / 3.0
+	defaultServiceAccountName       = "default"
+)
+
+var (
+	kedaServiceAccountName      = util.GetServiceAccountName()
+	kedaServiceAccountNamespace = util.GetPodNamespace()
+
+	// kedaServiceAccountTokenPath is the token of KEDA ServiceAccount mounted into the operator,
+	// it carries the audiences accepted by the kube-apiserver
+	kedaServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	apiServerAudiencesOnce sync.Once
+	apiServerAudiences     []string
+)
+
+// validateKedaIdentityOwner only allows tokens of KEDA ServiceAccount to be requested from ClusterTriggerAuthentication,
+// as any namespace could act with KEDA identity otherwise
+func validateKedaIdentityOwner(triggerAuthRef *kedav1alpha1.AuthenticationRef, isWorkloadIdentityOwner bool) error {
+	if isWorkloadIdentityOwner || triggerAuthRef.Kind == "ClusterTriggerAuthentication" {
+		return nil
+	}
+	return errors.New("keda identity owner is only allowed in ClusterTriggerAuthentication")
+}
+
+// resolveServiceAccountToken requests the ServiceAccount token configured in a TriggerAuthentication, it returns
+// the token and the time at which a new one has to be requested. The token is requested for the ServiceAccount
+// of the scale target, or for KEDA ServiceAccount when KEDA is the identity owner
+func resolveServiceAccountToken(ctx context.Context, kubeClient client.Client, serviceAccountToken *kedav1alpha1.AuthServiceAccountToken,
+	podSpec *corev1.PodSpec, namespace string) (string, time.Time, error) {
+	name, tokenNamespace := kedaServiceAccountName, kedaServiceAccountNamespace
+	if serviceAccountToken.IsWorkloadIdentityOwner() {
+		workloadServiceAccountName, err := getWorkloadServiceAccountName(podSpec)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("workload identity owner: %w", err)
+		}
+		name, tokenNamespace = workloadServiceAccountName, namespace
+	}
+
+	var audiences []string
+	if serviceAccountToken.Audience != "" {
+		audiences = []string{serviceAccountToken.Audience}
+	}
+
+	now := time.Now()
+	status, err := requestServiceAccountToken(ctx, kubeClient, name, tokenNamespace, audiences, serviceAccountToken.ExpirationSeconds)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var refreshAt time.Time
+	if !status.ExpirationTimestamp.IsZero() {
+		lifetime := status.ExpirationTimestamp.Sub(now)
+		refreshAt = now.Add(time.Duration(float64(lifetime) * serviceAccountTokenRefreshRatio))
+	}
+	return status.Token, refreshAt, nil
+}
+
+// getWorkloadServiceAccountName returns the ServiceAccount the pods of the scale target run as
+func getWorkloadServiceAccountName(podSpec *corev1.PodSpec) (string, error) {
+	if podSpec == nil {
+		return "", errors.New("a scale target with a pod template is required")
+	}
+	if podSpec.ServiceAccountName == "" {
+		return defaultServiceAccountName, nil
+	}
+	return podSpec.ServiceAccountName, nil
+}
+
+// requestServiceAccountToken requests a token for the ServiceAccount with the audiences using the TokenRequest API.
+// The tokens are sent outside of the cluster, so they are never requested for the kube-apiserver audiences.
+func requestServiceAccountToken(ctx context.Context, kubeClient client.Client, name, namespace string,
+	audiences []string, expirationSeconds *int64) (*authenticationv1.TokenRequestStatus, error) {
+	if kubeClient == nil {
+		return nil, errors.New("kubernetes client is required to request a service account token")
+	}
+	if err := validateServiceAccountTokenAudiences(audiences); err != nil {
+		return nil, err
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: expirationSeconds,
+		},
+	}
+	if err := kubeClient.SubResource("token").Create(ctx, serviceAccount, tokenRequest); err != nil {
+		return nil, fmt.Errorf("error requesting token for service account %s/%s: %w", namespace, name, err)
+	}
+
+	if len(tokenRequest.Status.Token) == 0 {
+		return nil, fmt.Errorf("empty token returned for service account %s/%s", namespace, name)
+	}
+
+	return &tokenRequest.Status, nil
+}
+
+// validateServiceAccountTokenAudiences rejects the audiences that would make the token valid against the kube-apiserver,
+// a token requested without audience gets the kube-apiserver ones
+func validateServiceAccountTokenAudiences(audiences []string) error {
+	if len(audiences) == 0 {
+		return errors.New("an audience is required to request a service account token")
+	}
+	for _, audience := range audiences {
+		if audience == "" {
+			return errors.New("an audience is required to request a service account token")
+		}
+		if slices.Contains(kedav1alpha1.KubernetesAPIServerAudiences, audience) || slices.Contains(getAPIServerAudiences(), audience) {
+			return fmt.Errorf("audience %s is accepted by the kube-apiserver and can't be used", audience)
+		}
+	}
+	return nil
+}
+
+// getAPIServerAudiences returns the audiences of the token mounted into KEDA, they are the ones the kube-apiserver
+// is configured with in the cluster
+func getAPIServerAudiences() []string {
+	apiServerAudiencesOnce.Do(func() {
+		token, err := os.ReadFile(kedaServiceAccountTokenPath)
+		if err != nil {
+			return
+		}
+		apiServerAudiences = getJWTAudiences(strings.TrimSpace(string(token)))
+	})
+	return apiServerAudiences
+}
+
+// getJWTAudiences returns the aud claim of a JWT without verifying it, the claim is either a string or a list
+func getJWTAudiences(token string) []string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil
+	}
+	var claims struct {
+		Audience json.RawMessage `json:"aud"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || len(claims.Audience) == 0 {
+		return nil
+	}
+	var audiences []string
+	if err := json.Unmarshal(claims.Audience, &audiences); err == nil {
+		return audiences
+	}
+	var audience string
+	if err := json.Unmarshal(claims.Audience, &audience); err == nil {
+		return []string{audience}
+	}
+	return nil
+}
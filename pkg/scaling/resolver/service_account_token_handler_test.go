@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestResolveServiceAccountToken(t *testing.T) {
+	workload := "workload"
+	keda := "keda"
+	expirationSeconds := int64(3600)
+
+	tests := []struct {
+		name                   string
+		serviceAccountToken    *kedav1alpha1.AuthServiceAccountToken
+		podSpec                *corev1.PodSpec
+		expectedServiceAccount types.NamespacedName
+		expectedAudiences      []string
+		expectRefresh          bool
+		isError                bool
+	}{
+		{
+			name:                   "workload identity owner by default",
+			serviceAccountToken:    &kedav1alpha1.AuthServiceAccountToken{Audience: "thanos"},
+			podSpec:                &corev1.PodSpec{ServiceAccountName: "workload-sa"},
+			expectedServiceAccount: types.NamespacedName{Namespace: namespace, Name: "workload-sa"},
+			expectedAudiences:      []string{"thanos"},
+		},
+		{
+			name:                   "keda identity owner",
+			serviceAccountToken:    &kedav1alpha1.AuthServiceAccountToken{Audience: "thanos", IdentityOwner: &keda, ExpirationSeconds: &expirationSeconds},
+			expectedServiceAccount: types.NamespacedName{Namespace: kedaServiceAccountNamespace, Name: kedaServiceAccountName},
+			expectedAudiences:      []string{"thanos"},
+			expectRefresh:          true,
+		},
+		{
+			name:                   "workload identity owner",
+			serviceAccountToken:    &kedav1alpha1.AuthServiceAccountToken{Audience: "thanos", IdentityOwner: &workload, ExpirationSeconds: &expirationSeconds},
+			podSpec:                &corev1.PodSpec{ServiceAccountName: "workload-sa"},
+			expectedServiceAccount: types.NamespacedName{Namespace: namespace, Name: "workload-sa"},
+			expectedAudiences:      []string{"thanos"},
+			expectRefresh:          true,
+		},
+		{
+			name:                   "workload identity owner with default service account",
+			serviceAccountToken:    &kedav1alpha1.AuthServiceAccountToken{Audience: "thanos", IdentityOwner: &workload},
+			podSpec:                &corev1.PodSpec{},
+			expectedServiceAccount: types.NamespacedName{Namespace: namespace, Name: defaultServiceAccountName},
+			expectedAudiences:      []string{"thanos"},
+		},
+		{
+			name:                "without audience",
+			serviceAccountToken: &kedav1alpha1.AuthServiceAccountToken{IdentityOwner: &workload},
+			podSpec:             &corev1.PodSpec{},
+			isError:             true,
+		},
+		{
+			name:                "kube-apiserver audience",
+			serviceAccountToken: &kedav1alpha1.AuthServiceAccountToken{Audience: "https://kubernetes.default.svc.cluster.local", IdentityOwner: &workload},
+			podSpec:             &corev1.PodSpec{},
+			isError:             true,
+		},
+		{
+			name:                "workload identity owner without pod spec",
+			serviceAccountToken: &kedav1alpha1.AuthServiceAccountToken{IdentityOwner: &workload},
+			isError:             true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeClient := &tokenRequestClient{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+			before := time.Now()
+			token, refreshAt, err := resolveServiceAccountToken(context.Background(), kubeClient, test.serviceAccountToken, test.podSpec, namespace)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, vaultTestJWT, token)
+			assert.Equal(t, test.expectedServiceAccount, kubeClient.serviceAccount)
+			assert.Equal(t, test.expectedAudiences, kubeClient.audiences)
+			if !test.expectRefresh {
+				assert.True(t, refreshAt.IsZero())
+				return
+			}
+			// the token is requested again after 2/3 of its lifetime
+			assert.WithinDuration(t, before.Add(40*time.Minute), refreshAt, 5*time.Second)
+		})
+	}
+}
+
+func TestGetJWTAudiences(t *testing.T) {
+	encode := func(claims string) string {
+		return "e30." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+	}
+
+	assert.Equal(t, []string{"https://oidc.example.com"}, getJWTAudiences(encode(`{"aud":["https://oidc.example.com"],"sub":"system:serviceaccount:keda:keda-operator"}`)))
+	assert.Equal(t, []string{"api"}, getJWTAudiences(encode(`{"aud":"api"}`)))
+	assert.Nil(t, getJWTAudiences(encode(`{"sub":"system:serviceaccount:keda:keda-operator"}`)))
+	assert.Nil(t, getJWTAudiences("not-a-jwt"))
+}
+
+func TestValidateKedaIdentityOwner(t *testing.T) {
+	assert.NoError(t, validateKedaIdentityOwner(&kedav1alpha1.AuthenticationRef{Name: "ta"}, true))
+	assert.NoError(t, validateKedaIdentityOwner(&kedav1alpha1.AuthenticationRef{Name: "cta", Kind: "ClusterTriggerAuthentication"}, true))
+	assert.NoError(t, validateKedaIdentityOwner(&kedav1alpha1.AuthenticationRef{Name: "cta", Kind: "ClusterTriggerAuthentication"}, false))
+	assert.Error(t, validateKedaIdentityOwner(&kedav1alpha1.AuthenticationRef{Name: "ta"}, false))
+	assert.Error(t, validateKedaIdentityOwner(&kedav1alpha1.AuthenticationRef{Name: "ta", Kind: "TriggerAuthentication"}, false))
+}
@@ -60,6 +60,7 @@ type ScaleHandler interface {
 	DeleteScalableObject(ctx context.Context, scalableObject interface{}) error
 	GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error)
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
+	ClearScalersCachesForAuthentications(ctx context.Context, authenticationKeys []resolver.AuthenticationKey)
 
 	GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error)
 }
@@ -233,6 +234,9 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) {
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
+
+	h.clearScalersCacheIfAuthParamsOutdated(ctx, scalableObject)
+
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
 		err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
@@ -246,7 +250,11 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			return
 		}
 
-		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
+		if obj.IsUsingDirectScaling() {
+			h.scaleExecutor.RequestDirectScale(ctx, obj, isActive, isError, h.getDirectScalingMetrics(ctx, obj, metricsRecords))
+		} else {
+			h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
+		}
 
 		if len(metricsRecords) > 0 {
 			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
@@ -363,6 +371,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 		Scalers:                  scalers,
 		ScalableObjectGeneration: withTriggers.Generation,
 		Recorder:                 h.recorder,
+		AuthenticationKeys:       getAuthenticationKeys(withTriggers),
 	}
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
@@ -376,6 +385,16 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 			newCache.CompiledFormula = program
 		}
 		newCache.ScaledObject = obj
+	case *kedav1alpha1.ScaledJob:
+		if obj.IsUsingFormula() {
+			// validate scalingStrategy formula and compile it
+			program, err := kedav1alpha1.ValidateAndCompileScalingStrategyFormula(obj)
+			if err != nil {
+				log.Error(err, "error validating-compiling scalingStrategy formula")
+				return nil, err
+			}
+			newCache.CompiledFormula = program
+		}
 	default:
 	}
 
@@ -416,6 +435,64 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 	return nil
 }
 
+// ClearScalersCachesForAuthentications invalidates caches of all the scalableObjects referencing any of the input
+// authentication objects, eg. when a Secret they were resolved from has changed
+func (h *scaleHandler) ClearScalersCachesForAuthentications(ctx context.Context, authenticationKeys []resolver.AuthenticationKey) {
+	if len(authenticationKeys) == 0 {
+		return
+	}
+
+	h.scalerCachesLock.Lock()
+	defer h.scalerCachesLock.Unlock()
+	for key, cache := range h.scalerCaches {
+		if !cache.UsesAuthentication(authenticationKeys) {
+			continue
+		}
+		log.V(1).WithValues("key", key).Info("Referenced authentication has changed, removing entry from ScalersCache")
+		go h.scaledObjectsMetricCache.Delete(key)
+		cache.Close(ctx)
+		delete(h.scalerCaches, key)
+	}
+}
+
+// getAuthenticationKeys returns the keys of the authentication objects referenced by the triggers
+func getAuthenticationKeys(withTriggers *kedav1alpha1.WithTriggers) []resolver.AuthenticationKey {
+	var keys []resolver.AuthenticationKey
+	for _, trigger := range withTriggers.Spec.Triggers {
+		if trigger.AuthenticationRef != nil && trigger.AuthenticationRef.Name != "" {
+			keys = append(keys, resolver.GetAuthenticationKey(trigger.AuthenticationRef, withTriggers.Namespace))
+		}
+	}
+	return keys
+}
+
+// clearScalersCacheIfAuthParamsOutdated invalidates cache for the input scalableObject when the resolved authentication
+// parameters of any of its scalers are about to expire (eg: Vault dynamic secrets) or the files they were read from
+// have changed, so the scalers are rebuilt with fresh credentials before they start failing
+func (h *scaleHandler) clearScalersCacheIfAuthParamsOutdated(ctx context.Context, scalableObject interface{}) {
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
+	if err != nil {
+		return
+	}
+
+	key := withTriggers.GenerateIdentifier()
+	h.scalerCachesLock.RLock()
+	cache, ok := h.scalerCaches[key]
+	h.scalerCachesLock.RUnlock()
+	if !ok {
+		return
+	}
+
+	if !cache.AreAuthParamsOutdated(time.Now()) {
+		return
+	}
+
+	log.V(1).Info("Authentication parameters are outdated, clearing ScalersCache", "key", key)
+	if err := h.ClearScalersCache(ctx, scalableObject); err != nil {
+		log.Error(err, "error clearing scalers cache", "key", key)
+	}
+}
+
 /// --------------------------------------------------------------------------- ///
 /// ----------             ScaledObject related methods               --------- ///
 /// --------------------------------------------------------------------------- ///
@@ -693,6 +770,38 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	return isScaledObjectActive, isScaledObjectError, metricsRecord, err
 }
 
+// getDirectScalingMetrics pairs the metric values from metrics records with the targets
+// from metric specs, so the replica count can be computed in direct scaling mode
+func (h *scaleHandler) getDirectScalingMetrics(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metricsRecords map[string]metricscache.MetricsRecord) []executor.DirectScalingMetric {
+	cache, err := h.GetScalersCache(ctx, scaledObject)
+	if err != nil {
+		log.Error(err, "error getting scalers cache", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+		return nil
+	}
+
+	var metrics []executor.DirectScalingMetric
+	for _, spec := range cache.GetMetricSpecForScaling(ctx) {
+		if spec.External == nil {
+			continue
+		}
+		metricName := spec.External.Metric.Name
+		record, ok := metricsRecords[metricName]
+		if !ok || record.ScalerError != nil {
+			continue
+		}
+		value := float64(0)
+		for _, metric := range record.Metric {
+			value += metric.Value.AsApproximateFloat64()
+		}
+		metrics = append(metrics, executor.DirectScalingMetric{
+			MetricName: metricName,
+			Value:      value,
+			Target:     spec.External.Target,
+		})
+	}
+	return metrics
+}
+
 // scalerState is used as return
 // for the function getScalerState. It contains
 // the state of the scaler and all the required
@@ -748,7 +857,8 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 		result.Metrics = append(result.Metrics, metrics...)
 		logger.V(1).Info("Getting metrics and activity from scaler", "scaler", triggerName, "metricName", metricName, "metrics", metrics, "activity", isMetricActive, "scalerError", err)
 
-		if scalerConfig.TriggerUseCachedMetrics {
+		// metrics records are needed for computing the replica count in direct scaling mode as well
+		if scalerConfig.TriggerUseCachedMetrics || scaledObject.IsUsingDirectScaling() {
 			result.Records[metricName] = metricscache.MetricsRecord{
 				IsActive:    isMetricActive,
 				Metric:      metrics,
@@ -846,6 +956,7 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
 
 			scalersMetrics = append(scalersMetrics, scaledjob.ScalerMetrics{
+				Name:        scalerConfigs[scalerIndex].TriggerName,
 				QueueLength: queueLength,
 				MaxValue:    maxValue,
 				IsActive:    isActive,
@@ -878,6 +989,16 @@ func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1a
 	logger := logf.Log.WithName("scalemetrics")
 
 	scalersMetrics := h.getScaledJobMetrics(ctx, scaledJob)
+
+	if scaledJob.IsUsingFormula() {
+		isActive, queueLength, maxValue, maxFloatValue, err := h.isScaledJobActiveWithFormula(ctx, scaledJob, scalersMetrics)
+		if err == nil {
+			logger.V(1).WithValues("scaledJob.Name", scaledJob.Name).Info("Checking if ScaleJob Scalers are active", "isActive", isActive, "maxValue", maxFloatValue, "Formula", scaledJob.Spec.ScalingStrategy.Formula)
+			return isActive, queueLength, maxValue
+		}
+		logger.Error(err, "error applying scalingStrategy formula, falling back to default calculation", "scaledJob.Name", scaledJob.Name)
+	}
+
 	isActive, queueLength, maxValue, maxFloatValue :=
 		scaledjob.IsScaledJobActive(scalersMetrics, scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation, scaledJob.MinReplicaCount(), scaledJob.MaxReplicaCount())
 
@@ -885,6 +1006,24 @@ func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1a
 	return isActive, queueLength, maxValue
 }
 
+// isScaledJobActiveWithFormula applies the compiled ScalingStrategy formula stored
+// in the scalers cache to the collected scalers metrics
+func (h *scaleHandler) isScaledJobActiveWithFormula(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, scalersMetrics []scaledjob.ScalerMetrics) (bool, int64, int64, float64, error) {
+	cache, err := h.GetScalersCache(ctx, scaledJob)
+	if err != nil {
+		return false, 0, 0, 0, err
+	}
+
+	triggerNames := make([]string, 0, len(scaledJob.Spec.Triggers))
+	for _, trigger := range scaledJob.Spec.Triggers {
+		if trigger.Name != "" {
+			triggerNames = append(triggerNames, trigger.Name)
+		}
+	}
+
+	return scaledjob.IsScaledJobActiveWithFormula(scalersMetrics, triggerNames, cache.CompiledFormula, scaledJob.MinReplicaCount(), scaledJob.MaxReplicaCount())
+}
+
 // getTrueMetricArray is a help function made for composite scaler to determine
 // what metrics should be used. In case of composite scaler (ScalingModifiers struct),
 // all external metrics will be used. Returns all external metrics otherwise it
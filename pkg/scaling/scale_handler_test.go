@@ -37,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -46,6 +47,7 @@ import (
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 const testNamespaceGlobal = "testNamespace"
@@ -435,6 +437,102 @@ func TestCheckScaledObjectScalersWithError(t *testing.T) {
 	assert.Equal(t, true, isError)
 }
 
+func TestClearScalersCacheIfAuthParamsOutdated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+	}
+
+	validScaler := mock_scalers.NewMockScaler(ctrl)
+	expiredScaler := mock_scalers.NewMockScaler(ctrl)
+	expiredScaler.EXPECT().Close(gomock.Any())
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler: validScaler,
+		}},
+		Recorder: recorder,
+	}
+
+	sh := scaleHandler{
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	// auth params without expiration keep the cache
+	sh.clearScalersCacheIfAuthParamsOutdated(context.TODO(), &scaledObject)
+	assert.Contains(t, caches, scaledObject.GenerateIdentifier())
+
+	// auth params not yet expired keep the cache
+	caches[scaledObject.GenerateIdentifier()].Scalers[0].ScalerConfig.AuthParamsMetadata.Expiration = time.Now().Add(time.Hour)
+	sh.clearScalersCacheIfAuthParamsOutdated(context.TODO(), &scaledObject)
+	assert.Contains(t, caches, scaledObject.GenerateIdentifier())
+
+	// expired leases clear the cache and are revoked
+	lease := &fakeAuthParamsLease{expiration: time.Now().Add(-time.Second)}
+	caches[scaledObject.GenerateIdentifier()].Scalers = []cache.ScalerBuilder{{
+		Scaler:       expiredScaler,
+		ScalerConfig: scalersconfig.ScalerConfig{AuthParamsMetadata: scalersconfig.AuthParamsMetadata{Leases: []scalersconfig.AuthParamsLease{lease}}},
+	}}
+	sh.clearScalersCacheIfAuthParamsOutdated(context.TODO(), &scaledObject)
+	assert.NotContains(t, caches, scaledObject.GenerateIdentifier())
+	assert.True(t, lease.revoked)
+}
+
+type fakeAuthParamsLease struct {
+	expiration time.Time
+	revoked    bool
+}
+
+func (l *fakeAuthParamsLease) Expiration() time.Time {
+	return l.expiration
+}
+
+func (l *fakeAuthParamsLease) Revoke() {
+	l.revoked = true
+}
+
+func TestClearScalersCachesForAuthentications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	authenticationKey := resolver.AuthenticationKey("TriggerAuthentication/test/auth")
+	otherAuthenticationKey := resolver.AuthenticationKey("ClusterTriggerAuthentication/auth")
+
+	referencingScaler := mock_scalers.NewMockScaler(ctrl)
+	referencingScaler.EXPECT().Close(gomock.Any())
+	otherScaler := mock_scalers.NewMockScaler(ctrl)
+
+	caches := map[string]*cache.ScalersCache{
+		"referencing": {
+			Scalers:            []cache.ScalerBuilder{{Scaler: referencingScaler}},
+			Recorder:           recorder,
+			AuthenticationKeys: []resolver.AuthenticationKey{otherAuthenticationKey, authenticationKey},
+		},
+		"other": {
+			Scalers:  []cache.ScalerBuilder{{Scaler: otherScaler}},
+			Recorder: recorder,
+		},
+	}
+
+	sh := scaleHandler{
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	sh.ClearScalersCachesForAuthentications(context.TODO(), []resolver.AuthenticationKey{authenticationKey})
+	assert.NotContains(t, caches, "referencing")
+	assert.Contains(t, caches, "other")
+}
+
 func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockClient := mock_client.NewMockClient(ctrl)
@@ -531,7 +629,16 @@ func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
 	}
 
 	mockClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, gomock.Any()).SetArg(2, deployment)
-	mockClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: triggerAuth.Name, Namespace: triggerAuth.Namespace}, gomock.Any()).SetArg(2, triggerAuth)
+	mockClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: triggerAuth.Name, Namespace: triggerAuth.Namespace}, gomock.Any()).SetArg(2, triggerAuth).Times(2)
+
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	var patchedTriggerAuth *kedav1alpha1.TriggerAuthentication
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, obj runtimeclient.Object, _ runtimeclient.Patch, _ ...runtimeclient.SubResourcePatchOption) error {
+			patchedTriggerAuth = obj.(*kedav1alpha1.TriggerAuthentication)
+			return nil
+		})
 
 	sh := scaleHandler{
 		client:                   mockClient,
@@ -553,6 +660,13 @@ func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
 	failureEvent := <-recorder.Events
 	assert.Contains(t, failureEvent, "KEDAScalerFailed")
 	assert.Contains(t, failureEvent, "unsupported protocol scheme")
+
+	assert.NotNil(t, patchedTriggerAuth)
+	readyCondition := patchedTriggerAuth.Status.Conditions.GetReadyCondition()
+	assert.True(t, readyCondition.IsFalse())
+	assert.Len(t, patchedTriggerAuth.Status.SourceErrors, 1)
+	assert.Equal(t, "hashiCorpVault", patchedTriggerAuth.Status.SourceErrors[0].Source)
+	assert.Nil(t, patchedTriggerAuth.Status.LastSuccessfulResolutionTime)
 }
 
 func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
@@ -1,8 +1,11 @@
 package scaledjob
 
 import (
+	"fmt"
 	"math"
 
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 )
@@ -45,6 +48,7 @@ func CalculateQueueLengthAndMaxValue(metrics []external_metrics.ExternalMetricVa
 }
 
 type ScalerMetrics struct {
+	Name        string
 	QueueLength float64
 	MaxValue    float64
 	IsActive    bool
@@ -107,6 +111,62 @@ func IsScaledJobActive(scalersMetrics []ScalerMetrics, multipleScalersCalculatio
 	return isActive, ceilToInt64(queueLength), ceilToInt64(maxValue), maxValue
 }
 
+// IsScaledJobActiveWithFormula returns whether the input ScaledJob is active and queueLength and maxValue for scale
+// calculated by the compiled ScalingStrategy formula. The formula is evaluated once over the queue lengths
+// and once over the max values of the named triggers, inactive or failing triggers contribute 0.
+func IsScaledJobActiveWithFormula(scalersMetrics []ScalerMetrics, triggerNames []string, compiledFormula *vm.Program, minReplicaCount, maxReplicaCount int64) (bool, int64, int64, float64, error) {
+	if compiledFormula == nil {
+		return false, 0, 0, 0, fmt.Errorf("cached compiled formula is nil during its calculation")
+	}
+
+	isActive := false
+	queueLengths := make(map[string]float64)
+	maxValues := make(map[string]float64)
+	for _, name := range triggerNames {
+		queueLengths[name] = 0
+		maxValues[name] = 0
+	}
+	for _, metrics := range scalersMetrics {
+		if metrics.Name == "" {
+			continue
+		}
+		if metrics.IsActive {
+			queueLengths[metrics.Name] += metrics.QueueLength
+			maxValues[metrics.Name] += metrics.MaxValue
+			isActive = true
+		}
+	}
+
+	queueLength, err := runFormula(compiledFormula, queueLengths)
+	if err != nil {
+		return false, 0, 0, 0, err
+	}
+	maxValue, err := runFormula(compiledFormula, maxValues)
+	if err != nil {
+		return false, 0, 0, 0, err
+	}
+
+	if minReplicaCount > 0 {
+		isActive = true
+	}
+
+	maxValue = math.Max(getMaxValue(maxValue, maxReplicaCount), 0)
+	return isActive, ceilToInt64(math.Max(queueLength, 0)), ceilToInt64(maxValue), maxValue, nil
+}
+
+// runFormula runs the precompiled formula with the given per trigger values
+func runFormula(compiledFormula *vm.Program, data map[string]float64) (float64, error) {
+	out, err := expr.Run(compiledFormula, data)
+	if err != nil {
+		return 0, fmt.Errorf("error trying to run custom formula: %w", err)
+	}
+	value, ok := out.(float64)
+	if !ok {
+		return 0, fmt.Errorf("custom formula returned unexpected type %T", out)
+	}
+	return value, nil
+}
+
 // ceilToInt64 returns the int64 ceil value for the float64 input
 func ceilToInt64(x float64) int64 {
 	return int64(math.Ceil(x))
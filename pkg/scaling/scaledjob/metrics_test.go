@@ -3,6 +3,7 @@ package scaledjob
 import (
 	"testing"
 
+	"github.com/expr-lang/expr"
 	"github.com/stretchr/testify/assert"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -59,3 +60,45 @@ func createMetricSpec(averageValue int64, metricName string) v2.MetricSpec {
 		},
 	}
 }
+
+func TestIsScaledJobActiveWithFormula(t *testing.T) {
+	scalersMetrics := []ScalerMetrics{
+		{Name: "a", QueueLength: 20, MaxValue: 10, IsActive: true},
+		{Name: "b", QueueLength: 60, MaxValue: 30, IsActive: true},
+		{Name: "c", QueueLength: 100, MaxValue: 50, IsActive: false},
+	}
+	triggerNames := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		formula             string
+		maxReplicaCount     int64
+		expectedQueueLength int64
+		expectedMaxValue    int64
+	}{
+		{formula: "a + b", maxReplicaCount: 100, expectedQueueLength: 80, expectedMaxValue: 40},
+		{formula: "max(a, b * 0.5)", maxReplicaCount: 100, expectedQueueLength: 30, expectedMaxValue: 15},
+		{formula: "a + c + d", maxReplicaCount: 100, expectedQueueLength: 20, expectedMaxValue: 10},
+		{formula: "a + b", maxReplicaCount: 25, expectedQueueLength: 80, expectedMaxValue: 25},
+		{formula: "a - b", maxReplicaCount: 100, expectedQueueLength: 0, expectedMaxValue: 0},
+	}
+
+	for _, test := range tests {
+		program, err := expr.Compile("float("+test.formula+")", expr.AsFloat64())
+		assert.NoError(t, err, test.formula)
+
+		isActive, queueLength, maxValue, _, err := IsScaledJobActiveWithFormula(scalersMetrics, triggerNames, program, 0, test.maxReplicaCount)
+		assert.NoError(t, err, test.formula)
+		assert.True(t, isActive, test.formula)
+		assert.Equal(t, test.expectedQueueLength, queueLength, test.formula)
+		assert.Equal(t, test.expectedMaxValue, maxValue, test.formula)
+	}
+
+	_, _, _, _, err := IsScaledJobActiveWithFormula(scalersMetrics, triggerNames, nil, 0, 100)
+	assert.Error(t, err)
+
+	program, err := expr.Compile("float(c)", expr.AsFloat64())
+	assert.NoError(t, err)
+	isActive, _, _, _, err := IsScaledJobActiveWithFormula(scalersMetrics[2:], triggerNames, program, 0, 100)
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+}
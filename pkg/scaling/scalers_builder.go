@@ -69,7 +69,7 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				TriggerUniqueKey:        fmt.Sprintf("%s-%s-%s-%d", withTriggers.Kind, withTriggers.Namespace, withTriggers.Name, triggerIndex),
 			}
 
-			authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
+			authParams, podIdentity, authParamsMetadata, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
 			switch podIdentity.Provider {
 			case kedav1alpha1.PodIdentityProviderAzure:
 				// FIXME: Delete this for v2.15
@@ -87,8 +87,12 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				return nil, nil, err
 			}
 			config.AuthParams = authParams
+			config.AuthParamsMetadata = authParamsMetadata
 			config.PodIdentity = podIdentity
 			scaler, err := buildScaler(ctx, h.client, trigger.Type, config)
+			if err != nil {
+				authParamsMetadata.Revoke()
+			}
 			return scaler, config, err
 		}
 
@@ -102,6 +106,7 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 			}
 			for _, builder := range result {
 				builder.Scaler.Close(ctx)
+				builder.ScalerConfig.AuthParamsMetadata.Revoke()
 			}
 			return nil, err
 		}
@@ -160,6 +165,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAzureServiceBusScaler(ctx, config)
 	case "cassandra":
 		return scalers.NewCassandraScaler(config)
+	case "clickhouse":
+		return scalers.NewClickHouseScaler(config)
 	case "couchdb":
 		return scalers.NewCouchDBScaler(ctx, config)
 	case "cpu":
@@ -199,6 +206,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewInfluxDBScaler(config)
 	case "kafka":
 		return scalers.NewKafkaScaler(config)
+	case "kubernetes-resource":
+		return scalers.NewKubernetesResourceScaler(client, config)
 	case "kubernetes-workload":
 		return scalers.NewKubernetesWorkloadScaler(client, config)
 	case "liiklus":
@@ -211,20 +220,26 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewMetricsAPIScaler(config)
 	case "mongodb":
 		return scalers.NewMongoDBScaler(ctx, config)
+	case "mqtt":
+		return scalers.NewMQTTScaler(config)
 	case "mssql":
-		return scalers.NewMSSQLScaler(config)
+		return scalers.NewSQLScaler(triggerType, config)
 	case "mysql":
-		return scalers.NewMySQLScaler(config)
+		return scalers.NewSQLScaler(triggerType, config)
 	case "nats-jetstream":
 		return scalers.NewNATSJetStreamScaler(config)
 	case "new-relic":
 		return scalers.NewNewRelicScaler(config)
+	case "nsq":
+		return scalers.NewNSQScaler(config)
 	case "openstack-metric":
 		return scalers.NewOpenstackMetricScaler(ctx, config)
 	case "openstack-swift":
 		return scalers.NewOpenstackSwiftScaler(config)
+	case "otlp":
+		return scalers.NewOTLPScaler(config)
 	case "postgresql":
-		return scalers.NewPostgreSQLScaler(config)
+		return scalers.NewSQLScaler(triggerType, config)
 	case "predictkube":
 		return scalers.NewPredictKubeScaler(ctx, config)
 	case "prometheus":
@@ -237,10 +252,16 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewRedisScaler(ctx, false, false, config)
 	case "redis-cluster":
 		return scalers.NewRedisScaler(ctx, true, false, config)
+	case "redis-cluster-jobqueue":
+		return scalers.NewRedisJobQueueScaler(ctx, true, false, config)
 	case "redis-cluster-streams":
 		return scalers.NewRedisStreamsScaler(ctx, true, false, config)
+	case "redis-jobqueue":
+		return scalers.NewRedisJobQueueScaler(ctx, false, false, config)
 	case "redis-sentinel":
 		return scalers.NewRedisScaler(ctx, false, true, config)
+	case "redis-sentinel-jobqueue":
+		return scalers.NewRedisJobQueueScaler(ctx, false, true, config)
 	case "redis-sentinel-streams":
 		return scalers.NewRedisStreamsScaler(ctx, false, true, config)
 	case "redis-streams":
@@ -251,8 +272,12 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewSolaceScaler(config)
 	case "solr":
 		return scalers.NewSolrScaler(config)
+	case "sql":
+		return scalers.NewSQLScaler(triggerType, config)
 	case "stan":
 		return scalers.NewStanScaler(config)
+	case "temporal":
+		return scalers.NewTemporalScaler(config)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
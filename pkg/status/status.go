@@ -92,8 +92,8 @@ func getTriggerAuth(ctx context.Context, client runtimeclient.Client, triggerAut
 	return nil, nil, fmt.Errorf("unknown trigger auth kind %s", triggerAuthRef.Kind)
 }
 
-// updateTriggerAuthenticationStatus patches TriggerAuthentication/ClusterTriggerAuthentication from AuthenticationRef with the status that updated by statushanler function or returns an error.
-func updateTriggerAuthenticationStatus(ctx context.Context, logger logr.Logger, client runtimeclient.Client, namespace string, triggerAuthRef *kedav1alpha1.AuthenticationRef, statusHandler func(*kedav1alpha1.TriggerAuthenticationStatus) *kedav1alpha1.TriggerAuthenticationStatus) error {
+// UpdateTriggerAuthenticationStatus patches TriggerAuthentication/ClusterTriggerAuthentication from AuthenticationRef with the status that updated by statushanler function or returns an error.
+func UpdateTriggerAuthenticationStatus(ctx context.Context, logger logr.Logger, client runtimeclient.Client, namespace string, triggerAuthRef *kedav1alpha1.AuthenticationRef, statusHandler func(*kedav1alpha1.TriggerAuthenticationStatus) *kedav1alpha1.TriggerAuthenticationStatus) error {
 	triggerAuth, triggerAuthStatus, err := getTriggerAuth(ctx, client, triggerAuthRef, namespace)
 
 	if err != nil {
@@ -105,6 +105,10 @@ func updateTriggerAuthenticationStatus(ctx context.Context, logger logr.Logger,
 	}
 
 	triggerAuthenticationStatus := statusHandler(triggerAuthStatus.DeepCopy())
+	if triggerAuthenticationStatus == nil {
+		// the handler left the status unchanged, there is nothing to patch
+		return nil
+	}
 
 	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
 		status, ok := target.(*kedav1alpha1.TriggerAuthenticationStatus)
@@ -136,7 +140,7 @@ func UpdateTriggerAuthenticationStatusFromTriggers(ctx context.Context, logger l
 			continue
 		}
 
-		err := updateTriggerAuthenticationStatus(ctx, logger, client, namespace, trigger.AuthenticationRef, statusHandler)
+		err := UpdateTriggerAuthenticationStatus(ctx, logger, client, namespace, trigger.AuthenticationRef, statusHandler)
 		if err != nil {
 			errs = errors.Wrap(errs, err.Error())
 		}
@@ -17,12 +17,25 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-const RestrictSecretAccessEnvVar = "KEDA_RESTRICT_SECRET_ACCESS"
+const (
+	RestrictSecretAccessEnvVar           = "KEDA_RESTRICT_SECRET_ACCESS"
+	AuthFilePathRootEnvVar               = "KEDA_AUTH_FILE_PATH_ROOT"
+	DefaultAuthFilePathRoot              = "/mnt/secrets-store"
+	ServiceAccountNameEnvVar             = "KEDA_SERVICE_ACCOUNT_NAME"
+	DefaultServiceAccountName            = "keda-operator"
+	KMSAESKeyfileEnvVar                  = "KEDA_KMS_AES_KEYFILE"
+	RequireEncryptedInlineSecretsEnvVar  = "KEDA_REQUIRE_ENCRYPTED_INLINE_SECRETS"
+	KubernetesResourceAllowedKindsEnvVar = "KEDA_KUBERNETES_RESOURCE_ALLOWED_KINDS"
+)
 
 var clusterObjectNamespaceCache *string
 
@@ -90,3 +103,70 @@ func GetPodNamespace() string {
 func GetRestrictSecretAccess() string {
 	return os.Getenv(RestrictSecretAccessEnvVar)
 }
+
+// GetAuthFilePathRoot retrieves the root directory of the files allowed to be used in ClusterTriggerAuthentication filePath,
+// it is defined by KEDA_AUTH_FILE_PATH_ROOT environment variable, default is /mnt/secrets-store
+func GetAuthFilePathRoot() string {
+	if root := os.Getenv(AuthFilePathRootEnvVar); root != "" {
+		return root
+	}
+	return DefaultAuthFilePathRoot
+}
+
+// GetServiceAccountName retrieves the name of the ServiceAccount KEDA is running with,
+// it is defined by KEDA_SERVICE_ACCOUNT_NAME environment variable, default is keda-operator
+func GetServiceAccountName() string {
+	if name := os.Getenv(ServiceAccountNameEnvVar); name != "" {
+		return name
+	}
+	return DefaultServiceAccountName
+}
+
+// GetKMSAESKeyfile retrieves the path of the keyfile used to decrypt inline values encrypted with aes-keyfile kms provider,
+// it is defined by KEDA_KMS_AES_KEYFILE environment variable, the provider is disabled when it isn't set
+func GetKMSAESKeyfile() string {
+	return os.Getenv(KMSAESKeyfileEnvVar)
+}
+
+// GetRequireEncryptedInlineSecrets retrieves whether inline secret values have to be encrypted,
+// it is defined by KEDA_REQUIRE_ENCRYPTED_INLINE_SECRETS environment variable, default is false
+func GetRequireEncryptedInlineSecrets() (bool, error) {
+	return ResolveOsEnvBool(RequireEncryptedInlineSecretsEnvVar, false)
+}
+
+// GetKubernetesResourceAllowedKinds retrieves the kinds the kubernetes-resource scaler is allowed to read, as a comma separated
+// list of apiVersion/kind like batch/v1/Job,v1/ConfigMap. It is defined by KEDA_KUBERNETES_RESOURCE_ALLOWED_KINDS environment
+// variable, the scaler can't read any kind when it isn't set
+func GetKubernetesResourceAllowedKinds() ([]schema.GroupVersionKind, error) {
+	var kinds []schema.GroupVersionKind
+	for _, value := range strings.Split(os.Getenv(KubernetesResourceAllowedKindsEnvVar), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		i := strings.LastIndex(value, "/")
+		if i <= 0 || i == len(value)-1 {
+			return nil, fmt.Errorf("invalid %s entry %q, it must be apiVersion/kind", KubernetesResourceAllowedKindsEnvVar, value)
+		}
+		groupVersion, err := schema.ParseGroupVersion(value[:i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s entry %q: %w", KubernetesResourceAllowedKindsEnvVar, value, err)
+		}
+		kinds = append(kinds, groupVersion.WithKind(value[i+1:]))
+	}
+	return kinds, nil
+}
+
+// IsKubernetesResourceKindAllowed returns whether the kubernetes-resource scaler is allowed to read the kind
+func IsKubernetesResourceKindAllowed(gvk schema.GroupVersionKind) (bool, error) {
+	kinds, err := GetKubernetesResourceAllowedKinds()
+	if err != nil {
+		return false, err
+	}
+	for _, kind := range kinds {
+		if kind == gvk {
+			return true, nil
+		}
+	}
+	return false, nil
+}
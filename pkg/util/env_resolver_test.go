@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestResolveMissingOsEnvDuration(t *testing.T) {
@@ -41,3 +42,27 @@ func TestResolveValidOsEnvDuration(t *testing.T) {
 	assert.Equal(t, time.Duration(30)*time.Minute, *actual)
 	assert.Nil(t, err)
 }
+
+func TestGetKubernetesResourceAllowedKinds(t *testing.T) {
+	kinds, err := GetKubernetesResourceAllowedKinds()
+	assert.Nil(t, err)
+	assert.Empty(t, kinds, "no kind is allowed by default")
+
+	t.Setenv(KubernetesResourceAllowedKindsEnvVar, "batch/v1/Job, v1/ConfigMap,")
+	kinds, err = GetKubernetesResourceAllowedKinds()
+	assert.Nil(t, err)
+	assert.Equal(t, []schema.GroupVersionKind{{Group: "batch", Version: "v1", Kind: "Job"}, {Version: "v1", Kind: "ConfigMap"}}, kinds)
+
+	allowed, err := IsKubernetesResourceKindAllowed(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+	allowed, err = IsKubernetesResourceKindAllowed(schema.GroupVersionKind{Version: "v1", Kind: "Secret"})
+	assert.Nil(t, err)
+	assert.False(t, allowed)
+
+	for _, invalid := range []string{"Job", "batch/v1/", "a/b/c/Job"} {
+		t.Setenv(KubernetesResourceAllowedKindsEnvVar, invalid)
+		_, err = GetKubernetesResourceAllowedKinds()
+		assert.NotNil(t, err, invalid)
+	}
+}
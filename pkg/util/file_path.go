@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// GetNamespacedAuthFilePathRoot returns the directory of the files allowed to be used in the filePath of
+// TriggerAuthentications from the input namespace, it is the subdirectory of the namespace in the root directory
+func GetNamespacedAuthFilePathRoot(root, namespace string) string {
+	return filepath.Join(root, namespace)
+}
+
+// ResolvePathWithinRoot returns the cleaned absolute path of the input path, relative paths are joined to the root.
+// An error is returned if the path is outside of the root directory.
+func ResolvePathWithinRoot(root, path string) (string, error) {
+	if root == "" || path == "" {
+		return "", fmt.Errorf("root and path are required")
+	}
+
+	root = filepath.Clean(root)
+	resolved := filepath.Clean(path)
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(root, resolved)
+	}
+
+	if !IsPathWithinRoot(root, resolved) {
+		return "", fmt.Errorf("path %s is outside of the allowed root %s", path, root)
+	}
+	return resolved, nil
+}
+
+// IsPathWithinRoot checks whether the cleaned path is the root directory or is located under it
+func IsPathWithinRoot(root, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(root), filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
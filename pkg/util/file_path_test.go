@@ -0,0 +1,36 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestResolvePathWithinRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "relative path", root: "/mnt/secrets-store", path: "db/password", expected: "/mnt/secrets-store/db/password"},
+		{name: "absolute path within root", root: "/mnt/secrets-store/", path: "/mnt/secrets-store/password", expected: "/mnt/secrets-store/password"},
+		{name: "relative path with clean traversal", root: "/mnt/secrets-store", path: "db/../password", expected: "/mnt/secrets-store/password"},
+		{name: "relative path escaping root", root: "/mnt/secrets-store", path: "../etc/passwd", wantErr: true},
+		{name: "absolute path outside root", root: "/mnt/secrets-store", path: "/etc/passwd", wantErr: true},
+		{name: "sibling directory with same prefix", root: "/mnt/secrets-store", path: "/mnt/secrets-store-other/password", wantErr: true},
+		{name: "empty path", root: "/mnt/secrets-store", path: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolvePathWithinRoot(tt.root, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolvePathWithinRoot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("ResolvePathWithinRoot() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}